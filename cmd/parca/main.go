@@ -17,6 +17,7 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/alecthomas/kong"
 	"github.com/common-nighthawk/go-figure"
@@ -31,17 +32,33 @@ var (
 	commit  = "dev"
 )
 
+type cli struct {
+	parca.Flags
+
+	Serve  struct{}          `cmd:"" default:"1" hidden:"" help:"Run the Parca server."`
+	Replay parca.ReplayFlags `cmd:"" help:"Replay a debug value log into a Parca server, or an in-process store if no store address is given."`
+}
+
 func main() {
 	ctx := context.Background()
-	flags := &parca.Flags{}
+	c := &cli{}
+	flags := &c.Flags
 
-	kong.Parse(flags)
+	kctx := kong.Parse(c)
 
 	if flags.Version {
 		fmt.Printf("parca, version %s (commit: %s)\n", version, commit)
 		return
 	}
 
+	if strings.HasPrefix(kctx.Command(), "replay") {
+		logger := parca.NewLogger(flags.LogLevel, parca.LogFormatLogfmt, "parca")
+		if err := parca.RunReplay(ctx, logger, prometheus.NewRegistry(), flags, &c.Replay); err != nil {
+			os.Exit(1)
+		}
+		return
+	}
+
 	serverStr := figure.NewColorFigure("Parca", "roman", "cyan", true)
 	serverStr.Print()
 
@@ -18,15 +18,29 @@ import (
 	"fmt"
 
 	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"google.golang.org/protobuf/types/known/durationpb"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
+// MaxQueryRangeSteps is the maximum number of steps of a QueryRangeRequest.
+const MaxQueryRangeSteps = 11000
+
 // Validate the QueryRangeRequest.
 func (r *QueryRangeRequest) Validate() error {
 	return validation.ValidateStruct(r,
 		validation.Field(&r.Start, validation.Required),
 		validation.Field(&r.End, validation.Required, isAfter(r.Start)),
 		validation.Field(&r.Query, validation.Required),
+		validation.Field(&r.Step, isStep(r.Start, r.End)),
+		validation.Field(&r.StepFunction, isStepFunction()),
+	)
+}
+
+// Validate the SeriesRequest.
+func (r *SeriesRequest) Validate() error {
+	return validation.ValidateStruct(r,
+		validation.Field(&r.Start, validation.Required),
+		validation.Field(&r.End, validation.Required, isAfter(r.Start)),
 	)
 }
 
@@ -46,11 +60,39 @@ func (r *QueryRequest) Validate() error {
 			&r.ReportType,
 			isReportType(),
 		),
+		validation.Field(
+			&r.Function,
+			validation.When(
+				r.ReportType == QueryRequest_REPORT_TYPE_CALLGRAPH_NEIGHBORHOOD ||
+					r.ReportType == QueryRequest_REPORT_TYPE_DISASSEMBLY,
+				validation.Required,
+			),
+		),
 	)
 	if err != nil {
 		return err
 	}
 
+	if r.ReportType == QueryRequest_REPORT_TYPE_SOURCE {
+		o := r.GetSourceOptions()
+		if o.GetFilename() == "" && o.GetFunction() == "" {
+			return fmt.Errorf("source reports require a filename or a function")
+		}
+		if o.GetFilename() != "" && o.GetFunction() != "" {
+			return fmt.Errorf("source reports require either a filename or a function, not both")
+		}
+	}
+
+	if o := r.GetGraphOptions(); o != nil {
+		err := validation.ValidateStruct(o,
+			validation.Field(&o.NodeFraction, validation.Min(float32(0)), validation.Max(float32(1))),
+			validation.Field(&o.EdgeFraction, validation.Min(float32(0)), validation.Max(float32(1))),
+		)
+		if err != nil {
+			return err
+		}
+	}
+
 	switch r.Mode {
 	case QueryRequest_MODE_SINGLE_UNSPECIFIED:
 		err := validateSingle(r.GetSingle())
@@ -150,6 +192,9 @@ func validateProfileSelection(sel *ProfileDiffSelection) error {
 		if err != nil {
 			return err
 		}
+		if len(sel.GetMerge().GetGroupBy()) > 0 {
+			return fmt.Errorf("group_by is not supported in diffs")
+		}
 	default:
 		return fmt.Errorf("invalid mode")
 	}
@@ -272,6 +317,55 @@ func (r DiffSelectionModeRule) Validate(v interface{}) error {
 	return nil
 }
 
+type StepFunctionRule struct{}
+
+func isStepFunction() StepFunctionRule { return StepFunctionRule{} }
+
+func (r StepFunctionRule) Validate(v interface{}) error {
+	i, ok := v.(QueryRangeRequest_StepFunction)
+	if !ok {
+		return fmt.Errorf("step function is not a step function")
+	}
+
+	_, ok = QueryRangeRequest_StepFunction_name[int32(i)]
+	if !ok {
+		return fmt.Errorf("invalid step function")
+	}
+
+	return nil
+}
+
+func isStep(start, end *timestamppb.Timestamp) StepRule {
+	return StepRule{Start: start, End: end}
+}
+
+// StepRule validates that the step is positive if set, and that the time
+// window has at most MaxQueryRangeSteps steps.
+type StepRule struct {
+	Start, End *timestamppb.Timestamp
+}
+
+// Validate runs the validation function for the StepRule.
+func (r StepRule) Validate(v interface{}) error {
+	step, ok := v.(*durationpb.Duration)
+	if !ok {
+		return fmt.Errorf("step is not a duration")
+	}
+	if step == nil {
+		return nil
+	}
+
+	d := step.AsDuration()
+	if d <= 0 {
+		return fmt.Errorf("step must be positive")
+	}
+	if r.Start != nil && r.End != nil && r.End.AsTime().Sub(r.Start.AsTime())/d > MaxQueryRangeSteps {
+		return fmt.Errorf("step must not result in more than %d steps", MaxQueryRangeSteps)
+	}
+
+	return nil
+}
+
 type ReportTypeRule struct{}
 
 func isReportType() ReportTypeRule { return ReportTypeRule{} }
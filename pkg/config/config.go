@@ -19,6 +19,7 @@ import (
 	"io/ioutil"
 	"net/url"
 	"path/filepath"
+	"regexp"
 	"strings"
 	"time"
 
@@ -32,6 +33,10 @@ import (
 	"gopkg.in/yaml.v2"
 
 	"github.com/parca-dev/parca/pkg/debuginfo"
+	"github.com/parca-dev/parca/pkg/filesource"
+	"github.com/parca-dev/parca/pkg/parcacol"
+	"github.com/parca-dev/parca/pkg/profilestore"
+	"github.com/parca-dev/parca/pkg/sourcepath"
 )
 
 const (
@@ -44,14 +49,23 @@ const (
 
 // Config holds all the configuration information for Parca.
 type Config struct {
-	DebugInfo     *debuginfo.Config `yaml:"debug_info"`
-	ScrapeConfigs []*ScrapeConfig   `yaml:"scrape_configs,omitempty"`
+	DebugInfo      *debuginfo.Config    `yaml:"debug_info"`
+	ScrapeConfigs  []*ScrapeConfig      `yaml:"scrape_configs,omitempty"`
+	FileSources    []*filesource.Config `yaml:"file_sources,omitempty"`
+	RedactionRules []*RedactionRule     `yaml:"redaction_rules,omitempty"`
+	SourcePaths    []*SourcePathRule    `yaml:"source_path_mapping,omitempty"`
+	// IngestLimits override the ingest limits configured by flags.
+	IngestLimits *profilestore.IngestLimitsConfig `yaml:"ingest_limits,omitempty"`
 }
 
 // Validate returns an error if the config is not valid.
 func (c *Config) Validate() error {
 	return validation.ValidateStruct(c,
 		validation.Field(&c.DebugInfo, validation.Required, debuginfo.Valid),
+		validation.Field(&c.FileSources),
+		validation.Field(&c.RedactionRules),
+		validation.Field(&c.SourcePaths),
+		validation.Field(&c.IngestLimits),
 	)
 }
 
@@ -106,6 +120,9 @@ func (c *Config) SetDirectory(dir string) {
 	for _, c := range c.ScrapeConfigs {
 		c.SetDirectory(dir)
 	}
+	for _, c := range c.FileSources {
+		c.SetDirectory(dir)
+	}
 }
 
 // Load parses the YAML input s into a Config.
@@ -148,6 +165,8 @@ type ScrapeConfig struct {
 	Scheme string `yaml:"scheme,omitempty"`
 
 	ProfilingConfig *ProfilingConfig `yaml:"profiling_config,omitempty"`
+	// Rewrites applied to the stacks of the scraped profiles at ingest time.
+	StacktraceConfig *StacktraceConfig `yaml:"stacktrace_config,omitempty"`
 
 	RelabelConfigs []*relabel.Config `yaml:"relabel_configs,omitempty"`
 	// We cannot do proper Go type embedding below as the parser will then parse
@@ -175,6 +194,48 @@ type ProfilingConfig struct {
 
 type PprofConfig map[string]*PprofProfilingConfig
 
+// StacktraceConfig configures how stacks are rewritten before they are stored.
+type StacktraceConfig struct {
+	// DropFrames drops frames whose function name fully matches the regex,
+	// along with all of their callees. Mirrors pprof's drop_frames.
+	DropFrames string `yaml:"drop_frames,omitempty"`
+	// KeepFrames exempts frames matching DropFrames from being dropped.
+	// Mirrors pprof's keep_frames.
+	KeepFrames string `yaml:"keep_frames,omitempty"`
+	// MaxDepth is the maximum number of frames a stack is stored with.
+	// Deeper stacks keep their leaf-most frames and a synthetic frame marking
+	// the truncation. Zero means no limit.
+	MaxDepth int `yaml:"max_depth,omitempty"`
+	// MaxStacks is the maximum number of distinct stacks stored per profile
+	// and sample type. The remaining stacks are folded into a single
+	// synthetic stack. Zero means no limit.
+	MaxStacks int `yaml:"max_stacks,omitempty"`
+}
+
+// Validate returns an error if the stacktrace config is not valid.
+func (c *StacktraceConfig) Validate() error {
+	if c.DropFrames != "" {
+		if _, err := regexp.Compile(c.DropFrames); err != nil {
+			return fmt.Errorf("invalid drop_frames regex: %w", err)
+		}
+	}
+	if c.KeepFrames != "" {
+		if c.DropFrames == "" {
+			return errors.New("keep_frames requires drop_frames to be set")
+		}
+		if _, err := regexp.Compile(c.KeepFrames); err != nil {
+			return fmt.Errorf("invalid keep_frames regex: %w", err)
+		}
+	}
+	if c.MaxDepth < 0 || c.MaxDepth == 1 {
+		return errors.New("max_depth must be zero or at least 2")
+	}
+	if c.MaxStacks < 0 {
+		return errors.New("max_stacks must not be negative")
+	}
+	return nil
+}
+
 // UnmarshalYAML implements the yaml.Unmarshaler interface.
 func (c *ScrapeConfig) UnmarshalYAML(unmarshal func(interface{}) error) error {
 	defaults := DefaultScrapeConfig()
@@ -239,6 +300,12 @@ func (c *ScrapeConfig) UnmarshalYAML(unmarshal func(interface{}) error) error {
 		}
 	}
 
+	if c.StacktraceConfig != nil {
+		if err := c.StacktraceConfig.Validate(); err != nil {
+			return fmt.Errorf("%v in %v", err, c.JobName)
+		}
+	}
+
 	// Validate the scrape and timeout internal configuration. When /debug/pprof/profile scraping
 	// is enabled we need to make sure there is enough time to complete the scrape.
 	if c.ScrapeTimeout > c.ScrapeInterval {
@@ -288,3 +355,90 @@ func CheckTargetAddress(address model.LabelValue) error {
 	}
 	return nil
 }
+
+// RedactionRule configures how values of ingested profiles are redacted
+// before they are stored.
+type RedactionRule struct {
+	// Target is one of function_filename, mapping_file, labels or
+	// pprof_labels.
+	Target string `yaml:"target"`
+	// LabelNames limits a labels or pprof_labels rule to the given label
+	// names. All labels are redacted if it is empty.
+	LabelNames []string `yaml:"label_names,omitempty"`
+	// Regex matches the parts of the values that are redacted. Unlike in
+	// relabel configs, it isn't anchored. Defaults to the whole value.
+	Regex string `yaml:"regex,omitempty"`
+	// Action is either replace or hash. Defaults to replace.
+	Action string `yaml:"action,omitempty"`
+	// Replacement of the matches of the replace action, may reference
+	// capture groups like $1.
+	Replacement string `yaml:"replacement,omitempty"`
+	// Salt prepended to the matches of the hash action before hashing.
+	Salt string `yaml:"salt,omitempty"`
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface.
+func (c *RedactionRule) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	type plain RedactionRule
+	unmarshalled := plain{
+		Regex:  ".+",
+		Action: string(parcacol.RedactReplace),
+	}
+	if err := unmarshal(&unmarshalled); err != nil {
+		return err
+	}
+
+	*c = RedactionRule(unmarshalled)
+	return nil
+}
+
+// Validate returns an error if the redaction rule is not valid.
+func (c *RedactionRule) Validate() error {
+	_, err := c.Rule()
+	return err
+}
+
+// Rule returns the redaction rule to configure the ingester with.
+func (c *RedactionRule) Rule() (*parcacol.RedactionRule, error) {
+	return parcacol.NewRedactionRule(
+		parcacol.RedactionTarget(c.Target),
+		c.LabelNames,
+		c.Regex,
+		parcacol.RedactionAction(c.Action),
+		c.Replacement,
+		c.Salt,
+	)
+}
+
+// SourcePathRule rewrites the source paths of functions, both of ingested
+// profiles and of symbolized locations. Of the configured rules, the first
+// matching one is applied.
+type SourcePathRule struct {
+	// TrimPrefix is removed from the start of paths, e.g.
+	// "/home/runner/work/repo/repo/". Mutually exclusive with Regex.
+	TrimPrefix string `yaml:"trim_prefix,omitempty"`
+	// Regex matches the parts of paths that are replaced with the
+	// replacement. Mutually exclusive with TrimPrefix.
+	Regex string `yaml:"regex,omitempty"`
+	// Replacement of the matches of the regex, may reference capture groups
+	// like $1.
+	Replacement string `yaml:"replacement,omitempty"`
+}
+
+// Validate returns an error if the source path rule is not valid.
+func (c *SourcePathRule) Validate() error {
+	_, err := c.Rule()
+	return err
+}
+
+// Rule returns the source path rule to configure the ingester and the
+// symbolizer with.
+func (c *SourcePathRule) Rule() (*sourcepath.Rule, error) {
+	if (c.TrimPrefix == "") == (c.Regex == "") {
+		return nil, errors.New("exactly one of trim_prefix and regex must be set")
+	}
+	if c.TrimPrefix != "" {
+		return sourcepath.NewTrimPrefixRule(c.TrimPrefix)
+	}
+	return sourcepath.NewRegexRule(c.Regex, c.Replacement)
+}
@@ -23,6 +23,8 @@ import (
 	"github.com/thanos-io/objstore/client"
 
 	"github.com/parca-dev/parca/pkg/debuginfo"
+	"github.com/parca-dev/parca/pkg/filesource"
+	"github.com/parca-dev/parca/pkg/profilestore"
 )
 
 func TestLoad(t *testing.T) {
@@ -218,6 +220,21 @@ func Test_Config_Validation(t *testing.T) {
 				},
 			},
 		},
+		"fileSourceWithoutPath": {
+			DebugInfo: &debuginfo.Config{
+				Bucket: &client.BucketConfig{
+					Type: client.FILESYSTEM,
+					Config: map[string]string{
+						"directory": "./tmp",
+					},
+				},
+			},
+			FileSources: []*filesource.Config{{
+				Name:             "ci",
+				FilenameTemplate: "{__name__}.pb.gz",
+				PollInterval:     model.Duration(time.Second),
+			}},
+		},
 	}
 	for name, test := range tests {
 		t.Run(name, func(t *testing.T) {
@@ -225,3 +242,151 @@ func Test_Config_Validation(t *testing.T) {
 		})
 	}
 }
+
+func TestLoadStacktraceConfig(t *testing.T) {
+	c, err := Load(`
+scrape_configs:
+  - job_name: 'parca'
+    static_configs:
+      - targets: [ 'localhost:10902' ]
+    stacktrace_config:
+      drop_frames: 'runtime\..*'
+      keep_frames: 'runtime\.mallocgc'
+      max_depth: 128
+      max_stacks: 1000
+`)
+	require.NoError(t, err)
+	require.Equal(t, &StacktraceConfig{
+		DropFrames: `runtime\..*`,
+		KeepFrames: `runtime\.mallocgc`,
+		MaxDepth:   128,
+		MaxStacks:  1000,
+	}, c.ScrapeConfigs[0].StacktraceConfig)
+
+	_, err = Load(`
+scrape_configs:
+  - job_name: 'parca'
+    stacktrace_config:
+      drop_frames: '('
+`)
+	require.Error(t, err)
+
+	_, err = Load(`
+scrape_configs:
+  - job_name: 'parca'
+    stacktrace_config:
+      max_depth: 1
+`)
+	require.Error(t, err)
+}
+
+func TestLoadFileSources(t *testing.T) {
+	c, err := Load(`
+file_sources:
+  - name: 'ci'
+    path: './profiles'
+    filename_template: '{job}/{__name__}.pb.gz'
+    processed_prefix: 'processed'
+`)
+	require.NoError(t, err)
+	c.SetDirectory("/etc/parca")
+	require.Equal(t, []*filesource.Config{{
+		Name:             "ci",
+		Path:             "/etc/parca/profiles",
+		FilenameTemplate: "{job}/{__name__}.pb.gz",
+		PollInterval:     model.Duration(30 * time.Second),
+		ProcessedPrefix:  "processed",
+	}}, c.FileSources)
+}
+
+func TestLoadRedactionRules(t *testing.T) {
+	c, err := Load(`
+redaction_rules:
+  - target: 'function_filename'
+    regex: '/home/[^/]+/'
+    replacement: '/home/redacted/'
+  - target: 'pprof_labels'
+    label_names: [ 'customer_id' ]
+    action: 'hash'
+`)
+	require.NoError(t, err)
+	require.Equal(t, []*RedactionRule{{
+		Target:      "function_filename",
+		Regex:       "/home/[^/]+/",
+		Action:      "replace",
+		Replacement: "/home/redacted/",
+	}, {
+		Target:     "pprof_labels",
+		LabelNames: []string{"customer_id"},
+		Regex:      ".+",
+		Action:     "hash",
+	}}, c.RedactionRules)
+	for _, r := range c.RedactionRules {
+		require.NoError(t, r.Validate())
+	}
+
+	for _, r := range []*RedactionRule{
+		{Target: "function_name", Regex: ".+", Action: "replace"},
+		{Target: "labels", Regex: "(", Action: "replace"},
+		{Target: "labels", Regex: ".+", Action: "drop"},
+		{Target: "mapping_file", LabelNames: []string{"job"}, Regex: ".+", Action: "replace"},
+	} {
+		require.Error(t, r.Validate())
+	}
+}
+
+func TestLoadSourcePaths(t *testing.T) {
+	c, err := Load(`
+source_path_mapping:
+  - trim_prefix: '/home/runner/work/repo/repo/'
+  - regex: '^/build/[^/]+/'
+`)
+	require.NoError(t, err)
+	require.Equal(t, []*SourcePathRule{
+		{TrimPrefix: "/home/runner/work/repo/repo/"},
+		{Regex: "^/build/[^/]+/"},
+	}, c.SourcePaths)
+	for _, r := range c.SourcePaths {
+		require.NoError(t, r.Validate())
+	}
+
+	for _, r := range []*SourcePathRule{
+		{},
+		{TrimPrefix: "/build/", Regex: "^/build/"},
+		{Regex: "("},
+	} {
+		require.Error(t, r.Validate())
+	}
+}
+
+func TestLoadIngestLimits(t *testing.T) {
+	c, err := Load(`
+ingest_limits:
+  tenants:
+    team-a:
+      profiles_per_second: 10
+      max_active_series: 1000
+  labels:
+    - selector: '{job="noisy"}'
+      bytes_per_second: 1048576
+`)
+	require.NoError(t, err)
+	require.Equal(t, &profilestore.IngestLimitsConfig{
+		Tenants: map[string]profilestore.IngestLimits{
+			"team-a": {ProfilesPerSecond: 10, MaxActiveSeries: 1000},
+		},
+		Labels: []*profilestore.LabelIngestLimits{{
+			Selector:     `{job="noisy"}`,
+			IngestLimits: profilestore.IngestLimits{BytesPerSecond: 1048576},
+		}},
+	}, c.IngestLimits)
+	require.NoError(t, c.IngestLimits.Validate())
+
+	for _, c := range []*profilestore.IngestLimitsConfig{
+		{Tenants: map[string]profilestore.IngestLimits{"team/a": {}}},
+		{Tenants: map[string]profilestore.IngestLimits{"team-a": {MaxActiveSeries: -1}}},
+		{Labels: []*profilestore.LabelIngestLimits{{Selector: `{job=}`}}},
+	} {
+		require.Error(t, c.Validate())
+	}
+}
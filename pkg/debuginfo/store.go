@@ -37,6 +37,7 @@ import (
 	"github.com/parca-dev/parca/pkg/hash"
 	"github.com/parca-dev/parca/pkg/metastore"
 	"github.com/parca-dev/parca/pkg/symbol"
+	"github.com/parca-dev/parca/pkg/symbol/disasm"
 	"github.com/parca-dev/parca/pkg/symbol/elfutils"
 )
 
@@ -333,6 +334,56 @@ func (s *Store) fetchObjectFile(ctx context.Context, buildID string) (string, er
 	return objFile, nil
 }
 
+// Disassemble returns the instructions of the functions of the binary of the
+// build ID that contain the addresses.
+func (s *Store) Disassemble(ctx context.Context, buildID string, addrs []uint64) ([]disasm.Instruction, error) {
+	if err := validateID(buildID); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	objFile, err := s.fetchObjectFile(ctx, buildID)
+	if err != nil {
+		if errors.Is(err, errDebugInfoNotFound) {
+			return nil, status.Errorf(codes.NotFound, "debug info of build ID %q not found", buildID)
+		}
+		return nil, status.Errorf(codes.Internal, "failed to fetch debug info: %v", err)
+	}
+
+	insts, err := disasm.Functions(objFile, addrs)
+	if err != nil {
+		if errors.Is(err, disasm.ErrUnsupportedMachine) {
+			return nil, status.Error(codes.Unimplemented, err.Error())
+		}
+		return nil, status.Errorf(codes.Internal, "failed to disassemble: %v", err)
+	}
+	return insts, nil
+}
+
+// FetchSource returns the content of a source file of the binary of the build
+// ID, if it was uploaded to the bucket. Parca doesn't write source files, they
+// have to be uploaded to the bucket out of band, e.g. by the CI that built the
+// binary, at the path returned by sourcePath.
+func (s *Store) FetchSource(ctx context.Context, buildID, filename string) ([]byte, error) {
+	if err := validateID(buildID); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	r, err := s.bucket.Get(ctx, sourcePath(buildID, filename))
+	if err != nil {
+		if s.bucket.IsObjNotFoundErr(err) {
+			return nil, status.Errorf(codes.NotFound, "source file %q of build ID %q not found", filename, buildID)
+		}
+		return nil, status.Errorf(codes.Internal, "failed to fetch source file: %v", err)
+	}
+	defer r.Close()
+
+	b, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to read source file: %v", err)
+	}
+	return b, nil
+}
+
 func (s *Store) fetchDebuginfodFile(ctx context.Context, buildID string) (string, error) {
 	logger := log.With(s.logger, "buildid", buildID)
 	level.Debug(logger).Log("msg", "attempting to download from debuginfod servers")
@@ -392,3 +443,10 @@ func cache(localPath string, r io.ReadCloser) error {
 func objectPath(buildID string) string {
 	return path.Join(buildID, "debuginfo")
 }
+
+// sourcePath returns the path of a source file of the binary of the build ID:
+// <buildid>/sources/<filename>. The filename is cleaned, so it can't refer to
+// files outside of the sources of the build ID.
+func sourcePath(buildID, filename string) string {
+	return path.Join(buildID, "sources", path.Clean("/"+filename))
+}
@@ -28,7 +28,9 @@ import (
 	"github.com/thanos-io/objstore/client"
 	"github.com/thanos-io/objstore/filesystem"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
 
 	debuginfopb "github.com/parca-dev/parca/gen/proto/go/parca/debuginfo/v1alpha1"
 	"github.com/parca-dev/parca/pkg/symbol"
@@ -122,4 +124,62 @@ func TestStore(t *testing.T) {
 	exists, err := c.Exists(context.Background(), "abcd", "")
 	require.NoError(t, err)
 	require.True(t, exists)
+
+	_, err = s.FetchSource(context.Background(), "abcd", "/src/main.go")
+	require.Equal(t, codes.NotFound, status.Code(err))
+
+	require.NoError(t, s.bucket.Upload(context.Background(), "abcd/sources/src/main.go", bytes.NewBufferString("package main\n")))
+	src, err := s.FetchSource(context.Background(), "abcd", "/src/main.go")
+	require.NoError(t, err)
+	require.Equal(t, "package main\n", string(src))
+
+	// Filenames can't refer to files outside of the sources of the build ID.
+	src, err = s.FetchSource(context.Background(), "abcd", "../../src/main.go")
+	require.NoError(t, err)
+	require.Equal(t, "package main\n", string(src))
+	_, err = s.FetchSource(context.Background(), "abcd", "../debuginfo")
+	require.Equal(t, codes.NotFound, status.Code(err))
+
+	_, err = s.FetchSource(context.Background(), "xyz", "main.go")
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestStoreDisassemble(t *testing.T) {
+	cacheDir, err := ioutil.TempDir("", "parca-test-cache")
+	require.NoError(t, err)
+	defer os.RemoveAll(cacheDir)
+
+	logger := log.NewNopLogger()
+	sym, err := symbol.NewSymbolizer(logger)
+	require.NoError(t, err)
+
+	s, err := NewStore(
+		logger,
+		sym,
+		&Config{
+			Bucket: &client.BucketConfig{
+				Type: client.FILESYSTEM,
+				Config: filesystem.Config{
+					Directory: "../symbolizer/testdata/",
+				},
+			},
+			Cache: &CacheConfig{
+				Type: FILESYSTEM,
+				Config: &FilesystemCacheConfig{
+					Directory: cacheDir,
+				},
+			},
+		},
+		NopDebugInfodClient{},
+	)
+	require.NoError(t, err)
+
+	insts, err := s.Disassemble(context.Background(), "2d6912fd3dd64542f6f6294f4bf9cb6c265b3085", []uint64{0x463781})
+	require.NoError(t, err)
+	require.NotEmpty(t, insts)
+	require.Equal(t, uint64(0x463720), insts[0].Address)
+	require.Equal(t, "main.main", insts[0].Function)
+
+	_, err = s.Disassemble(context.Background(), "abcd", []uint64{0x463781})
+	require.Equal(t, codes.NotFound, status.Code(err))
 }
@@ -0,0 +1,141 @@
+// Copyright 2022 The Parca Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filesource
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/prometheus/common/model"
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/thanos-io/objstore/client"
+)
+
+const defaultPollInterval = model.Duration(30 * time.Second)
+
+// Config configures a directory or bucket that is polled for pprof files. The
+// profiles are written to the default tenant like the profiles of scrapes, so
+// the ingestion limits of the default tenant apply to them and they are
+// archived if raw profiles are.
+type Config struct {
+	// Name of the file source, used in logs and metrics.
+	Name string `yaml:"name"`
+	// Path of a local directory to watch. Mutually exclusive with Bucket.
+	Path string `yaml:"path,omitempty"`
+	// Bucket to watch. Mutually exclusive with Path.
+	Bucket *client.BucketConfig `yaml:"bucket,omitempty"`
+	// Prefix limits the watched files to the ones below the prefix.
+	Prefix string `yaml:"prefix,omitempty"`
+	// FilenameTemplate extracts labels from the path of a file relative to
+	// the prefix, e.g. "{job}/{__name__}-{commit}.pb.gz". Every {label}
+	// matches a non-empty part of a path segment. Files not matching the
+	// template are ignored. When empty, all .pb.gz files are ingested.
+	FilenameTemplate string `yaml:"filename_template,omitempty"`
+	// Labels are attached to all profiles of the file source.
+	Labels map[string]string `yaml:"labels,omitempty"`
+	// How frequently to list the files of the source.
+	PollInterval model.Duration `yaml:"poll_interval,omitempty"`
+	// ProcessedPrefix, if set, is the prefix processed files are moved to,
+	// e.g. "processed" moves them below "processed/". Otherwise processed
+	// files are left in place.
+	ProcessedPrefix string `yaml:"processed_prefix,omitempty"`
+}
+
+// SetDirectory joins a relative path with dir.
+func (c *Config) SetDirectory(dir string) {
+	if c.Path != "" && !filepath.IsAbs(c.Path) {
+		c.Path = filepath.Join(dir, c.Path)
+	}
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface.
+func (c *Config) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	type plain Config
+	unmarshalled := plain{
+		PollInterval: defaultPollInterval,
+	}
+	if err := unmarshal(&unmarshalled); err != nil {
+		return err
+	}
+
+	*c = Config(unmarshalled)
+	return nil
+}
+
+// Validate returns an error if the file source config is not valid.
+func (c *Config) Validate() error {
+	if c.Name == "" {
+		return errors.New("file source name is empty")
+	}
+	if (c.Path == "") == (c.Bucket == nil) {
+		return fmt.Errorf("exactly one of path and bucket must be set in file source %s", c.Name)
+	}
+	if c.PollInterval <= 0 {
+		return fmt.Errorf("poll_interval must be positive in file source %s", c.Name)
+	}
+
+	for name := range c.Labels {
+		if !model.LabelName(name).IsValid() {
+			return fmt.Errorf("invalid label name %q in file source %s", name, c.Name)
+		}
+	}
+
+	t, err := compileFilenameTemplate(c.FilenameTemplate)
+	if err != nil {
+		return fmt.Errorf("invalid filename_template in file source %s: %w", c.Name, err)
+	}
+	if _, ok := c.Labels[labels.MetricName]; !ok && t.SubexpIndex(labels.MetricName) == -1 {
+		return fmt.Errorf("file source %s needs a %s label or template placeholder", c.Name, labels.MetricName)
+	}
+
+	return nil
+}
+
+var placeholderRx = regexp.MustCompile(`\{([^{}]*)\}`)
+
+// compileFilenameTemplate compiles the template to a regexp with a named
+// capture group per placeholder.
+func compileFilenameTemplate(t string) (*regexp.Regexp, error) {
+	if t == "" {
+		return regexp.MustCompile(`^.*\.pb\.gz$`), nil
+	}
+
+	b := strings.Builder{}
+	b.WriteByte('^')
+
+	seen := map[string]struct{}{}
+	last := 0
+	for _, m := range placeholderRx.FindAllStringSubmatchIndex(t, -1) {
+		name := t[m[2]:m[3]]
+		if !model.LabelName(name).IsValid() {
+			return nil, fmt.Errorf("invalid label name %q", name)
+		}
+		if _, ok := seen[name]; ok {
+			return nil, fmt.Errorf("duplicate placeholder %q", name)
+		}
+		seen[name] = struct{}{}
+
+		b.WriteString(regexp.QuoteMeta(t[last:m[0]]))
+		b.WriteString(`(?P<` + name + `>[^/]+?)`)
+		last = m[1]
+	}
+	b.WriteString(regexp.QuoteMeta(t[last:]))
+	b.WriteByte('$')
+
+	return regexp.Compile(b.String())
+}
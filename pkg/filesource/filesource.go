@@ -0,0 +1,461 @@
+// Copyright 2022 The Parca Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filesource
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/go-kit/log/level"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/thanos-io/objstore"
+	"github.com/thanos-io/objstore/client"
+	"github.com/thanos-io/objstore/filesystem"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protowire"
+	"gopkg.in/yaml.v2"
+
+	profilestorepb "github.com/parca-dev/parca/gen/proto/go/parca/profilestore/v1alpha1"
+	"github.com/parca-dev/parca/pkg/rejection"
+)
+
+type metrics struct {
+	filesIngested *prometheus.CounterVec
+	filesFailed   *prometheus.CounterVec
+	filesRejected *prometheus.CounterVec
+}
+
+func newMetrics(reg prometheus.Registerer) *metrics {
+	m := &metrics{
+		filesIngested: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "parca_file_source_files_ingested_total",
+				Help: "Total number of files ingested by a file source.",
+			},
+			[]string{"source"},
+		),
+		filesFailed: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "parca_file_source_files_failed_total",
+				Help: "Total number of files of a file source that failed to be ingested.",
+			},
+			[]string{"source"},
+		),
+		filesRejected: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "parca_file_source_files_rejected_total",
+				Help: "Total number of files of a file source that were rejected as duplicate, out of order, out of bounds or exceeding a cardinality or samples limit.",
+			},
+			[]string{"source"},
+		),
+	}
+	reg.MustRegister(m.filesIngested, m.filesFailed, m.filesRejected)
+	return m
+}
+
+// Manager polls the configured file sources and ingests new pprof files.
+type Manager struct {
+	logger  log.Logger
+	sources []*source
+}
+
+// NewManager returns a manager of the file sources. The files are written to
+// the store with WriteRaw, the same as the profiles of scrapes, so that they
+// are subject to the same limits and archived the same way.
+func NewManager(logger log.Logger, reg prometheus.Registerer, store profilestorepb.ProfileStoreServiceServer, cfgs []*Config) (*Manager, error) {
+	m := &Manager{
+		logger: logger,
+	}
+	if len(cfgs) == 0 {
+		return m, nil
+	}
+
+	metrics := newMetrics(reg)
+	for _, cfg := range cfgs {
+		s, err := newSource(log.With(logger, "file_source", cfg.Name), metrics, store, cfg)
+		if err != nil {
+			return nil, fmt.Errorf("file source %s: %w", cfg.Name, err)
+		}
+		m.sources = append(m.sources, s)
+	}
+
+	return m, nil
+}
+
+// Run polls all file sources until the context is canceled.
+func (m *Manager) Run(ctx context.Context) error {
+	var wg sync.WaitGroup
+	for _, s := range m.sources {
+		wg.Add(1)
+		go func(s *source) {
+			defer wg.Done()
+			s.run(ctx)
+		}(s)
+	}
+	wg.Wait()
+	<-ctx.Done()
+
+	return nil
+}
+
+type source struct {
+	logger   log.Logger
+	metrics  *metrics
+	store    profilestorepb.ProfileStoreServiceServer
+	cfg      *Config
+	bucket   objstore.Bucket
+	template *regexp.Regexp
+	labels   labels.Labels
+	// processedPrefix is the processed prefix of the config ending in a
+	// slash, so that it doesn't match files that merely start with it.
+	processedPrefix string
+
+	// ingested holds the files that were already processed. As the storage
+	// is in memory, re-ingesting the files after a restart is intended, so
+	// there is no need to persist the progress.
+	ingested map[string]struct{}
+	// failed holds the size of files that failed to be parsed, so they are
+	// only retried once they changed, e.g. when they were still being written.
+	failed map[string]int64
+}
+
+func newSource(logger log.Logger, metrics *metrics, store profilestorepb.ProfileStoreServiceServer, cfg *Config) (*source, error) {
+	t, err := compileFilenameTemplate(cfg.FilenameTemplate)
+	if err != nil {
+		return nil, err
+	}
+
+	var bucket objstore.Bucket
+	if cfg.Path != "" {
+		bucket, err = filesystem.NewBucket(cfg.Path)
+		if err != nil {
+			return nil, fmt.Errorf("instantiate filesystem bucket: %w", err)
+		}
+	} else {
+		bucketCfg, err := yaml.Marshal(cfg.Bucket)
+		if err != nil {
+			return nil, fmt.Errorf("marshal content of object storage configuration: %w", err)
+		}
+		bucket, err = client.NewBucket(logger, bucketCfg, nil, "parca/filesource")
+		if err != nil {
+			return nil, fmt.Errorf("instantiate object storage: %w", err)
+		}
+	}
+
+	processedPrefix := cfg.ProcessedPrefix
+	if processedPrefix != "" && !strings.HasSuffix(processedPrefix, objstore.DirDelim) {
+		processedPrefix += objstore.DirDelim
+	}
+
+	return &source{
+		logger:          logger,
+		metrics:         metrics,
+		store:           store,
+		cfg:             cfg,
+		bucket:          bucket,
+		template:        t,
+		labels:          labels.FromMap(cfg.Labels),
+		processedPrefix: processedPrefix,
+		ingested:        map[string]struct{}{},
+		failed:          map[string]int64{},
+	}, nil
+}
+
+func (s *source) run(ctx context.Context) {
+	ticker := time.NewTicker(time.Duration(s.cfg.PollInterval))
+	defer ticker.Stop()
+
+	for {
+		if err := s.poll(ctx); err != nil && !errors.Is(err, context.Canceled) {
+			level.Warn(s.logger).Log("msg", "failed to poll file source", "err", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// poll ingests all files of the source that weren't ingested yet. The files
+// are ingested in the order of the time of their profiles, as profiles older
+// than the last profile of their series are rejected. The files are read once
+// and only the time of their profiles is decoded to order them, the profiles
+// are parsed once by the store.
+func (s *source) poll(ctx context.Context) error {
+	names := []string{}
+	err := s.bucket.Iter(ctx, s.cfg.Prefix, func(name string) error {
+		if strings.HasSuffix(name, objstore.DirDelim) {
+			return nil
+		}
+		if s.processedPrefix != "" && strings.HasPrefix(name, s.processedPrefix) {
+			return nil
+		}
+		if _, ok := s.ingested[name]; ok {
+			return nil
+		}
+		names = append(names, name)
+		return nil
+	}, objstore.WithRecursiveIter)
+	if err != nil {
+		return err
+	}
+
+	type file struct {
+		name      string
+		labels    labels.Labels
+		raw       []byte
+		size      int64
+		timestamp int64
+	}
+	files := make([]file, 0, len(names))
+	for _, name := range names {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		ls, ok := s.fileLabels(name)
+		if !ok {
+			continue
+		}
+
+		if size, ok := s.failed[name]; ok {
+			attrs, err := s.bucket.Attributes(ctx, name)
+			if err != nil || attrs.Size == size {
+				continue
+			}
+		}
+
+		raw, size, timestamp, err := s.load(ctx, name)
+		if err != nil {
+			continue
+		}
+		files = append(files, file{name: name, labels: ls, raw: raw, size: size, timestamp: timestamp})
+	}
+	sort.SliceStable(files, func(i, j int) bool {
+		return files[i].timestamp < files[j].timestamp
+	})
+
+	for i, f := range files {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		err := s.write(ctx, f.labels, f.raw)
+		// The file is not needed anymore, even if it is retried on the
+		// next poll.
+		files[i].raw = nil
+		switch {
+		case err == nil:
+			s.metrics.filesIngested.WithLabelValues(s.cfg.Name).Inc()
+		case isRejected(err):
+			// Rejected files would be rejected again, so they are treated
+			// as processed.
+			level.Debug(s.logger).Log("msg", "rejected file", "file", f.name, "err", err)
+			s.metrics.filesRejected.WithLabelValues(s.cfg.Name).Inc()
+		case status.Code(err) == codes.InvalidArgument:
+			s.failWithSize(f.name, f.size, fmt.Errorf("write profile: %w", err))
+			continue
+		default:
+			// The store failed to ingest the profile, or a rate limit was
+			// exceeded, which is retried on the next poll.
+			s.fail(f.name, fmt.Errorf("write profile: %w", err))
+			continue
+		}
+
+		s.ingested[f.name] = struct{}{}
+		delete(s.failed, f.name)
+
+		if s.processedPrefix != "" {
+			if err := s.moveProcessed(ctx, f.name); err != nil {
+				level.Warn(s.logger).Log("msg", "failed to move processed file", "file", f.name, "err", err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// write writes the raw profile of a file to the store.
+func (s *source) write(ctx context.Context, ls labels.Labels, raw []byte) error {
+	lset := &profilestorepb.LabelSet{Labels: make([]*profilestorepb.Label, 0, len(ls))}
+	for _, l := range ls {
+		lset.Labels = append(lset.Labels, &profilestorepb.Label{Name: l.Name, Value: l.Value})
+	}
+
+	_, err := s.store.WriteRaw(ctx, &profilestorepb.WriteRawRequest{
+		Series: []*profilestorepb.RawProfileSeries{{
+			Labels:  lset,
+			Samples: []*profilestorepb.RawSample{{RawProfile: raw}},
+		}},
+	})
+	return err
+}
+
+// fileLabels returns the labels of the profiles of the file, and whether the
+// file matches the filename template.
+func (s *source) fileLabels(name string) (labels.Labels, bool) {
+	rel := strings.TrimPrefix(strings.TrimPrefix(name, s.cfg.Prefix), objstore.DirDelim)
+	m := s.template.FindStringSubmatch(rel)
+	if m == nil {
+		return nil, false
+	}
+
+	b := labels.NewBuilder(s.labels)
+	for i, n := range s.template.SubexpNames() {
+		if n != "" {
+			b.Set(n, m[i])
+		}
+	}
+	return b.Labels(), true
+}
+
+// load reads the profile of the file, the size of the file and the time the
+// profile was taken at. Files that
+// can't be decoded are only retried once their size changed, e.g. when they
+// were still being written, files that failed to be read on the next poll.
+func (s *source) load(ctx context.Context, name string) ([]byte, int64, int64, error) {
+	r, err := s.bucket.Get(ctx, name)
+	if err != nil {
+		return nil, 0, 0, s.fail(name, fmt.Errorf("get file: %w", err))
+	}
+	defer r.Close()
+
+	b, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, 0, 0, s.fail(name, fmt.Errorf("read file: %w", err))
+	}
+	size := int64(len(b))
+
+	raw, timestamp, err := profileTime(b)
+	if err != nil {
+		return nil, 0, 0, s.failWithSize(name, size, fmt.Errorf("decode profile: %w", err))
+	}
+
+	if timestamp == 0 {
+		// Not all tools write the time the profile was taken at, the time
+		// the file was last modified is the closest approximation. As the
+		// last value of a field wins, appending the time to the profile
+		// sets it.
+		attrs, err := s.bucket.Attributes(ctx, name)
+		if err != nil {
+			return nil, 0, 0, s.fail(name, fmt.Errorf("get file attributes: %w", err))
+		}
+		timestamp = attrs.LastModified.UnixNano()
+		raw = protowire.AppendTag(raw, profileTimeNanosField, protowire.VarintType)
+		raw = protowire.AppendVarint(raw, uint64(timestamp))
+		return raw, size, timestamp, nil
+	}
+
+	return b, size, timestamp, nil
+}
+
+// profileTimeNanosField is the field number of time_nanos in profile.proto.
+const profileTimeNanosField = 9
+
+// profileTime returns the decompressed profile and the time_nanos field of
+// it, without parsing the rest of the profile.
+func profileTime(b []byte) ([]byte, int64, error) {
+	if len(b) >= 2 && b[0] == 0x1f && b[1] == 0x8b {
+		gz, err := gzip.NewReader(bytes.NewBuffer(b))
+		if err != nil {
+			return nil, 0, err
+		}
+		if b, err = ioutil.ReadAll(gz); err != nil {
+			return nil, 0, err
+		}
+	}
+
+	var timestamp int64
+	for rest := b; len(rest) > 0; {
+		num, typ, n := protowire.ConsumeTag(rest)
+		if n < 0 {
+			return nil, 0, protowire.ParseError(n)
+		}
+		rest = rest[n:]
+
+		if num == profileTimeNanosField && typ == protowire.VarintType {
+			v, n := protowire.ConsumeVarint(rest)
+			if n < 0 {
+				return nil, 0, protowire.ParseError(n)
+			}
+			timestamp = int64(v)
+			rest = rest[n:]
+			continue
+		}
+
+		n = protowire.ConsumeFieldValue(num, typ, rest)
+		if n < 0 {
+			return nil, 0, protowire.ParseError(n)
+		}
+		rest = rest[n:]
+	}
+
+	return b, timestamp, nil
+}
+
+// isRejected returns whether the store rejected the profile in a way that it
+// would reject it again, unlike exceeding a rate or series limit.
+func isRejected(err error) bool {
+	switch rejection.Reason(err) {
+	case rejection.DuplicateSample, rejection.OutOfOrderSample, rejection.OutOfBounds, rejection.CardinalityLimit, rejection.SamplesLimit:
+		return true
+	}
+	return false
+}
+
+// fail reports a file that failed to be ingested and is retried on the next
+// poll.
+func (s *source) fail(name string, err error) error {
+	level.Warn(s.logger).Log("msg", "failed to ingest file", "file", name, "err", err)
+	s.metrics.filesFailed.WithLabelValues(s.cfg.Name).Inc()
+	return err
+}
+
+// failWithSize reports a file that failed to be ingested and is only retried
+// once its size changed.
+func (s *source) failWithSize(name string, size int64, err error) error {
+	s.failed[name] = size
+	return s.fail(name, err)
+}
+
+// moveProcessed moves the file below the processed prefix.
+func (s *source) moveProcessed(ctx context.Context, name string) error {
+	r, err := s.bucket.Get(ctx, name)
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+
+	rel := strings.TrimPrefix(strings.TrimPrefix(name, s.cfg.Prefix), objstore.DirDelim)
+	if err := s.bucket.Upload(ctx, path.Join(s.processedPrefix, rel), r); err != nil {
+		return err
+	}
+
+	return s.bucket.Delete(ctx, name)
+}
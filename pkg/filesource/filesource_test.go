@@ -0,0 +1,250 @@
+// Copyright 2022 The Parca Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filesource
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"io/ioutil"
+	"testing"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/google/pprof/profile"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/stretchr/testify/require"
+	"github.com/thanos-io/objstore"
+	"github.com/thanos-io/objstore/client"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	profilestorepb "github.com/parca-dev/parca/gen/proto/go/parca/profilestore/v1alpha1"
+	"github.com/parca-dev/parca/pkg/rejection"
+)
+
+type fakeStore struct {
+	profilestorepb.UnimplementedProfileStoreServiceServer
+
+	ingested   []labels.Labels
+	timestamps []int64
+	seen       map[string]struct{}
+	// failures is the number of writes that fail.
+	failures int
+}
+
+func (s *fakeStore) WriteRaw(_ context.Context, r *profilestorepb.WriteRawRequest) (*profilestorepb.WriteRawResponse, error) {
+	if s.failures > 0 {
+		s.failures--
+		return nil, status.Error(codes.Internal, "insert failed")
+	}
+
+	rs := r.Series[0]
+	ls := make(labels.Labels, 0, len(rs.Labels.Labels))
+	for _, l := range rs.Labels.Labels {
+		ls = append(ls, labels.Label{Name: l.Name, Value: l.Value})
+	}
+	p, err := profile.Parse(bytes.NewBuffer(rs.Samples[0].RawProfile))
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	if _, ok := s.seen[ls.String()]; ok {
+		st, err := status.New(codes.AlreadyExists, "duplicate sample").WithDetails(&errdetails.ErrorInfo{
+			Reason: rejection.DuplicateSample,
+			Domain: rejection.Domain,
+		})
+		if err != nil {
+			return nil, err
+		}
+		return nil, st.Err()
+	}
+	s.seen[ls.String()] = struct{}{}
+	s.ingested = append(s.ingested, ls)
+	s.timestamps = append(s.timestamps, p.TimeNanos)
+	return &profilestorepb.WriteRawResponse{}, nil
+}
+
+// failingBucket fails the given number of Get calls.
+type failingBucket struct {
+	objstore.Bucket
+	failures int
+}
+
+func (b *failingBucket) Get(ctx context.Context, name string) (io.ReadCloser, error) {
+	if b.failures > 0 {
+		b.failures--
+		return nil, errors.New("connection reset")
+	}
+	return b.Bucket.Get(ctx, name)
+}
+
+func newTestSource(t *testing.T, cfg *Config) (*source, *fakeStore, objstore.Bucket) {
+	t.Helper()
+
+	require.NoError(t, cfg.Validate())
+
+	store := &fakeStore{seen: map[string]struct{}{}}
+	s, err := newSource(log.NewNopLogger(), newMetrics(prometheus.NewRegistry()), store, cfg)
+	require.NoError(t, err)
+
+	bucket := objstore.NewInMemBucket()
+	s.bucket = bucket
+
+	return s, store, bucket
+}
+
+func TestCompileFilenameTemplate(t *testing.T) {
+	rx, err := compileFilenameTemplate("{job}/{__name__}-{commit}.pb.gz")
+	require.NoError(t, err)
+
+	m := rx.FindStringSubmatch("ci/cpu-abc123.pb.gz")
+	require.Equal(t, []string{"ci/cpu-abc123.pb.gz", "ci", "cpu", "abc123"}, m)
+	require.Nil(t, rx.FindStringSubmatch("ci/nested/cpu-abc123.pb.gz"))
+	require.Nil(t, rx.FindStringSubmatch("ci/cpu-abc123.pb"))
+
+	_, err = compileFilenameTemplate("{job}/{job}.pb.gz")
+	require.Error(t, err)
+
+	_, err = compileFilenameTemplate("{0job}.pb.gz")
+	require.Error(t, err)
+}
+
+func TestSourcePoll(t *testing.T) {
+	ctx := context.Background()
+
+	b, err := ioutil.ReadFile("../query/testdata/alloc_objects.pb.gz")
+	require.NoError(t, err)
+
+	s, store, bucket := newTestSource(t, &Config{
+		Name:             "ci",
+		Path:             "unused",
+		Prefix:           "profiles",
+		FilenameTemplate: "{job}/{commit}.pb.gz",
+		Labels:           map[string]string{"__name__": "memory"},
+		PollInterval:     defaultPollInterval,
+		ProcessedPrefix:  "processed",
+	})
+
+	require.NoError(t, bucket.Upload(ctx, "profiles/bench/abc.pb.gz", bytes.NewReader(b)))
+	require.NoError(t, bucket.Upload(ctx, "profiles/bench/ignored.txt", bytes.NewReader(b)))
+
+	require.NoError(t, s.poll(ctx))
+	require.Equal(t, []labels.Labels{
+		labels.FromStrings("__name__", "memory", "commit", "abc", "job", "bench"),
+	}, store.ingested)
+	require.Equal(t, "processed/", s.processedPrefix)
+
+	// The processed file was moved aside and is not ingested again.
+	exists, err := bucket.Exists(ctx, "profiles/bench/abc.pb.gz")
+	require.NoError(t, err)
+	require.False(t, exists)
+	exists, err = bucket.Exists(ctx, "processed/bench/abc.pb.gz")
+	require.NoError(t, err)
+	require.True(t, exists)
+
+	require.NoError(t, bucket.Upload(ctx, "profiles/bench/def.pb.gz", bytes.NewReader(b)))
+	require.NoError(t, s.poll(ctx))
+	require.Len(t, store.ingested, 2)
+
+	// Files that can't be parsed are only retried once they changed.
+	require.NoError(t, bucket.Upload(ctx, "profiles/bench/partial.pb.gz", bytes.NewReader(b[:100])))
+	require.NoError(t, s.poll(ctx))
+	require.NoError(t, s.poll(ctx))
+	require.Len(t, store.ingested, 2)
+	require.Contains(t, s.failed, "profiles/bench/partial.pb.gz")
+
+	require.NoError(t, bucket.Upload(ctx, "profiles/bench/partial.pb.gz", bytes.NewReader(b)))
+	require.NoError(t, s.poll(ctx))
+	require.Len(t, store.ingested, 3)
+	require.Empty(t, s.failed)
+}
+
+func TestSourcePollOrder(t *testing.T) {
+	ctx := context.Background()
+
+	f, err := ioutil.ReadFile("../query/testdata/alloc_objects.pb.gz")
+	require.NoError(t, err)
+	p, err := profile.Parse(bytes.NewBuffer(f))
+	require.NoError(t, err)
+
+	s, store, bucket := newTestSource(t, &Config{
+		Name:             "ci",
+		Path:             "unused",
+		FilenameTemplate: "{job}/{commit}.pb.gz",
+		Labels:           map[string]string{"__name__": "memory"},
+		PollInterval:     defaultPollInterval,
+	})
+	upload := func(name string, ts time.Time) {
+		p.TimeNanos = ts.UnixNano()
+		buf := bytes.NewBuffer(nil)
+		require.NoError(t, p.Write(buf))
+		require.NoError(t, bucket.Upload(ctx, name, buf))
+	}
+
+	// Files are ingested in the order of their profiles, not their names.
+	upload("bench/10.pb.gz", time.Unix(10, 0))
+	upload("bench/9.pb.gz", time.Unix(9, 0))
+	require.NoError(t, s.poll(ctx))
+	require.Equal(t, []int64{
+		time.Unix(9, 0).UnixNano(),
+		time.Unix(10, 0).UnixNano(),
+	}, store.timestamps)
+
+	// Files that failed to be read or ingested are retried on the next poll,
+	// even if they didn't change.
+	fb := &failingBucket{Bucket: bucket, failures: 1}
+	s.bucket = fb
+	upload("bench/11.pb.gz", time.Unix(11, 0))
+	require.NoError(t, s.poll(ctx))
+	require.Len(t, store.ingested, 2)
+
+	store.failures = 1
+	require.NoError(t, s.poll(ctx))
+	require.Len(t, store.ingested, 2)
+
+	require.NoError(t, s.poll(ctx))
+	require.Len(t, store.ingested, 3)
+	require.Empty(t, s.failed)
+
+	// Profiles without a time get the time the file was last modified.
+	p.TimeNanos = 0
+	buf := bytes.NewBuffer(nil)
+	require.NoError(t, p.Write(buf))
+	require.NoError(t, bucket.Upload(ctx, "bench/untimed.pb.gz", buf))
+	require.NoError(t, s.poll(ctx))
+	require.Len(t, store.timestamps, 4)
+	require.Greater(t, store.timestamps[3], time.Unix(11, 0).UnixNano())
+}
+
+func TestConfigValidate(t *testing.T) {
+	valid := Config{
+		Name:             "ci",
+		Path:             "profiles",
+		FilenameTemplate: "{__name__}.pb.gz",
+		PollInterval:     defaultPollInterval,
+	}
+	require.NoError(t, valid.Validate())
+
+	missingName := valid
+	missingName.FilenameTemplate = "{job}.pb.gz"
+	require.Error(t, missingName.Validate())
+
+	bothSources := valid
+	bothSources.Bucket = &client.BucketConfig{Type: client.FILESYSTEM}
+	require.Error(t, bothSources.Validate())
+}
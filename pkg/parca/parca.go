@@ -36,6 +36,7 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/prometheus/discovery"
 	"github.com/prometheus/prometheus/model/labels"
+	"github.com/thanos-io/objstore/client"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
 	"go.opentelemetry.io/otel/propagation"
@@ -46,6 +47,7 @@ import (
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/insecure"
+	"gopkg.in/yaml.v2"
 
 	debuginfopb "github.com/parca-dev/parca/gen/proto/go/parca/debuginfo/v1alpha1"
 	profilestorepb "github.com/parca-dev/parca/gen/proto/go/parca/profilestore/v1alpha1"
@@ -53,14 +55,18 @@ import (
 	scrapepb "github.com/parca-dev/parca/gen/proto/go/parca/scrape/v1alpha1"
 	"github.com/parca-dev/parca/pkg/config"
 	"github.com/parca-dev/parca/pkg/debuginfo"
+	"github.com/parca-dev/parca/pkg/filesource"
 	"github.com/parca-dev/parca/pkg/metastore"
 	"github.com/parca-dev/parca/pkg/parcacol"
 	"github.com/parca-dev/parca/pkg/profilestore"
 	queryservice "github.com/parca-dev/parca/pkg/query"
+	"github.com/parca-dev/parca/pkg/rawprofile"
 	"github.com/parca-dev/parca/pkg/scrape"
 	"github.com/parca-dev/parca/pkg/server"
+	"github.com/parca-dev/parca/pkg/sourcepath"
 	"github.com/parca-dev/parca/pkg/symbol"
 	"github.com/parca-dev/parca/pkg/symbolizer"
+	"github.com/parca-dev/parca/pkg/tenant"
 )
 
 const (
@@ -85,6 +91,34 @@ type Flags struct {
 	StorageDebugValueLog bool  `default:"false" help:"Log every value written to the database into a separate file. This is only for debugging purposes to produce data to replay situations in tests."`
 	StorageGranuleSize   int   `default:"8196" help:"Granule size for storage."`
 	StorageActiveMemory  int64 `default:"536870912" help:"Amount of memory to use for active storage. Defaults to 512MB."`
+	StorageRawProfiles   bool  `default:"false" help:"Archive every profile written via WriteRaw as it was received in the debug info bucket, so that it can be downloaded with GetRawProfile."`
+
+	StorageOutOfBoundsPast   time.Duration `default:"0" help:"Reject profiles with a timestamp further in the past than this. Zero disables the check."`
+	StorageOutOfBoundsFuture time.Duration `default:"0" help:"Reject profiles with a timestamp further in the future than this. Zero disables the check."`
+
+	StorageTenants []string `help:"Tenants that can write profiles in addition to the default tenant. Profiles of other tenants are rejected."`
+
+	StorageIngestConcurrency int `default:"0" help:"Maximum number of profiles parsed and ingested concurrently. Defaults to the number of CPUs."`
+	StorageInsertBatchRows   int `default:"100000" help:"Maximum number of rows of concurrently ingested profiles that are merged into a single insert into the table. Zero disables batching."`
+
+	LimitsMaxLabelNamesPerProfileType  int           `default:"0" help:"Maximum number of distinct label names, and of distinct pprof label names, of a profile type. Zero disables the limit."`
+	LimitsMaxLabelValuesPerProfileType int           `default:"0" help:"Maximum number of distinct values of a label or pprof label of a profile type. Zero disables the limit."`
+	LimitsMaxPprofLabelNamesPerSeries  int           `default:"0" help:"Maximum number of distinct pprof label names of a series. Zero disables the limit."`
+	LimitsMaxPprofLabelValuesPerSeries int           `default:"0" help:"Maximum number of distinct values of a pprof label of a series. Zero disables the limit."`
+	LimitsCardinalityAction            string        `default:"reject" enum:"reject,strip" help:"What to do with profiles exceeding a cardinality limit. Reject rejects the whole profile, strip removes the offending labels."`
+	LimitsCardinalityRetention         time.Duration `default:"1h" help:"How long label values count towards the cardinality limits after the last profile that had them."`
+
+	LimitsProfilesPerSecond    float64       `default:"0" help:"Rate at which a tenant can write profiles via WriteRaw and WriteArrow. Zero disables the limit. Can be overridden per tenant and label selector in the config file."`
+	LimitsProfilesBurst        int           `default:"0" help:"Number of profiles a tenant can write at once. Defaults to the profiles per second."`
+	LimitsBytesPerSecond       float64       `default:"0" help:"Rate at which a tenant can write bytes of profiles via WriteRaw and WriteArrow. Zero disables the limit."`
+	LimitsBytesBurst           int           `default:"0" help:"Number of bytes a tenant can write at once, larger profiles are always rejected. Defaults to the bytes per second."`
+	LimitsMaxSamplesPerProfile int           `default:"0" help:"Maximum number of samples of a profile written via WriteRaw or WriteArrow. Zero disables the limit."`
+	LimitsMaxActiveSeries      int           `default:"0" help:"Maximum number of active series of a tenant. Zero disables the limit."`
+	LimitsActiveSeriesTimeout  time.Duration `default:"10m" help:"Time after which a series that didn't receive profiles is no longer active."`
+	LimitsAdminTenant          string        `default:"" help:"Tenant that can see the usage of the limits of all tenants. Other tenants, including the default tenant, only see their own usage."`
+
+	HAReplicaLabel    string        `default:"" help:"Label that distinguishes the replicas of highly available scrapers. Series only differing in this label are deduplicated and stored without it."`
+	HAFailoverTimeout time.Duration `default:"30s" help:"Time after which another replica is elected if the elected replica of a series stopped sending profiles."`
 
 	SymbolizerDemangleMode  string `default:"simple" help:"Mode to demangle C++ symbols. Default mode is simplified: no parameters, no templates, no return type" enum:"simple,full,none,templates"`
 	SymbolizerNumberOfTries int    `default:"3" help:"Number of tries to attempt to symbolize an unsybolized location"`
@@ -99,6 +133,7 @@ type Flags struct {
 	BearerTokenFile    string            `kong:"help='File to read bearer token from to authenticate with store.'"`
 	Insecure           bool              `kong:"help='Send gRPC requests via plaintext instead of TLS.'"`
 	InsecureSkipVerify bool              `kong:"help='Skip TLS certificate verification.'"`
+	Tenant             string            `kong:"help='Tenant to send profiles to the store for, and to query profiles of. Profiles are sent for the default tenant if empty.'"`
 	ExternalLabel      map[string]string `kong:"help='Label(s) to attach to all profiles in scraper-only mode.'"`
 }
 
@@ -134,56 +169,78 @@ func Run(ctx context.Context, logger log.Logger, reg *prometheus.Registry, flags
 		return runScraper(ctx, logger, reg, tracerProvider, flags, version, cfg)
 	}
 
-	var mStr metastore.ProfileMetaStore
-	switch flags.Metastore {
-	case metaStoreBadgerInMemory:
-		mStr = metastore.NewBadgerMetastore(
-			logger,
-			reg,
-			tracerProvider.Tracer(metaStoreBadgerInMemory),
-			metastore.NewRandomUUIDGenerator(),
-		)
-	default:
-		err := fmt.Errorf("unknown metastore implementation: %s", flags.Metastore)
-		level.Error(logger).Log("msg", "failed to initialize metastore", "err", err)
+	mStr, colDB, tables, err := openStorage(logger, reg, tracerProvider, flags)
+	if err != nil {
 		return err
 	}
 
-	col := arcticdb.New(
-		reg,
-		flags.StorageGranuleSize,
-		flags.StorageActiveMemory,
-	)
-	colDB, err := col.DB("parca")
+	stacktraceFilters, err := getStacktraceFilters(cfg.ScrapeConfigs)
 	if err != nil {
-		level.Error(logger).Log("msg", "failed to load database", "err", err)
+		level.Error(logger).Log("msg", "failed to initialize stacktrace filters", "err", err)
 		return err
 	}
-	table, err := colDB.Table("stacktraces", arcticdb.NewTableConfig(
-		parcacol.Schema(),
-	), logger)
+
+	redactor, err := getRedactor(cfg.RedactionRules)
 	if err != nil {
-		level.Error(logger).Log("msg", "create table", "err", err)
+		level.Error(logger).Log("msg", "failed to initialize redaction rules", "err", err)
+		return err
+	}
+
+	sourcePaths, err := getSourcePathMapper(cfg.SourcePaths)
+	if err != nil {
+		level.Error(logger).Log("msg", "failed to initialize source path mapping", "err", err)
 		return err
 	}
 
+	debugValueLog := flags.StorageDebugValueLog
+	if debugValueLog && redactor != nil {
+		// The debug value log contains the profiles as they were received.
+		level.Warn(logger).Log("msg", "the debug value log is disabled as redaction rules are configured")
+		debugValueLog = false
+	}
+
+	limiter := getCardinalityLimiter(reg, flags)
+
+	ingestLimiter, err := getIngestLimiter(reg, flags, cfg.IngestLimits)
+	if err != nil {
+		level.Error(logger).Log("msg", "failed to initialize ingest limits", "err", err)
+		return err
+	}
+
+	storeOpts := profilestoreOptions(
+		flags,
+		parcacol.WithStacktraceFilters(stacktraceFilters),
+		parcacol.WithRedactor(redactor),
+		parcacol.WithSourcePathMapper(sourcePaths),
+		parcacol.WithCardinalityLimiter(limiter),
+	)
+	storeOpts = append(storeOpts, profilestore.WithIngestLimiter(ingestLimiter))
+	queryOpts := []queryservice.Option{queryservice.WithCardinalityLimiter(limiter)}
+	if flags.StorageRawProfiles {
+		if redactor != nil {
+			// Just like the debug value log, raw profiles aren't redacted.
+			level.Warn(logger).Log("msg", "raw profiles are not archived as redaction rules are configured")
+		} else {
+			rawProfiles, err := getRawProfileArchive(logger, cfg.DebugInfo)
+			if err != nil {
+				level.Error(logger).Log("msg", "failed to initialize raw profile archive", "err", err)
+				return err
+			}
+			storeOpts = append(storeOpts, profilestore.WithRawProfiles(rawProfiles))
+			queryOpts = append(queryOpts, queryservice.WithRawProfiles(rawProfiles))
+		}
+	}
+
 	s := profilestore.NewProfileColumnStore(
 		logger,
+		reg,
 		tracerProvider.Tracer("profilestore"),
 		mStr,
-		table,
-		flags.StorageDebugValueLog,
-	)
-	q := queryservice.NewColumnQueryAPI(
-		logger,
-		tracerProvider.Tracer("query-service"),
-		mStr,
-		query.NewEngine(
-			memory.DefaultAllocator,
-			colDB.TableProvider(),
-		),
-		"stacktraces",
+		tables,
+		debugValueLog,
+		storeOpts...,
 	)
+	admin := profilestore.NewAdminServer(ingestLimiter, flags.LimitsAdminTenant)
 
 	ctx, cancel := context.WithCancel(ctx)
 	defer cancel()
@@ -199,10 +256,18 @@ func Run(ctx context.Context, logger log.Logger, reg *prometheus.Registry, flags
 		return err
 	}
 
+	// File sources write the profiles of the default tenant.
+	fileSources, err := filesource.NewManager(logger, reg, s, cfg.FileSources)
+	if err != nil {
+		level.Error(logger).Log("msg", "failed to initialize file sources", "err", err)
+		return err
+	}
+
 	sym, err := symbol.NewSymbolizer(logger,
 		symbol.WithDemangleMode(flags.SymbolizerDemangleMode),
 		symbol.WithAttemptThreshold(flags.SymbolizerNumberOfTries),
 		symbol.WithCacheItemTTL(symbolizationInterval*3),
+		symbol.WithSourcePathMapper(sourcePaths),
 	)
 	if err != nil {
 		level.Error(logger).Log("msg", "failed to initialize symbolizer", "err", err)
@@ -230,6 +295,24 @@ func Run(ctx context.Context, logger log.Logger, reg *prometheus.Registry, flags
 		return err
 	}
 
+	// Source and disassembly reports include the source files and the
+	// instructions of the binaries uploaded with the debug info.
+	queryOpts = append(queryOpts,
+		queryservice.WithSources(dbgInfo),
+		queryservice.WithDisassembler(dbgInfo),
+	)
+	q := queryservice.NewColumnQueryAPI(
+		logger,
+		tracerProvider.Tracer("query-service"),
+		mStr,
+		query.NewEngine(
+			memory.DefaultAllocator,
+			colDB.TableProvider(),
+		),
+		tables,
+		queryOpts...,
+	)
+
 	var gr run.Group
 	gr.Add(run.SignalHandler(ctx, os.Interrupt, syscall.SIGINT, syscall.SIGTERM))
 	{
@@ -263,6 +346,18 @@ func Run(ctx context.Context, logger log.Logger, reg *prometheus.Registry, flags
 			m.Stop()
 		},
 	)
+	if len(cfg.FileSources) > 0 {
+		ctx, cancel := context.WithCancel(ctx)
+		gr.Add(
+			func() error {
+				return fileSources.Run(ctx)
+			},
+			func(_ error) {
+				level.Debug(logger).Log("msg", "file sources exiting")
+				cancel()
+			},
+		)
+	}
 	parcaserver := server.NewServer(reg, version)
 	gr.Add(
 		func() error {
@@ -275,6 +370,7 @@ func Run(ctx context.Context, logger log.Logger, reg *prometheus.Registry, flags
 				server.RegisterableFunc(func(ctx context.Context, srv *grpc.Server, mux *runtime.ServeMux, endpoint string, opts []grpc.DialOption) error {
 					debuginfopb.RegisterDebugInfoServiceServer(srv, dbgInfo)
 					profilestorepb.RegisterProfileStoreServiceServer(srv, s)
+					profilestorepb.RegisterAdminServiceServer(srv, admin)
 					querypb.RegisterQueryServiceServer(srv, q)
 					scrapepb.RegisterScrapeServiceServer(srv, m)
 
@@ -286,6 +382,10 @@ func Run(ctx context.Context, logger log.Logger, reg *prometheus.Registry, flags
 						return err
 					}
 
+					if err := profilestorepb.RegisterAdminServiceHandlerFromEndpoint(ctx, mux, endpoint, opts); err != nil {
+						return err
+					}
+
 					if err := querypb.RegisterQueryServiceHandlerFromEndpoint(ctx, mux, endpoint, opts); err != nil {
 						return err
 					}
@@ -332,44 +432,9 @@ func runScraper(
 		return fmt.Errorf("parca scraper mode needs to have a --store-address")
 	}
 
-	metrics := grpc_prometheus.NewClientMetrics()
-	metrics.EnableClientHandlingTimeHistogram()
-	reg.MustRegister(metrics)
-
-	opts := []grpc.DialOption{
-		grpc.WithUnaryInterceptor(
-			metrics.UnaryClientInterceptor(),
-		),
-	}
-	if flags.Insecure {
-		opts = append(opts, grpc.WithTransportCredentials(insecure.NewCredentials()))
-	} else {
-		opts = append(opts, grpc.WithTransportCredentials(credentials.NewTLS(&tls.Config{
-			InsecureSkipVerify: flags.InsecureSkipVerify,
-		})))
-	}
-
-	if flags.BearerToken != "" {
-		opts = append(opts, grpc.WithPerRPCCredentials(&perRequestBearerToken{
-			token:    flags.BearerToken,
-			insecure: flags.Insecure,
-		}))
-	}
-
-	if flags.BearerTokenFile != "" {
-		b, err := ioutil.ReadFile(flags.BearerTokenFile)
-		if err != nil {
-			return fmt.Errorf("failed to read bearer token from file: %w", err)
-		}
-		opts = append(opts, grpc.WithPerRPCCredentials(&perRequestBearerToken{
-			token:    strings.TrimSpace(string(b)),
-			insecure: flags.Insecure,
-		}))
-	}
-
-	conn, err := grpc.Dial(flags.StoreAddress, opts...)
+	conn, err := storeConn(reg, flags)
 	if err != nil {
-		return fmt.Errorf("failed to create gRPC connection: %w", err)
+		return err
 	}
 
 	store := profilestore.NewGRPCForwarder(conn, logger)
@@ -469,6 +534,104 @@ func (t *perRequestBearerToken) RequireTransportSecurity() bool {
 	return !t.insecure
 }
 
+// openStorage opens the metastore and the tables of the stacktraces of all
+// tenants.
+func openStorage(logger log.Logger, reg prometheus.Registerer, tracerProvider trace.TracerProvider, flags *Flags) (metastore.ProfileMetaStore, *arcticdb.DB, *parcacol.Tables, error) {
+	var mStr metastore.ProfileMetaStore
+	switch flags.Metastore {
+	case metaStoreBadgerInMemory:
+		mStr = metastore.NewBadgerMetastore(
+			logger,
+			reg,
+			tracerProvider.Tracer(metaStoreBadgerInMemory),
+			metastore.NewRandomUUIDGenerator(),
+		)
+	default:
+		err := fmt.Errorf("unknown metastore implementation: %s", flags.Metastore)
+		level.Error(logger).Log("msg", "failed to initialize metastore", "err", err)
+		return nil, nil, nil, err
+	}
+
+	col := arcticdb.New(
+		reg,
+		flags.StorageGranuleSize,
+		flags.StorageActiveMemory,
+	)
+	colDB, err := col.DB("parca")
+	if err != nil {
+		level.Error(logger).Log("msg", "failed to load database", "err", err)
+		return nil, nil, nil, err
+	}
+	for _, id := range flags.StorageTenants {
+		if err := tenant.Validate(id); err != nil {
+			level.Error(logger).Log("msg", "invalid storage tenant", "err", err)
+			return nil, nil, nil, err
+		}
+	}
+	tables := parcacol.NewTables(logger, colDB, "stacktraces", parcacol.WithTenants(flags.StorageTenants...))
+	if _, err := tables.Table(tenant.Default); err != nil {
+		level.Error(logger).Log("msg", "create table", "err", err)
+		return nil, nil, nil, err
+	}
+
+	return mStr, colDB, tables, nil
+}
+
+// storeConn returns a gRPC connection to the store address of the flags.
+func storeConn(reg prometheus.Registerer, flags *Flags) (*grpc.ClientConn, error) {
+	metrics := grpc_prometheus.NewClientMetrics()
+	metrics.EnableClientHandlingTimeHistogram()
+	reg.MustRegister(metrics)
+
+	opts := []grpc.DialOption{
+		grpc.WithUnaryInterceptor(
+			metrics.UnaryClientInterceptor(),
+		),
+	}
+	if flags.Insecure {
+		opts = append(opts, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	} else {
+		opts = append(opts, grpc.WithTransportCredentials(credentials.NewTLS(&tls.Config{
+			InsecureSkipVerify: flags.InsecureSkipVerify,
+		})))
+	}
+
+	if flags.BearerToken != "" {
+		opts = append(opts, grpc.WithPerRPCCredentials(&perRequestBearerToken{
+			token:    flags.BearerToken,
+			insecure: flags.Insecure,
+		}))
+	}
+
+	if flags.BearerTokenFile != "" {
+		b, err := ioutil.ReadFile(flags.BearerTokenFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read bearer token from file: %w", err)
+		}
+		opts = append(opts, grpc.WithPerRPCCredentials(&perRequestBearerToken{
+			token:    strings.TrimSpace(string(b)),
+			insecure: flags.Insecure,
+		}))
+	}
+
+	if flags.Tenant != "" {
+		if err := tenant.Validate(flags.Tenant); err != nil {
+			return nil, err
+		}
+		opts = append(opts, grpc.WithPerRPCCredentials(&tenant.Credentials{
+			ID:       flags.Tenant,
+			Insecure: flags.Insecure,
+		}))
+	}
+
+	conn, err := grpc.Dial(flags.StoreAddress, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create gRPC connection: %w", err)
+	}
+
+	return conn, nil
+}
+
 func getDiscoveryConfigs(cfgs []*config.ScrapeConfig) map[string]discovery.Configs {
 	c := make(map[string]discovery.Configs)
 	for _, v := range cfgs {
@@ -477,6 +640,129 @@ func getDiscoveryConfigs(cfgs []*config.ScrapeConfig) map[string]discovery.Confi
 	return c
 }
 
+func profilestoreOptions(flags *Flags, ingesterOpts ...parcacol.IngesterOption) []profilestore.Option {
+	opts := []profilestore.Option{
+		profilestore.WithIngesterOptions(
+			parcacol.WithTimestampBounds(flags.StorageOutOfBoundsPast, flags.StorageOutOfBoundsFuture),
+			parcacol.WithInsertBatching(flags.StorageInsertBatchRows),
+		),
+		profilestore.WithIngesterOptions(ingesterOpts...),
+		profilestore.WithIngestConcurrency(flags.StorageIngestConcurrency),
+	}
+	if flags.HAReplicaLabel != "" {
+		opts = append(opts, profilestore.WithReplicaDeduplication(flags.HAReplicaLabel, flags.HAFailoverTimeout))
+	}
+	return opts
+}
+
+func getStacktraceFilters(cfgs []*config.ScrapeConfig) (map[string]*parcacol.StacktraceFilter, error) {
+	filters := make(map[string]*parcacol.StacktraceFilter)
+	for _, v := range cfgs {
+		c := v.StacktraceConfig
+		if c == nil {
+			continue
+		}
+
+		f, err := parcacol.NewStacktraceFilter(c.DropFrames, c.KeepFrames, c.MaxDepth, c.MaxStacks)
+		if err != nil {
+			return nil, fmt.Errorf("job %s: %w", v.JobName, err)
+		}
+		filters[v.JobName] = f
+	}
+	return filters, nil
+}
+
+// getRedactor returns nil if there are no redaction rules.
+func getRedactor(cfgs []*config.RedactionRule) (*parcacol.Redactor, error) {
+	if len(cfgs) == 0 {
+		return nil, nil
+	}
+
+	rules := make([]*parcacol.RedactionRule, 0, len(cfgs))
+	for i, c := range cfgs {
+		r, err := c.Rule()
+		if err != nil {
+			return nil, fmt.Errorf("redaction rule %d: %w", i, err)
+		}
+		rules = append(rules, r)
+	}
+	return parcacol.NewRedactor(rules...), nil
+}
+
+// getCardinalityLimiter returns nil if no cardinality limits are configured.
+func getCardinalityLimiter(reg prometheus.Registerer, flags *Flags) *parcacol.CardinalityLimiter {
+	limits := parcacol.CardinalityLimits{
+		MaxLabelNamesPerProfileType:  flags.LimitsMaxLabelNamesPerProfileType,
+		MaxLabelValuesPerProfileType: flags.LimitsMaxLabelValuesPerProfileType,
+		MaxPprofLabelNamesPerSeries:  flags.LimitsMaxPprofLabelNamesPerSeries,
+		MaxPprofLabelValuesPerSeries: flags.LimitsMaxPprofLabelValuesPerSeries,
+		Strip:                        flags.LimitsCardinalityAction == "strip",
+		Retention:                    flags.LimitsCardinalityRetention,
+	}
+	if limits.MaxLabelNamesPerProfileType <= 0 &&
+		limits.MaxLabelValuesPerProfileType <= 0 &&
+		limits.MaxPprofLabelNamesPerSeries <= 0 &&
+		limits.MaxPprofLabelValuesPerSeries <= 0 {
+		return nil
+	}
+	return parcacol.NewCardinalityLimiter(reg, limits)
+}
+
+// getIngestLimiter returns nil if no ingest limits are configured.
+func getIngestLimiter(reg prometheus.Registerer, flags *Flags, cfg *profilestore.IngestLimitsConfig) (*profilestore.IngestLimiter, error) {
+	if flags.LimitsAdminTenant != "" {
+		if err := tenant.Validate(flags.LimitsAdminTenant); err != nil {
+			return nil, fmt.Errorf("admin tenant: %w", err)
+		}
+	}
+
+	defaults := profilestore.IngestLimits{
+		ProfilesPerSecond:    flags.LimitsProfilesPerSecond,
+		ProfilesBurst:        flags.LimitsProfilesBurst,
+		BytesPerSecond:       flags.LimitsBytesPerSecond,
+		BytesBurst:           flags.LimitsBytesBurst,
+		MaxSamplesPerProfile: flags.LimitsMaxSamplesPerProfile,
+		MaxActiveSeries:      flags.LimitsMaxActiveSeries,
+	}
+	if defaults == (profilestore.IngestLimits{}) && (cfg == nil || len(cfg.Tenants) == 0 && len(cfg.Labels) == 0) {
+		return nil, nil
+	}
+	return profilestore.NewIngestLimiter(reg, defaults, cfg, flags.LimitsActiveSeriesTimeout)
+}
+
+// getRawProfileArchive returns an archive in the bucket of the debug info
+// store.
+func getRawProfileArchive(logger log.Logger, cfg *debuginfo.Config) (*rawprofile.Archive, error) {
+	bucketCfg, err := yaml.Marshal(cfg.Bucket)
+	if err != nil {
+		return nil, fmt.Errorf("marshal content of object storage configuration: %w", err)
+	}
+
+	bucket, err := client.NewBucket(logger, bucketCfg, nil, "parca/rawprofiles")
+	if err != nil {
+		return nil, fmt.Errorf("instantiate object storage: %w", err)
+	}
+
+	return rawprofile.NewArchive(bucket), nil
+}
+
+// getSourcePathMapper returns nil if there are no source path rules.
+func getSourcePathMapper(cfgs []*config.SourcePathRule) (*sourcepath.Mapper, error) {
+	if len(cfgs) == 0 {
+		return nil, nil
+	}
+
+	rules := make([]*sourcepath.Rule, 0, len(cfgs))
+	for i, c := range cfgs {
+		r, err := c.Rule()
+		if err != nil {
+			return nil, fmt.Errorf("source path rule %d: %w", i, err)
+		}
+		rules = append(rules, r)
+	}
+	return sourcepath.NewMapper(rules...), nil
+}
+
 func initTracer(logger log.Logger, otlpAddress string) (trace.TracerProvider, func(), error) {
 	ctx := context.Background()
 
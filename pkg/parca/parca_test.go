@@ -14,20 +14,15 @@
 package parca
 
 import (
-	"bytes"
 	"context"
 	"errors"
 	"io/ioutil"
 	"os"
-	"path/filepath"
-	"sort"
-	"strings"
 	"testing"
 	"time"
 
 	"github.com/apache/arrow/go/v8/arrow/memory"
 	"github.com/cenkalti/backoff/v4"
-	"github.com/fatih/semgroup"
 	"github.com/go-kit/log"
 	"github.com/google/pprof/profile"
 	"github.com/polarsignals/arcticdb"
@@ -36,7 +31,6 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/prometheus/model/labels"
 	"github.com/prometheus/prometheus/model/timestamp"
-	"github.com/prometheus/prometheus/promql/parser"
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/otel/trace"
 	"google.golang.org/grpc"
@@ -47,8 +41,9 @@ import (
 	querypb "github.com/parca-dev/parca/gen/proto/go/parca/query/v1alpha1"
 	"github.com/parca-dev/parca/pkg/metastore"
 	"github.com/parca-dev/parca/pkg/parcacol"
-	parcaprofile "github.com/parca-dev/parca/pkg/profile"
+	"github.com/parca-dev/parca/pkg/profilestore"
 	queryservice "github.com/parca-dev/parca/pkg/query"
+	"github.com/parca-dev/parca/pkg/replay"
 )
 
 func benchmarkSetup(ctx context.Context, b *testing.B) (pb.ProfileStoreServiceClient, <-chan struct{}) {
@@ -140,57 +135,10 @@ func Benchmark_WriteRaw(b *testing.B) {
 	<-done
 }
 
-func replayDebugLog(ctx context.Context, t require.TestingT) (querypb.QueryServiceServer, *arcticdb.Table, *semgroup.Group, func()) {
-	dir := "../../tmp/"
-	files, err := ioutil.ReadDir(dir)
+func replayDebugLog(ctx context.Context, t require.TestingT) (querypb.QueryServiceServer, *arcticdb.Table, func() error, func()) {
+	profiles, err := replay.ReadDir("../../tmp/")
 	require.NoError(t, err)
 
-	type Sample struct {
-		Timestamp int64
-		Labels    labels.Labels
-		FilePath  string
-	}
-
-	var samples []Sample
-	for _, file := range files {
-		if file.IsDir() {
-			matchers, err := parser.ParseMetricSelector(file.Name())
-			if err != nil {
-				t.Errorf("failed to parse label-set %s: %v", file.Name(), err)
-				continue
-			}
-
-			ls := make(labels.Labels, 0, len(matchers))
-			for _, matcher := range matchers {
-				ls = append(ls, labels.Label{
-					Name:  matcher.Name,
-					Value: matcher.Value,
-				})
-			}
-			sort.Sort(ls)
-
-			sampleFiles, err := ioutil.ReadDir(filepath.Join(dir, file.Name()))
-			require.NoError(t, err)
-
-			for _, sampleFile := range sampleFiles {
-				if sampleFile.IsDir() {
-					continue
-				}
-				if strings.HasSuffix(sampleFile.Name(), ".pb.gz") {
-					samples = append(samples, Sample{
-						Timestamp: sampleFile.ModTime().Unix(),
-						Labels:    ls,
-						FilePath:  filepath.Join(dir, file.Name(), sampleFile.Name()),
-					})
-				}
-			}
-		}
-	}
-
-	sort.Slice(samples, func(i, j int) bool {
-		return samples[i].Timestamp < samples[j].Timestamp
-	})
-
 	logger := log.NewNopLogger()
 	reg := prometheus.NewRegistry()
 	tracer := trace.NewNoopTracerProvider().Tracer("")
@@ -224,38 +172,21 @@ func replayDebugLog(ctx context.Context, t require.TestingT) (querypb.QueryServi
 			memory.DefaultAllocator,
 			colDB.TableProvider(),
 		),
-		"stacktraces",
+		parcacol.NewTables(logger, colDB, "stacktraces"),
 	)
 
-	const maxWorkers = 8
-	s := semgroup.NewGroup(ctx, maxWorkers)
-	for _, sample := range samples {
-		s.Go(func() error {
-			fileContent, err := ioutil.ReadFile(sample.FilePath)
-			if err != nil {
-				return err
-			}
-			p, err := profile.Parse(bytes.NewBuffer(fileContent))
-			if err != nil {
-				return err
-			}
-			profiles, err := parcaprofile.ProfilesFromPprof(ctx, logger, m, p, false)
-			if err != nil {
-				return err
-			}
-
-			for _, profile := range profiles {
-				_, err = parcacol.InsertProfileIntoTable(ctx, logger, table, sample.Labels, profile)
-				if err != nil {
-					return err
-				}
-			}
+	store := profilestore.NewProfileColumnStore(logger, reg, tracer, m, parcacol.NewTables(logger, colDB, "stacktraces"), false)
 
-			return nil
-		})
-	}
+	done := make(chan error, 1)
+	go func() {
+		_, err := replay.Replay(ctx, logger, func(ctx context.Context, req *pb.WriteRawRequest) error {
+			_, err := store.WriteRaw(ctx, req)
+			return err
+		}, profiles)
+		done <- err
+	}()
 
-	return api, table, s, func() {
+	return api, table, func() error { return <-done }, func() {
 		m.Close()
 	}
 }
@@ -267,7 +198,7 @@ func TestReplay(t *testing.T) {
 	t.Skip()
 
 	ctx := context.Background()
-	api, table, s, cleanup := replayDebugLog(ctx, t)
+	api, table, wait, cleanup := replayDebugLog(ctx, t)
 	t.Cleanup(cleanup)
 
 	go func() {
@@ -286,15 +217,15 @@ func TestReplay(t *testing.T) {
 		}
 	}()
 
-	require.NoError(t, s.Wait())
+	require.NoError(t, wait())
 	table.Sync()
 }
 
 func BenchmarkValuesAPI(b *testing.B) {
 	ctx := context.Background()
-	api, table, s, cleanup := replayDebugLog(ctx, b)
+	api, table, wait, cleanup := replayDebugLog(ctx, b)
 	b.Cleanup(cleanup)
-	require.NoError(b, s.Wait())
+	require.NoError(b, wait())
 	table.Sync()
 
 	b.ResetTimer()
@@ -354,7 +285,7 @@ func TestConsistency(t *testing.T) {
 			memory.DefaultAllocator,
 			colDB.TableProvider(),
 		),
-		"stacktraces",
+		parcacol.NewTables(logger, colDB, "stacktraces"),
 	)
 
 	ts := timestamppb.New(timestamp.Time(p1.TimeNanos / time.Millisecond.Nanoseconds()))
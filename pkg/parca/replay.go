@@ -0,0 +1,102 @@
+// Copyright 2022 The Parca Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parca
+
+import (
+	"context"
+
+	"github.com/go-kit/log"
+	"github.com/go-kit/log/level"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.opentelemetry.io/otel/trace"
+
+	profilestorepb "github.com/parca-dev/parca/gen/proto/go/parca/profilestore/v1alpha1"
+	"github.com/parca-dev/parca/pkg/profilestore"
+	"github.com/parca-dev/parca/pkg/replay"
+)
+
+type ReplayFlags struct {
+	Dir         string  `arg:"" optional:"" default:"tmp" help:"Directory of the debug value log to replay."`
+	Realtime    bool    `default:"false" help:"Reproduce the timing of the original writes instead of replaying as fast as possible."`
+	Speed       float64 `default:"1" help:"Factor to speed up the replay by in realtime mode."`
+	Concurrency int     `default:"8" help:"Number of series to replay concurrently when not replaying in realtime."`
+}
+
+// RunReplay replays the debug value log into the store at the store address,
+// or into an in-process store if no store address is configured.
+func RunReplay(ctx context.Context, logger log.Logger, reg *prometheus.Registry, flags *Flags, replayFlags *ReplayFlags) error {
+	profiles, err := replay.ReadDir(replayFlags.Dir)
+	if err != nil {
+		level.Error(logger).Log("msg", "failed to read debug value log", "dir", replayFlags.Dir, "err", err)
+		return err
+	}
+
+	var write replay.WriteRawFunc
+	if flags.StoreAddress != "" {
+		conn, err := storeConn(reg, flags)
+		if err != nil {
+			return err
+		}
+		defer conn.Close()
+
+		client := profilestorepb.NewProfileStoreServiceClient(conn)
+		write = func(ctx context.Context, req *profilestorepb.WriteRawRequest) error {
+			_, err := client.WriteRaw(ctx, req)
+			return err
+		}
+	} else {
+		tracerProvider := trace.NewNoopTracerProvider()
+		mStr, _, tables, err := openStorage(logger, reg, tracerProvider, flags)
+		if err != nil {
+			return err
+		}
+
+		s := profilestore.NewProfileColumnStore(
+			logger,
+			reg,
+			tracerProvider.Tracer("profilestore"),
+			mStr,
+			tables,
+			false,
+			profilestoreOptions(flags)...,
+		)
+		write = func(ctx context.Context, req *profilestorepb.WriteRawRequest) error {
+			_, err := s.WriteRaw(ctx, req)
+			return err
+		}
+	}
+
+	opts := []replay.Option{replay.WithConcurrency(replayFlags.Concurrency)}
+	if replayFlags.Realtime {
+		opts = append(opts, replay.WithRealtime(replayFlags.Speed))
+	}
+
+	level.Info(logger).Log("msg", "replaying debug value log", "dir", replayFlags.Dir, "profiles", len(profiles))
+	stats, err := replay.Replay(ctx, logger, write, profiles, opts...)
+	if err != nil {
+		level.Error(logger).Log("msg", "failed to replay debug value log", "err", err)
+		return err
+	}
+
+	level.Info(logger).Log(
+		"msg", "replayed debug value log",
+		"profiles", stats.Profiles,
+		"rejected", stats.Rejected,
+		"bytes", stats.Bytes,
+		"duration", stats.Duration,
+		"profiles_per_second", float64(stats.Profiles)/stats.Duration.Seconds(),
+	)
+
+	return nil
+}
@@ -16,6 +16,8 @@ package parcacol
 import (
 	"context"
 	"fmt"
+	"sort"
+	"strings"
 
 	"github.com/apache/arrow/go/v8/arrow"
 	"github.com/apache/arrow/go/v8/arrow/array"
@@ -30,6 +32,38 @@ func ArrowRecordToStacktraceSamples(
 	ar arrow.Record,
 	valueColumnName string,
 ) (*profile.StacktraceSamples, error) {
+	groups, err := ArrowRecordToStacktraceSamplesGroups(ctx, metaStore, ar, valueColumnName, nil)
+	if err != nil {
+		return nil, err
+	}
+	if len(groups) == 0 {
+		return &profile.StacktraceSamples{
+			Samples: []*profile.Sample{},
+		}, nil
+	}
+
+	return groups[0].Samples, nil
+}
+
+// StacktraceSamplesGroup are the samples of the rows of a record with the
+// same values of the group columns.
+type StacktraceSamplesGroup struct {
+	// Values are the values of the group columns, empty if they are null or
+	// the record has no such column.
+	Values  []string
+	Samples *profile.StacktraceSamples
+}
+
+// ArrowRecordToStacktraceSamplesGroups partitions the rows of a record by the
+// values of the group columns and returns the samples of each group, sorted
+// by their values. The stacktraces of all groups are resolved at once.
+func ArrowRecordToStacktraceSamplesGroups(
+	ctx context.Context,
+	metaStore metastore.ProfileMetaStore,
+	ar arrow.Record,
+	valueColumnName string,
+	groupColumnNames []string,
+) ([]StacktraceSamplesGroup, error) {
 	// sample is an intermediate representation used before
 	// we actually have the profile.Sample assembled from the metastore.
 	type sample struct {
@@ -39,29 +73,61 @@ func ArrowRecordToStacktraceSamples(
 	}
 
 	schema := ar.Schema()
-	indices := schema.FieldIndices("stacktrace")
+	indices := schema.FieldIndices(ColumnStacktrace)
 	if len(indices) != 1 {
 		return nil, fmt.Errorf("expected exactly one stacktrace column, got %d", len(indices))
 	}
 	stacktraceColumn := ar.Column(indices[0]).(*array.Binary)
 
-	indices = schema.FieldIndices("sum(value)")
+	indices = schema.FieldIndices(valueColumnName)
 	if len(indices) != 1 {
 		return nil, fmt.Errorf("expected exactly one value column, got %d", len(indices))
 	}
 	valueColumn := ar.Column(indices[0]).(*array.Int64)
 
+	groupColumns := make([]*array.Binary, len(groupColumnNames))
+	for i, name := range groupColumnNames {
+		indices := schema.FieldIndices(name)
+		if len(indices) > 1 {
+			return nil, fmt.Errorf("expected at most one column named %q, got %d", name, len(indices))
+		}
+		if len(indices) == 0 {
+			continue
+		}
+		col, ok := ar.Column(indices[0]).(*array.Binary)
+		if !ok {
+			return nil, fmt.Errorf("expected column %q to be a binary column, got %T", name, ar.Column(indices[0]))
+		}
+		groupColumns[i] = col
+	}
+
+	type group struct {
+		values  []string
+		samples []*sample
+	}
+
 	rows := int(ar.NumRows())
-	samples := make([]*sample, 0, rows)
+	groups := map[string]*group{}
 	stacktraceUUIDs := make([][]byte, 0, rows)
 	for i := 0; i < rows; i++ {
-		stacktraceID := stacktraceColumn.Value(i)
-		value := valueColumn.Value(i)
+		values := make([]string, len(groupColumns))
+		for j, col := range groupColumns {
+			if col != nil && !col.IsNull(i) {
+				values[j] = string(col.Value(i))
+			}
+		}
+		key := strings.Join(values, "\xff")
+		g, ok := groups[key]
+		if !ok {
+			g = &group{values: values}
+			groups[key] = g
+		}
 
+		stacktraceID := stacktraceColumn.Value(i)
 		stacktraceUUIDs = append(stacktraceUUIDs, stacktraceID)
-		samples = append(samples, &sample{
+		g.samples = append(g.samples, &sample{
 			stacktraceID: stacktraceID,
-			value:        value,
+			value:        valueColumn.Value(i),
 		})
 	}
 
@@ -86,25 +152,41 @@ func ArrowRecordToStacktraceSamples(
 		return nil, err
 	}
 
-	for _, s := range samples {
-		s.locationIDs = stacktraceMap[string(s.stacktraceID)].LocationIds
-	}
+	res := make([]StacktraceSamplesGroup, 0, len(groups))
+	for _, g := range groups {
+		stackSamples := make([]*profile.Sample, 0, len(g.samples))
+		for _, s := range g.samples {
+			s.locationIDs = stacktraceMap[string(s.stacktraceID)].LocationIds
 
-	stackSamples := make([]*profile.Sample, 0, len(samples))
-	for _, s := range samples {
-		stackSample := &profile.Sample{
-			Value:    s.value,
-			Location: make([]*metastore.Location, 0, len(s.locationIDs)),
-		}
+			stackSample := &profile.Sample{
+				Value:    s.value,
+				Location: make([]*metastore.Location, 0, len(s.locationIDs)),
+			}
+
+			for _, l := range s.locationIDs {
+				stackSample.Location = append(stackSample.Location, locationsMap[string(l)])
+			}
 
-		for _, l := range s.locationIDs {
-			stackSample.Location = append(stackSample.Location, locationsMap[string(l)])
+			stackSamples = append(stackSamples, stackSample)
 		}
 
-		stackSamples = append(stackSamples, stackSample)
+		res = append(res, StacktraceSamplesGroup{
+			Values: g.values,
+			Samples: &profile.StacktraceSamples{
+				Samples: stackSamples,
+			},
+		})
 	}
 
-	return &profile.StacktraceSamples{
-		Samples: stackSamples,
-	}, nil
+	sort.Slice(res, func(i, j int) bool {
+		a, b := res[i].Values, res[j].Values
+		for k := range a {
+			if a[k] != b[k] {
+				return a[k] < b[k]
+			}
+		}
+		return false
+	})
+
+	return res, nil
 }
@@ -0,0 +1,116 @@
+// Copyright 2022 The Parca Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parcacol
+
+import (
+	"context"
+	"sync"
+)
+
+// insertBatcher merges the samples of concurrent insertions into a single
+// sorted buffer, as building, sorting and inserting a buffer is costly
+// regardless of its size.
+//
+// Only one batch is inserted at a time. The samples arriving meanwhile are
+// collected into the next batch, which is inserted by the first of its
+// callers once the table is done with the previous one. An idle table
+// therefore doesn't delay insertions. Batches hold at most maxRows samples
+// unless a single insertion is larger, callers wait for the next batch if
+// the pending one is full, which applies backpressure while the table is
+// busy.
+type insertBatcher struct {
+	maxRows int
+	insert  func(context.Context, Samples) error
+	metrics *IngestMetrics
+
+	// inserting is held while a batch is inserted.
+	inserting chan struct{}
+
+	mtx     sync.Mutex
+	pending *insertBatch
+}
+
+type insertBatch struct {
+	samples Samples
+	// taken is closed once the batch is no longer pending, done once it
+	// was inserted.
+	taken chan struct{}
+	done  chan struct{}
+	err   error
+}
+
+func newInsertBatcher(maxRows int, insert func(context.Context, Samples) error, metrics *IngestMetrics) *insertBatcher {
+	return &insertBatcher{
+		maxRows:   maxRows,
+		insert:    insert,
+		metrics:   metrics,
+		inserting: make(chan struct{}, 1),
+	}
+}
+
+// add inserts the samples as part of a batch and returns once the batch was
+// inserted. The context only cancels waiting for a batch to join, once the
+// samples joined one add waits for it to be inserted regardless, so that the
+// result always tells whether the samples were inserted.
+func (b *insertBatcher) add(ctx context.Context, samples Samples) error {
+	for {
+		b.mtx.Lock()
+		batch := b.pending
+		if batch != nil && len(batch.samples)+len(samples) > b.maxRows {
+			b.mtx.Unlock()
+			select {
+			case <-batch.taken:
+				continue
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+
+		leader := batch == nil
+		if leader {
+			batch = &insertBatch{
+				taken: make(chan struct{}),
+				done:  make(chan struct{}),
+			}
+			b.pending = batch
+		}
+		batch.samples = append(batch.samples, samples...)
+		b.mtx.Unlock()
+
+		if leader {
+			b.flush(batch)
+		}
+
+		<-batch.done
+		return batch.err
+	}
+}
+
+// flush inserts the batch once the previous one was inserted.
+func (b *insertBatcher) flush(batch *insertBatch) {
+	b.inserting <- struct{}{}
+	defer func() { <-b.inserting }()
+
+	b.mtx.Lock()
+	b.pending = nil
+	close(batch.taken)
+	b.mtx.Unlock()
+
+	b.metrics.observeBatch(len(batch.samples))
+
+	// The batch holds the samples of other callers too, so it is inserted
+	// even if the context of the leader is canceled.
+	batch.err = b.insert(context.Background(), batch.samples)
+	close(batch.done)
+}
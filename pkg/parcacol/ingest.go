@@ -24,10 +24,12 @@ import (
 	"github.com/google/pprof/profile"
 	"github.com/google/uuid"
 	"github.com/polarsignals/arcticdb/dynparquet"
+	"github.com/prometheus/common/model"
 	"github.com/prometheus/prometheus/model/labels"
 
 	pb "github.com/parca-dev/parca/gen/proto/go/parca/metastore/v1alpha1"
 	"github.com/parca-dev/parca/pkg/metastore"
+	"github.com/parca-dev/parca/pkg/sourcepath"
 )
 
 type Table interface {
@@ -39,30 +41,199 @@ type Ingester struct {
 	logger    log.Logger
 	table     Table
 	metaStore metastore.ProfileMetaStore // Swap with local interface
+
+	// stacktraceFilters are keyed by the job label of the ingested series.
+	stacktraceFilters map[string]*StacktraceFilter
+	redactor          *Redactor
+	sourcePaths       *sourcepath.Mapper
+	limiter           *CardinalityLimiter
+	arrowLimiter      ProfileLimiter
+	tenant            string
+	metrics           *IngestMetrics
+
+	// batcher is nil unless insertions are batched.
+	batcher      *insertBatcher
+	maxBatchRows int
+
+	series    *seriesTracker
+	now       func() time.Time
+	maxPast   time.Duration
+	maxFuture time.Duration
+}
+
+type IngesterOption func(*Ingester)
+
+// WithStacktraceFilters configures the stack rewrites for the series of the
+// given jobs.
+func WithStacktraceFilters(filters map[string]*StacktraceFilter) IngesterOption {
+	return func(ing *Ingester) {
+		ing.stacktraceFilters = filters
+	}
+}
+
+// WithRedactor redacts file paths and label values of all ingested profiles.
+func WithRedactor(r *Redactor) IngesterOption {
+	return func(ing *Ingester) {
+		ing.redactor = r
+	}
+}
+
+// WithSourcePathMapper rewrites the filenames of the functions of all ingested
+// profiles.
+func WithSourcePathMapper(m *sourcepath.Mapper) IngesterOption {
+	return func(ing *Ingester) {
+		ing.sourcePaths = m
+	}
+}
+
+// WithCardinalityLimiter limits the distinct label names and values of the
+// ingested profiles.
+func WithCardinalityLimiter(l *CardinalityLimiter) IngesterOption {
+	return func(ing *Ingester) {
+		ing.limiter = l
+	}
+}
+
+// ProfileLimiter enforces the ingest limits of the tenants, like the rate of
+// profiles and bytes and the number of samples and active series.
+type ProfileLimiter interface {
+	// Allow returns an error if a profile of the series of the given size in
+	// bytes and with the given number of samples exceeds a limit of the
+	// tenant.
+	Allow(tenantID string, ls labels.Labels, size, samples int) error
+}
+
+// WithArrowLimiter enforces the ingest limits for every series and timestamp
+// of the records passed to IngestArrow, which are rejected like profiles that
+// are out of order. Profiles passed to Ingest are expected to be limited by
+// the caller before they are parsed.
+func WithArrowLimiter(l ProfileLimiter) IngesterOption {
+	return func(ing *Ingester) {
+		ing.arrowLimiter = l
+	}
+}
+
+// WithTenant sets the tenant the profiles are ingested for, the cardinality
+// limits are enforced for every tenant separately.
+func WithTenant(tenantID string) IngesterOption {
+	return func(ing *Ingester) {
+		ing.tenant = tenantID
+	}
+}
+
+// WithIngestMetrics instruments the ingestion of profiles.
+func WithIngestMetrics(m *IngestMetrics) IngesterOption {
+	return func(ing *Ingester) {
+		ing.metrics = m
+	}
+}
+
+// WithInsertBatching merges the samples of concurrent insertions into
+// batches of up to the given number of rows, which are inserted one at a
+// time. Zero disables batching.
+func WithInsertBatching(maxRows int) IngesterOption {
+	return func(ing *Ingester) {
+		ing.maxBatchRows = maxRows
+	}
 }
 
-func NewIngester(logger log.Logger, metaStore metastore.ProfileMetaStore, table Table) *Ingester {
-	return &Ingester{logger: logger, metaStore: metaStore, table: table}
+// WithTimestampBounds rejects profiles with timestamps further in the past or
+// the future than the given durations. A zero duration disables the
+// respective bound.
+func WithTimestampBounds(maxPast, maxFuture time.Duration) IngesterOption {
+	return func(ing *Ingester) {
+		ing.maxPast = maxPast
+		ing.maxFuture = maxFuture
+	}
+}
+
+func NewIngester(logger log.Logger, metaStore metastore.ProfileMetaStore, table Table, opts ...IngesterOption) *Ingester {
+	ing := &Ingester{
+		logger:    logger,
+		metaStore: metaStore,
+		table:     table,
+		now:       time.Now,
+	}
+	for _, opt := range opts {
+		opt(ing)
+	}
+	retention := defaultSeriesRetention
+	if ing.maxPast > 0 {
+		retention = ing.maxPast
+	}
+	ing.series = newSeriesTracker(retention)
+	if ing.maxBatchRows > 0 {
+		ing.batcher = newInsertBatcher(ing.maxBatchRows, ing.insertSamples, ing.metrics)
+	}
+	return ing
 }
 
 var ErrMissingNameLabel = errors.New("missing __name__ label")
 
-func (ing Ingester) Ingest(ctx context.Context, inLs labels.Labels, p *profile.Profile, normalized bool) error {
-	samples, err := ing.ConvertPProf(ctx, inLs, p, normalized)
+// Ingest writes the profile to the table. Profiles with a timestamp out of the
+// configured bounds, or not newer than the last profile of the same series,
+// are rejected with ErrOutOfBounds, ErrDuplicateSample or ErrOutOfOrderSample.
+// Profiles exceeding the cardinality limits are rejected with
+// ErrCardinalityLimit, unless the limiter strips the offending labels.
+// The timestamp is only recorded for the series once the profile was
+// inserted, so that profiles failing to be ingested can be retried.
+func (ing Ingester) Ingest(ctx context.Context, inLs labels.Labels, p *profile.Profile, normalized bool) (err error) {
+	now := ing.now()
+	if err := checkTimestampBounds(now, p.TimeNanos, ing.maxPast, ing.maxFuture); err != nil {
+		return err
+	}
+
+	name, ls, err := ing.seriesLabels(inLs)
 	if err != nil {
 		return err
 	}
 
-	for _, s := range samples {
-		if err := ing.IngestSamples(ctx, s); err != nil {
-			return err
-		}
+	// The labels only count towards the cardinality limits once the profile
+	// is known not to be a duplicate or out of order.
+	hash, err := ing.series.reserve(now, inLs, p.TimeNanos)
+	if err != nil {
+		return err
 	}
+	defer func() {
+		ing.series.done(hash, p.TimeNanos, err == nil)
+	}()
 
-	return nil
+	ls, stripped, err := ing.limiter.limit(now, ing.tenant, name, ls, ing.pprofLabels(p))
+	if err != nil {
+		return err
+	}
+
+	samples, err := ing.convertPProf(ctx, name, ls, stripped, inLs.Get(model.JobLabel), p, normalized)
+	if err != nil {
+		return err
+	}
+
+	// The samples of all sample types are inserted at once.
+	n := 0
+	for _, s := range samples {
+		n += len(s)
+	}
+	if n == 0 {
+		return nil
+	}
+	rows := make(Samples, 0, n)
+	for _, s := range samples {
+		rows = append(rows, s...)
+	}
+	return ing.IngestSamples(ctx, rows)
 }
 
 func (ing Ingester) ConvertPProf(ctx context.Context, inLs labels.Labels, p *profile.Profile, normalized bool) ([]Samples, error) {
+	name, ls, err := ing.seriesLabels(inLs)
+	if err != nil {
+		return nil, err
+	}
+	return ing.convertPProf(ctx, name, ls, nil, inLs.Get(model.JobLabel), p, normalized)
+}
+
+// seriesLabels returns the name of the profiles of the series, and its
+// redacted labels excluding the __name__ label.
+func (ing Ingester) seriesLabels(inLs labels.Labels) (string, labels.Labels, error) {
 	// We need to extract the name from the labels into a separate column.
 	// The labels are the same excluding the __name__.
 	var name string
@@ -71,19 +242,52 @@ func (ing Ingester) ConvertPProf(ctx context.Context, inLs labels.Labels, p *pro
 		if l.Name == labels.MetricName {
 			name = l.Value
 		} else {
-			ls = append(ls, l)
+			ls = append(ls, labels.Label{Name: l.Name, Value: ing.redactor.Label(l.Name, l.Value)})
 		}
 	}
 	if name == "" {
-		return nil, ErrMissingNameLabel
+		return "", nil, ErrMissingNameLabel
 	}
 	sort.Sort(ls)
+	return name, ls, nil
+}
+
+// pprofLabels returns the distinct redacted string labels of the samples of
+// the profile, sorted by name and value.
+func (ing Ingester) pprofLabels(p *profile.Profile) []labels.Label {
+	if ing.limiter == nil {
+		return nil
+	}
+
+	seen := map[labels.Label]struct{}{}
+	for _, s := range p.Sample {
+		for k, v := range s.Label {
+			if len(v) == 1 {
+				seen[labels.Label{Name: k, Value: ing.redactor.PprofLabel(k, v[0])}] = struct{}{}
+			}
+		}
+	}
+
+	return sortedLabels(seen)
+}
+
+func (ing Ingester) convertPProf(ctx context.Context, name string, ls labels.Labels, stripped strippedLabels, job string, p *profile.Profile, normalized bool) ([]Samples, error) {
+	var pf *profileFilter
+	if f, ok := ing.stacktraceFilters[job]; ok {
+		pf = newProfileFilter(f, p)
+	}
 
 	samples := make([]Samples, 0, len(p.SampleType))
 	for i := range p.SampleType {
+		start := time.Now()
 		pn := &profileNormalizer{
-			logger:    ing.logger,
-			metaStore: ing.metaStore,
+			logger:      ing.logger,
+			metaStore:   ing.metaStore,
+			redactor:    ing.redactor,
+			sourcePaths: ing.sourcePaths,
+			metrics:     ing.metrics,
+			name:        name,
+			stripped:    stripped,
 
 			samples:       make(map[string]*Sample, len(p.Sample)),
 			locationsByID: make(map[uint64]*metastore.Location, len(p.Location)),
@@ -112,14 +316,21 @@ func (ing Ingester) ConvertPProf(ctx context.Context, inLs labels.Labels, p *pro
 			Timestamp:  p.TimeNanos / time.Millisecond.Nanoseconds(),
 		}
 
+		pprofSamples := p.Sample
+		if pf != nil {
+			pprofSamples = pf.samples(p.Sample, i)
+		}
+
 		// All samples for this sample type
-		typeSamples := make(Samples, 0, len(p.Sample))
-		for _, s := range p.Sample {
+		typeSamples := make(Samples, 0, len(pprofSamples))
+		var ingested, zeroValue, noLocations, stacktraces int
+		for _, s := range pprofSamples {
 			select {
 			case <-ctx.Done():
 				return nil, ctx.Err()
 			default:
 				if isZeroSample(s) {
+					zeroValue++
 					continue
 				}
 
@@ -128,29 +339,59 @@ func (ing Ingester) ConvertPProf(ctx context.Context, inLs labels.Labels, p *pro
 				// stacktrace UUIDs since location IDs are going to be saved directly
 				// in the columnstore.
 				if len(s.Location) == 0 {
+					noLocations++
 					continue
 				}
 
-				sample, _, err := pn.mapSample(ctx, s, meta, i, normalized)
+				sample, created, err := pn.mapSample(ctx, s, meta, i, normalized)
 				if err != nil {
 					return nil, err
 				}
+				ingested++
+				if created {
+					stacktraces++
+				}
 
 				typeSamples = append(typeSamples, sample)
 			}
 		}
 		samples = append(samples, typeSamples)
+
+		ing.metrics.observeSamples(name, meta.SampleType, ingested, zeroValue, noLocations, stacktraces)
+		ing.metrics.ObserveStage(name, meta.SampleType, StageConvert, start)
 	}
 
 	return samples, nil
 }
 
+// IngestSamples inserts the samples into the table, as part of a batch if
+// insertions are batched.
 func (ing Ingester) IngestSamples(ctx context.Context, samples Samples) error {
+	if ing.metrics != nil {
+		var name, sampleType recordLabel
+		for _, s := range samples {
+			name.observe(s.Name)
+			sampleType.observe(s.SampleType)
+		}
+		defer ing.metrics.ObserveStage(name.value, sampleType.value, StageInsert, time.Now())
+	}
+
+	if ing.batcher != nil {
+		return ing.batcher.add(ctx, samples)
+	}
+	return ing.insertSamples(ctx, samples)
+}
+
+func (ing Ingester) insertSamples(ctx context.Context, samples Samples) error {
 	buffer, err := samples.ToBuffer(Schema())
 	if err != nil {
 		return fmt.Errorf("failed to convert samples to buffer: %w", err)
 	}
 
+	return ing.insertBuffer(ctx, buffer)
+}
+
+func (ing Ingester) insertBuffer(ctx context.Context, buffer *dynparquet.Buffer) error {
 	buffer.Sort()
 
 	// This is necessary because sorting a buffer makes concurrent reading not
@@ -158,7 +399,7 @@ func (ing Ingester) IngestSamples(ctx context.Context, samples Samples) error {
 	// executes the cyclic sort once and makes the resulting buffer safe for
 	// concurrent reading as it no longer has to perform the cyclic sorting at
 	// read time. This should probably be improved in the parquet library.
-	buffer, err = buffer.Clone()
+	buffer, err := buffer.Clone()
 	if err != nil {
 		return err
 	}
@@ -181,8 +422,15 @@ func isZeroSample(s *profile.Sample) bool {
 }
 
 type profileNormalizer struct {
-	logger    log.Logger
-	metaStore metastore.ProfileMetaStore
+	logger      log.Logger
+	metaStore   metastore.ProfileMetaStore
+	redactor    *Redactor
+	sourcePaths *sourcepath.Mapper
+	metrics     *IngestMetrics
+	// name of the profiles, metastore entries created are attributed to.
+	name string
+	// stripped are the pprof labels exceeding the cardinality limits.
+	stripped strippedLabels
 
 	samples map[string]*Sample
 	// Memoization tables within a profile.
@@ -230,7 +478,9 @@ func (pn *profileNormalizer) mapSample(ctx context.Context, s *profile.Sample, m
 	}
 	for k, v := range s.Label {
 		if len(v) == 1 {
-			sn.Label[k] = v[0]
+			if v := pn.redactor.PprofLabel(k, v[0]); !pn.stripped.contains(k, v) {
+				sn.Label[k] = v
+			}
 		}
 	}
 	for k, v := range s.NumLabel {
@@ -243,14 +493,48 @@ func (pn *profileNormalizer) mapSample(ctx context.Context, s *profile.Sample, m
 		}
 	}
 
+	// Add current values to the existing sample.
+	stacktraceUUID, err := pn.mapStacktrace(ctx, sn)
+	if err != nil {
+		return nil, false, err
+	}
+
+	sa, found := pn.samples[string(stacktraceUUID[:])]
+	if found {
+		sa.Value += s.Value[index]
+		return sa, false, nil
+	}
+
+	pn.samples[string(stacktraceUUID[:])] = &Sample{
+		Name:       meta.Name,
+		Labels:     meta.Labels,
+		Duration:   meta.Duration,
+		Period:     meta.Period,
+		PeriodType: meta.PeriodType,
+		PeriodUnit: meta.PeriodUnit,
+		SampleType: meta.SampleType,
+		SampleUnit: meta.SampleUnit,
+		Timestamp:  meta.Timestamp,
+
+		Stacktrace:     stacktraceUUID[:],
+		PprofLabels:    sn.Label,
+		PprofNumLabels: sn.NumLabel,
+		Value:          s.Value[index],
+	}
+
+	return pn.samples[string(stacktraceUUID[:])], true, nil
+}
+
+// mapStacktrace returns the ID of the stacktrace of the sample, creating it
+// in the metastore if it doesn't exist yet.
+func (pn *profileNormalizer) mapStacktrace(ctx context.Context, sn *SampleNormalizer) (uuid.UUID, error) {
 	// Check memoization table. Must be done on the remapped location to
-	// account for the remapped mapping. Add current values to the
-	// existing sample.
+	// account for the remapped mapping.
 	k := MakeStacktraceKey(sn)
 
 	stacktraceUUID, err := pn.metaStore.GetStacktraceByKey(ctx, k)
 	if err != nil && err != metastore.ErrStacktraceNotFound {
-		return nil, false, err
+		return uuid.Nil, err
 	}
 
 	if stacktraceUUID == uuid.Nil {
@@ -277,34 +561,12 @@ func (pn *profileNormalizer) mapSample(ctx context.Context, s *profile.Sample, m
 
 		stacktraceUUID, err = pn.metaStore.CreateStacktrace(ctx, k, pbs)
 		if err != nil {
-			return nil, false, err
+			return uuid.Nil, err
 		}
+		pn.metrics.observeCreated(pn.name, "stacktrace")
 	}
 
-	sa, found := pn.samples[string(stacktraceUUID[:])]
-	if found {
-		sa.Value += s.Value[index]
-		return sa, false, nil
-	}
-
-	pn.samples[string(stacktraceUUID[:])] = &Sample{
-		Name:       meta.Name,
-		Labels:     meta.Labels,
-		Duration:   meta.Duration,
-		Period:     meta.Period,
-		PeriodType: meta.PeriodType,
-		PeriodUnit: meta.PeriodUnit,
-		SampleType: meta.SampleType,
-		SampleUnit: meta.SampleUnit,
-		Timestamp:  meta.Timestamp,
-
-		Stacktrace:     stacktraceUUID[:],
-		PprofLabels:    sn.Label,
-		PprofNumLabels: sn.NumLabel,
-		Value:          s.Value[index],
-	}
-
-	return pn.samples[string(stacktraceUUID[:])], true, nil
+	return stacktraceUUID, nil
 }
 
 type SampleNormalizer struct {
@@ -363,6 +625,7 @@ func (pn *profileNormalizer) mapLocation(ctx context.Context, src *profile.Locat
 	if err != nil {
 		return nil, err
 	}
+	pn.metrics.observeCreated(pn.name, "location")
 
 	l.ID, err = uuid.FromBytes(id)
 	if err != nil {
@@ -381,12 +644,16 @@ func (pn *profileNormalizer) mapMapping(ctx context.Context, src *profile.Mappin
 		return mi, nil
 	}
 
+	// Redact before the memoization tables are checked, so that raw values
+	// are neither stored nor used as keys.
+	file := pn.redactor.MappingFile(src.File)
+
 	// Check memoization tables.
 	m, err := pn.metaStore.GetMappingByKey(ctx, &pb.Mapping{
 		Start:   src.Start,
 		Limit:   src.Limit,
 		Offset:  src.Offset,
-		File:    src.File,
+		File:    file,
 		BuildId: src.BuildID,
 	})
 	if err != nil && err != metastore.ErrMappingNotFound {
@@ -408,7 +675,7 @@ func (pn *profileNormalizer) mapMapping(ctx context.Context, src *profile.Mappin
 		Start:           src.Start,
 		Limit:           src.Limit,
 		Offset:          src.Offset,
-		File:            src.File,
+		File:            file,
 		BuildId:         src.BuildID,
 		HasFunctions:    src.HasFunctions,
 		HasFilenames:    src.HasFilenames,
@@ -421,6 +688,7 @@ func (pn *profileNormalizer) mapMapping(ctx context.Context, src *profile.Mappin
 	if err != nil {
 		return mapInfo{}, err
 	}
+	pn.metrics.observeCreated(pn.name, "mapping")
 	m.Id = id
 	mi := mapInfo{m, 0}
 	pn.mappingsByID[src.ID] = mi
@@ -446,10 +714,13 @@ func (pn *profileNormalizer) mapFunction(ctx context.Context, src *profile.Funct
 	if f, ok := pn.functionsByID[src.ID]; ok {
 		return f, nil
 	}
+	// Source paths are rewritten first, so that the redaction rules hold for
+	// the stored filename.
+	filename := pn.redactor.FunctionFilename(pn.sourcePaths.Map(src.Filename))
 	f, err := pn.metaStore.GetFunctionByKey(ctx, &pb.Function{
 		Name:       src.Name,
 		SystemName: src.SystemName,
-		Filename:   src.Filename,
+		Filename:   filename,
 		StartLine:  src.StartLine,
 	})
 	if err != nil && err != metastore.ErrFunctionNotFound {
@@ -462,7 +733,7 @@ func (pn *profileNormalizer) mapFunction(ctx context.Context, src *profile.Funct
 	f = &pb.Function{
 		Name:       src.Name,
 		SystemName: src.SystemName,
-		Filename:   src.Filename,
+		Filename:   filename,
 		StartLine:  src.StartLine,
 	}
 
@@ -470,6 +741,7 @@ func (pn *profileNormalizer) mapFunction(ctx context.Context, src *profile.Funct
 	if err != nil {
 		return nil, err
 	}
+	pn.metrics.observeCreated(pn.name, "function")
 	f.Id = id
 
 	pn.functionsByID[src.ID] = f
@@ -0,0 +1,617 @@
+// Copyright 2022 The Parca Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parcacol
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/apache/arrow/go/v8/arrow"
+	"github.com/apache/arrow/go/v8/arrow/array"
+	"github.com/google/pprof/profile"
+	"github.com/google/uuid"
+	"github.com/polarsignals/arcticdb/dynparquet"
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/segmentio/parquet-go"
+
+	pb "github.com/parca-dev/parca/gen/proto/go/parca/metastore/v1alpha1"
+	"github.com/parca-dev/parca/pkg/metastore"
+)
+
+var ErrInvalidArrowRecord = errors.New("invalid arrow record")
+
+// arrowRecord holds the typed columns of an Arrow record that is written to
+// the table. The dynamic columns are sorted by their label name.
+type arrowRecord struct {
+	rows int
+	// size is the number of bytes of the buffers of the record.
+	size int
+
+	ints       map[string]*array.Int64
+	strs       map[string]*array.String
+	stacktrace *array.List
+	locationID *array.Uint64
+
+	labelNames         []string
+	labels             []*array.String
+	pprofLabelNames    []string
+	pprofLabels        []*array.String
+	pprofNumLabelNames []string
+	pprofNumLabels     []*array.Int64
+}
+
+func newArrowRecord(r arrow.Record) (*arrowRecord, error) {
+	ar := &arrowRecord{
+		rows: int(r.NumRows()),
+		size: recordSize(r),
+		ints: map[string]*array.Int64{},
+		strs: map[string]*array.String{},
+	}
+
+	type dynamicColumn struct {
+		name string
+		col  arrow.Array
+	}
+	var labelCols, pprofLabelCols, pprofNumLabelCols []dynamicColumn
+
+	for i, f := range r.Schema().Fields() {
+		col := r.Column(i)
+		var ok bool
+
+		switch {
+		case f.Name == ColumnDuration, f.Name == ColumnPeriod, f.Name == ColumnTimestamp, f.Name == ColumnValue:
+			ar.ints[f.Name], ok = col.(*array.Int64)
+		case f.Name == ColumnName, f.Name == ColumnPeriodType, f.Name == ColumnPeriodUnit, f.Name == ColumnSampleType, f.Name == ColumnSampleUnit:
+			ar.strs[f.Name], ok = col.(*array.String)
+		case f.Name == ColumnStacktrace:
+			ar.stacktrace, ok = col.(*array.List)
+			if ok {
+				ar.locationID, ok = ar.stacktrace.ListValues().(*array.Uint64)
+			}
+		case strings.HasPrefix(f.Name, ColumnLabels+"."):
+			_, ok = col.(*array.String)
+			labelCols = append(labelCols, dynamicColumn{strings.TrimPrefix(f.Name, ColumnLabels+"."), col})
+		case strings.HasPrefix(f.Name, ColumnPprofLabels+"."):
+			_, ok = col.(*array.String)
+			pprofLabelCols = append(pprofLabelCols, dynamicColumn{strings.TrimPrefix(f.Name, ColumnPprofLabels+"."), col})
+		case strings.HasPrefix(f.Name, ColumnPprofNumLabels+"."):
+			_, ok = col.(*array.Int64)
+			pprofNumLabelCols = append(pprofNumLabelCols, dynamicColumn{strings.TrimPrefix(f.Name, ColumnPprofNumLabels+"."), col})
+		default:
+			return nil, fmt.Errorf("%w: unknown column %s", ErrInvalidArrowRecord, f.Name)
+		}
+
+		if !ok {
+			return nil, fmt.Errorf("%w: column %s has unexpected type %s", ErrInvalidArrowRecord, f.Name, f.Type)
+		}
+	}
+
+	for _, name := range []string{ColumnDuration, ColumnPeriod, ColumnTimestamp, ColumnValue} {
+		if ar.ints[name] == nil {
+			return nil, fmt.Errorf("%w: missing column %s", ErrInvalidArrowRecord, name)
+		}
+	}
+	for _, name := range []string{ColumnName, ColumnPeriodType, ColumnPeriodUnit, ColumnSampleType, ColumnSampleUnit} {
+		if ar.strs[name] == nil {
+			return nil, fmt.Errorf("%w: missing column %s", ErrInvalidArrowRecord, name)
+		}
+	}
+	if ar.stacktrace == nil {
+		return nil, fmt.Errorf("%w: missing column %s", ErrInvalidArrowRecord, ColumnStacktrace)
+	}
+
+	for _, cols := range [][]dynamicColumn{labelCols, pprofLabelCols, pprofNumLabelCols} {
+		sort.Slice(cols, func(i, j int) bool {
+			return cols[i].name < cols[j].name
+		})
+	}
+	for _, c := range labelCols {
+		ar.labelNames = append(ar.labelNames, c.name)
+		ar.labels = append(ar.labels, c.col.(*array.String))
+	}
+	for _, c := range pprofLabelCols {
+		ar.pprofLabelNames = append(ar.pprofLabelNames, c.name)
+		ar.pprofLabels = append(ar.pprofLabels, c.col.(*array.String))
+	}
+	for _, c := range pprofNumLabelCols {
+		ar.pprofNumLabelNames = append(ar.pprofNumLabelNames, c.name)
+		ar.pprofNumLabels = append(ar.pprofNumLabels, c.col.(*array.Int64))
+	}
+
+	return ar, nil
+}
+
+// recordSize returns the number of bytes of the buffers of the columns of the
+// record, which is about the size of the record when it's sent over the wire.
+func recordSize(r arrow.Record) int {
+	size := 0
+	for _, col := range r.Columns() {
+		size += arrayDataSize(col.Data())
+	}
+	return size
+}
+
+func arrayDataSize(d arrow.ArrayData) int {
+	size := 0
+	for _, b := range d.Buffers() {
+		if b != nil {
+			size += b.Len()
+		}
+	}
+	for _, c := range d.Children() {
+		size += arrayDataSize(c)
+	}
+	return size
+}
+
+func (ar *arrowRecord) int64(name string, row int) int64 {
+	return ar.ints[name].Value(row)
+}
+
+func (ar *arrowRecord) string(name string, row int) string {
+	return ar.strs[name].Value(row)
+}
+
+// series returns the labels of the series of the row including the name.
+func (ar *arrowRecord) series(row int) labels.Labels {
+	ls := make(labels.Labels, 0, len(ar.labels)+1)
+	ls = append(ls, labels.Label{Name: labels.MetricName, Value: ar.string(ColumnName, row)})
+	for i, col := range ar.labels {
+		if col.IsValid(row) {
+			ls = append(ls, labels.Label{Name: ar.labelNames[i], Value: col.Value(row)})
+		}
+	}
+	sort.Sort(ls)
+	return ls
+}
+
+// IngestArrow writes the samples of an Arrow record to the table. The record
+// has the columns of the schema, but the stacktrace column is a list of the
+// IDs of the given locations, leaf first. Unlike Ingest, the stacktrace
+// filters are not applied, as agents sending columnar data are expected to do
+// so themselves. Redaction rules and cardinality limits are applied all the
+// same, as they must hold regardless of the client.
+//
+// Just like the profiles passed to Ingest, the samples of a series and
+// timestamp are rejected if they are out of bounds, exceed the cardinality
+// limits or are not newer than the last samples of the series. They are also
+// rejected if they exceed the limits of the arrow limiter, with their share
+// of the rows of the record as their size. The remaining
+// samples are still written and the first rejection is returned. The
+// timestamps of the series are only recorded once the samples were inserted,
+// so that a record failing to be ingested can be retried.
+//
+// The rows are written to a buffer straight from the columns of the record
+// and inserted on their own, as a record already is a batch of samples, rather
+// than being converted to Samples first.
+func (ing Ingester) IngestArrow(ctx context.Context, r arrow.Record, locations map[uint64]*profile.Location, normalized bool) error {
+	ar, err := newArrowRecord(r)
+	if err != nil {
+		return err
+	}
+	if ar.rows == 0 {
+		return nil
+	}
+
+	profiles, reserved, rejected := ing.rejectArrowRows(ar)
+	inserted := false
+	defer func() {
+		for _, ap := range reserved {
+			ing.series.done(ap.hash, ap.timestamp, inserted)
+		}
+	}()
+
+	start := time.Now()
+	pn := &profileNormalizer{
+		logger:      ing.logger,
+		metaStore:   ing.metaStore,
+		redactor:    ing.redactor,
+		sourcePaths: ing.sourcePaths,
+		metrics:     ing.metrics,
+
+		locationsByID: make(map[uint64]*metastore.Location, len(locations)),
+		functionsByID: map[uint64]*pb.Function{},
+		mappingsByID:  map[uint64]mapInfo{},
+	}
+
+	// Rows of the same series, timestamp, sample type and stacktrace are
+	// aggregated into a single row, just like ConvertPProf aggregates the
+	// samples of a profile.
+	type rowKey struct {
+		profile    *arrowProfile
+		sampleType string
+		sampleUnit string
+		periodType string
+		periodUnit string
+		period     int64
+		duration   int64
+		stacktrace uuid.UUID
+	}
+	var rows []arrowRow
+	rowsByKey := map[rowKey]int{}
+
+	type metricsKey struct {
+		name       string
+		sampleType string
+	}
+	type sampleCounts struct {
+		ingested, zeroValue, noLocations, stacktraces int
+	}
+	counts := map[metricsKey]*sampleCounts{}
+	var recordName, recordSampleType recordLabel
+
+	offsets := ar.stacktrace.Offsets()[ar.stacktrace.Data().Offset():]
+	for i := 0; i < ar.rows; i++ {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		ap := profiles[i]
+		if ap.rejected {
+			continue
+		}
+
+		mk := metricsKey{name: ap.name, sampleType: ar.string(ColumnSampleType, i)}
+		c, ok := counts[mk]
+		if !ok {
+			c = &sampleCounts{}
+			counts[mk] = c
+		}
+		recordName.observe(mk.name)
+		recordSampleType.observe(mk.sampleType)
+
+		// Samples without locations or values are skipped, the same as in
+		// ConvertPProf.
+		if ar.int64(ColumnValue, i) == 0 {
+			c.zeroValue++
+			continue
+		}
+		if !ar.stacktrace.IsValid(i) || offsets[i] == offsets[i+1] {
+			c.noLocations++
+			continue
+		}
+		c.ingested++
+		pn.name = ap.name
+
+		sn := &SampleNormalizer{
+			Location: make([]*metastore.Location, 0, offsets[i+1]-offsets[i]),
+			Label:    map[string]string{},
+			NumLabel: map[string]int64{},
+			NumUnit:  map[string]string{},
+		}
+		for j := offsets[i]; j < offsets[i+1]; j++ {
+			id := ar.locationID.Value(int(j))
+			loc, ok := locations[id]
+			if !ok {
+				return fmt.Errorf("%w: unknown location id %d", ErrInvalidArrowRecord, id)
+			}
+			l, err := pn.mapLocation(ctx, loc, normalized)
+			if err != nil {
+				return err
+			}
+			sn.Location = append(sn.Location, l)
+		}
+		row := arrowRow{row: i, profile: ap, value: ar.int64(ColumnValue, i)}
+		for _, l := range ar.rowPprofLabels(ing.redactor, i) {
+			if !ap.stripped.contains(l.Name, l.Value) {
+				sn.Label[l.Name] = l.Value
+				row.pprofLabels = append(row.pprofLabels, l)
+			}
+		}
+		for k, col := range ar.pprofNumLabels {
+			if col.IsValid(i) {
+				sn.NumLabel[ar.pprofNumLabelNames[k]] = col.Value(i)
+			}
+		}
+
+		row.stacktrace, err = pn.mapStacktrace(ctx, sn)
+		if err != nil {
+			return err
+		}
+
+		k := rowKey{
+			profile:    ap,
+			sampleType: mk.sampleType,
+			sampleUnit: ar.string(ColumnSampleUnit, i),
+			periodType: ar.string(ColumnPeriodType, i),
+			periodUnit: ar.string(ColumnPeriodUnit, i),
+			period:     ar.int64(ColumnPeriod, i),
+			duration:   ar.int64(ColumnDuration, i),
+			stacktrace: row.stacktrace,
+		}
+		if j, ok := rowsByKey[k]; ok {
+			rows[j].value += row.value
+			continue
+		}
+		c.stacktraces++
+		rowsByKey[k] = len(rows)
+		rows = append(rows, row)
+	}
+
+	for k, c := range counts {
+		ing.metrics.observeSamples(k.name, k.sampleType, c.ingested, c.zeroValue, c.noLocations, c.stacktraces)
+	}
+	ing.metrics.ObserveStage(recordName.value, recordSampleType.value, StageConvert, start)
+
+	if len(rows) == 0 {
+		inserted = true
+		return rejected
+	}
+
+	start = time.Now()
+	buffer, err := ar.toBuffer(Schema(), rows)
+	if err != nil {
+		return fmt.Errorf("failed to convert arrow record to buffer: %w", err)
+	}
+	if err := ing.insertBuffer(ctx, buffer); err != nil {
+		return err
+	}
+	ing.metrics.ObserveStage(recordName.value, recordSampleType.value, StageInsert, start)
+	inserted = true
+
+	return rejected
+}
+
+// arrowRow is a row of a record that is written to the table.
+type arrowRow struct {
+	row        int
+	profile    *arrowProfile
+	stacktrace uuid.UUID
+	// value is the sum of the values of the rows aggregated into this one.
+	value int64
+	// pprofLabels are the redacted pprof labels of the row, sorted by name
+	// and without the ones stripped by the cardinality limiter.
+	pprofLabels []labels.Label
+}
+
+// toBuffer writes the rows to a buffer of the schema, taking the values
+// straight from the columns of the record. Only the dynamic columns that hold
+// a value of any of the rows are part of the buffer, the same as for the
+// buffers of Samples.
+func (ar *arrowRecord) toBuffer(schema *dynparquet.Schema, rows []arrowRow) (*dynparquet.Buffer, error) {
+	labelNames := map[string]struct{}{}
+	pprofLabelNames := map[string]struct{}{}
+	pprofNumLabelNames := map[string]struct{}{}
+	var last *arrowProfile
+	for _, r := range rows {
+		if r.profile != last {
+			for _, l := range r.profile.labels {
+				labelNames[l.Name] = struct{}{}
+			}
+			last = r.profile
+		}
+		for _, l := range r.pprofLabels {
+			pprofLabelNames[l.Name] = struct{}{}
+		}
+		for k, col := range ar.pprofNumLabels {
+			if col.IsValid(r.row) {
+				pprofNumLabelNames[ar.pprofNumLabelNames[k]] = struct{}{}
+			}
+		}
+	}
+	dynamic := map[string][]string{
+		ColumnLabels:         sortedNames(labelNames),
+		ColumnPprofLabels:    sortedNames(pprofLabelNames),
+		ColumnPprofNumLabels: sortedNames(pprofNumLabelNames),
+	}
+
+	// The columns of the pprof num labels of the buffer, nil if the
+	// record doesn't have the column.
+	numLabels := make([]*array.Int64, len(dynamic[ColumnPprofNumLabels]))
+	for i, name := range dynamic[ColumnPprofNumLabels] {
+		k := sort.SearchStrings(ar.pprofNumLabelNames, name)
+		numLabels[i] = ar.pprofNumLabels[k]
+	}
+
+	buffer, err := schema.NewBuffer(dynamic)
+	if err != nil {
+		return nil, err
+	}
+
+	var row parquet.Row
+	for k := range rows {
+		row = ar.parquetRow(schema, row[:0], &rows[k], dynamic, numLabels)
+		if _, err := buffer.WriteRows([]parquet.Row{row}); err != nil {
+			return nil, err
+		}
+	}
+	return buffer, nil
+}
+
+// parquetRow appends the values of the row to the parquet row, in the order
+// of the columns of the schema like Sample.ToParquetRow.
+func (ar *arrowRecord) parquetRow(schema *dynparquet.Schema, row parquet.Row, r *arrowRow, dynamic map[string][]string, numLabels []*array.Int64) parquet.Row {
+	i := r.row
+	columnIndex := 0
+	for _, column := range schema.Columns() {
+		switch column.Name {
+		case ColumnDuration, ColumnPeriod, ColumnTimestamp:
+			row = append(row, parquet.ValueOf(ar.int64(column.Name, i)).Level(0, 0, columnIndex))
+			columnIndex++
+		case ColumnValue:
+			row = append(row, parquet.ValueOf(r.value).Level(0, 0, columnIndex))
+			columnIndex++
+		case ColumnName, ColumnPeriodType, ColumnPeriodUnit, ColumnSampleType, ColumnSampleUnit:
+			row = append(row, parquet.ValueOf(ar.string(column.Name, i)).Level(0, 0, columnIndex))
+			columnIndex++
+		case ColumnStacktrace:
+			row = append(row, parquet.ValueOf(r.stacktrace[:]).Level(0, 0, columnIndex))
+			columnIndex++
+
+		// All remaining cases take care of dynamic columns
+		case ColumnLabels:
+			row, columnIndex = appendLabelValues(row, columnIndex, dynamic[ColumnLabels], r.profile.labels)
+		case ColumnPprofLabels:
+			row, columnIndex = appendLabelValues(row, columnIndex, dynamic[ColumnPprofLabels], r.pprofLabels)
+		case ColumnPprofNumLabels:
+			for _, col := range numLabels {
+				if col.IsValid(i) {
+					row = append(row, parquet.ValueOf(col.Value(i)).Level(0, 1, columnIndex))
+				} else {
+					row = append(row, parquet.ValueOf(nil).Level(0, 0, columnIndex))
+				}
+				columnIndex++
+			}
+		default:
+			panic(fmt.Errorf("conversion not implement for column: %s", column.Name))
+		}
+	}
+	return row
+}
+
+// appendLabelValues appends a value for every name to the row, the value of
+// the label of that name or NULL. The labels are sorted by name.
+func appendLabelValues(row parquet.Row, columnIndex int, names []string, ls []labels.Label) (parquet.Row, int) {
+	j := 0
+	for _, name := range names {
+		if j < len(ls) && ls[j].Name == name {
+			row = append(row, parquet.ValueOf(ls[j].Value).Level(0, 1, columnIndex))
+			j++
+		} else {
+			row = append(row, parquet.ValueOf(nil).Level(0, 0, columnIndex))
+		}
+		columnIndex++
+	}
+	return row, columnIndex
+}
+
+func sortedNames(names map[string]struct{}) []string {
+	sorted := make([]string, 0, len(names))
+	for name := range names {
+		sorted = append(sorted, name)
+	}
+	sort.Strings(sorted)
+	return sorted
+}
+
+// arrowProfile are the rows of a record of the same series and timestamp.
+type arrowProfile struct {
+	name      string
+	series    labels.Labels
+	timestamp int64
+	rows      []int
+
+	// labels are the redacted labels of the series without the __name__
+	// label, and stripped the pprof labels exceeding the cardinality limits.
+	labels   labels.Labels
+	stripped strippedLabels
+	rejected bool
+	// hash of the series, of which the timestamp is reserved unless the
+	// profile is rejected.
+	hash uint64
+}
+
+// rejectArrowRows applies the timestamp bounds, the out-of-order checks, the
+// cardinality limits and the ingest limits to every series and timestamp of
+// the record. It returns the profile of every row, the profiles of which the
+// timestamps were reserved and the first rejection.
+func (ing Ingester) rejectArrowRows(ar *arrowRecord) ([]*arrowProfile, []*arrowProfile, error) {
+	type profileKey struct {
+		series    uint64
+		timestamp int64
+	}
+
+	rowProfiles := make([]*arrowProfile, ar.rows)
+	profiles := map[profileKey]*arrowProfile{}
+	ordered := []*arrowProfile{}
+	for i := 0; i < ar.rows; i++ {
+		ls := ar.series(i)
+		ts := ar.int64(ColumnTimestamp, i) * time.Millisecond.Nanoseconds()
+		k := profileKey{ls.Hash(), ts}
+
+		ap, ok := profiles[k]
+		if !ok {
+			ap = &arrowProfile{series: ls, timestamp: ts}
+			profiles[k] = ap
+			ordered = append(ordered, ap)
+		}
+		ap.rows = append(ap.rows, i)
+		rowProfiles[i] = ap
+	}
+
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return ordered[i].timestamp < ordered[j].timestamp
+	})
+
+	var (
+		now      = ing.now()
+		reserved []*arrowProfile
+		rejected error
+	)
+	for _, ap := range ordered {
+		err := checkTimestampBounds(now, ap.timestamp, ing.maxPast, ing.maxFuture)
+		if err == nil {
+			ap.hash, err = ing.series.reserve(now, ap.series, ap.timestamp)
+		}
+		if err != nil {
+			if rejected == nil {
+				rejected = err
+			}
+			ap.rejected = true
+			continue
+		}
+
+		// The labels only count towards the cardinality limits once the
+		// profile is known not to be a duplicate or out of order. The
+		// name is always set, as it's a required column.
+		ap.name, ap.labels, _ = ing.seriesLabels(ap.series)
+		ap.labels, ap.stripped, err = ing.limiter.limit(now, ing.tenant, ap.name, ap.labels, ing.arrowPprofLabels(ar, ap.rows))
+		if err == nil && ing.arrowLimiter != nil {
+			err = ing.arrowLimiter.Allow(ing.tenant, ap.series, ar.size*len(ap.rows)/ar.rows, len(ap.rows))
+		}
+		if err != nil {
+			ing.series.done(ap.hash, ap.timestamp, false)
+			if rejected == nil {
+				rejected = err
+			}
+			ap.rejected = true
+			continue
+		}
+		reserved = append(reserved, ap)
+	}
+
+	return rowProfiles, reserved, rejected
+}
+
+// arrowPprofLabels returns the distinct redacted pprof labels of the rows,
+// sorted by name and value.
+func (ing Ingester) arrowPprofLabels(ar *arrowRecord, rows []int) []labels.Label {
+	if ing.limiter == nil {
+		return nil
+	}
+
+	seen := map[labels.Label]struct{}{}
+	for _, i := range rows {
+		for _, l := range ar.rowPprofLabels(ing.redactor, i) {
+			seen[l] = struct{}{}
+		}
+	}
+
+	return sortedLabels(seen)
+}
+
+// rowPprofLabels returns the redacted pprof labels of the row.
+func (ar *arrowRecord) rowPprofLabels(r *Redactor, row int) []labels.Label {
+	ls := make([]labels.Label, 0, len(ar.pprofLabels))
+	for k, col := range ar.pprofLabels {
+		if col.IsValid(row) {
+			ls = append(ls, labels.Label{Name: ar.pprofLabelNames[k], Value: r.PprofLabel(ar.pprofLabelNames[k], col.Value(row))})
+		}
+	}
+	return ls
+}
@@ -14,11 +14,22 @@
 package parcacol
 
 import (
+	"context"
+	"errors"
+	"sync"
 	"testing"
+	"time"
 
+	"github.com/go-kit/log"
+	"github.com/google/pprof/profile"
+	"github.com/polarsignals/arcticdb/dynparquet"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/prometheus/model/labels"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace"
 
 	"github.com/parca-dev/parca/pkg/metastore"
+	"github.com/parca-dev/parca/pkg/tenant"
 )
 
 func TestMakeStacktraceKey(t *testing.T) {
@@ -80,3 +91,380 @@ func BenchmarkMakeStacktraceKey(b *testing.B) {
 		_ = MakeStacktraceKey(s)
 	}
 }
+
+func testFilterProfile() *profile.Profile {
+	fns := []*profile.Function{
+		{ID: 1, Name: "main"},
+		{ID: 2, Name: "parse"},
+		{ID: 3, Name: "runtime.mallocgc"},
+		{ID: 4, Name: "runtime.memclr"},
+	}
+	locs := make([]*profile.Location, 0, len(fns))
+	for _, fn := range fns {
+		locs = append(locs, &profile.Location{ID: fn.ID, Line: []profile.Line{{Function: fn}}})
+	}
+
+	return &profile.Profile{
+		SampleType: []*profile.ValueType{{Type: "alloc_space", Unit: "bytes"}},
+		Function:   fns,
+		Location:   locs,
+		Sample: []*profile.Sample{
+			// Leaf first: memclr <- mallocgc <- parse <- main
+			{Location: []*profile.Location{locs[3], locs[2], locs[1], locs[0]}, Value: []int64{10}},
+			{Location: []*profile.Location{locs[1], locs[1], locs[1], locs[0]}, Value: []int64{5}},
+			{Location: []*profile.Location{locs[1], locs[0]}, Value: []int64{3}},
+			{Location: []*profile.Location{locs[0]}, Value: []int64{1}},
+		},
+	}
+}
+
+func functionNames(locs []*profile.Location) []string {
+	names := make([]string, 0, len(locs))
+	for _, l := range locs {
+		for _, ln := range l.Line {
+			names = append(names, ln.Function.Name)
+		}
+	}
+	return names
+}
+
+func TestStacktraceFilterDropFrames(t *testing.T) {
+	p := testFilterProfile()
+
+	f, err := NewStacktraceFilter("runtime\\..*", "", 0, 0)
+	require.NoError(t, err)
+
+	samples := newProfileFilter(f, p).samples(p.Sample, 0)
+	require.Len(t, samples, 4)
+	require.Equal(t, []string{"parse", "main"}, functionNames(samples[0].Location))
+	require.Equal(t, []string{"parse", "parse", "parse", "main"}, functionNames(samples[1].Location))
+
+	// The original profile must not be modified.
+	require.Equal(t, []string{"runtime.memclr", "runtime.mallocgc", "parse", "main"}, functionNames(p.Sample[0].Location))
+
+	f, err = NewStacktraceFilter("runtime\\..*", "runtime.mallocgc", 0, 0)
+	require.NoError(t, err)
+
+	samples = newProfileFilter(f, p).samples(p.Sample, 0)
+	require.Equal(t, []string{"runtime.mallocgc", "parse", "main"}, functionNames(samples[0].Location))
+}
+
+func TestStacktraceFilterMaxDepth(t *testing.T) {
+	p := testFilterProfile()
+
+	f, err := NewStacktraceFilter("", "", 3, 0)
+	require.NoError(t, err)
+
+	samples := newProfileFilter(f, p).samples(p.Sample, 0)
+	require.Len(t, samples, 4)
+	require.Equal(t, []string{"runtime.memclr", "runtime.mallocgc", TruncatedFunctionName}, functionNames(samples[0].Location))
+	require.Equal(t, []string{"parse", "parse", TruncatedFunctionName}, functionNames(samples[1].Location))
+	require.Equal(t, []string{"parse", "main"}, functionNames(samples[2].Location))
+
+	_, err = NewStacktraceFilter("", "", 1, 0)
+	require.Error(t, err)
+}
+
+func TestStacktraceFilterMaxStacks(t *testing.T) {
+	p := testFilterProfile()
+
+	f, err := NewStacktraceFilter("", "", 0, 2)
+	require.NoError(t, err)
+
+	samples := newProfileFilter(f, p).samples(p.Sample, 0)
+	require.Len(t, samples, 3)
+	require.Equal(t, int64(10), samples[0].Value[0])
+	require.Equal(t, int64(5), samples[1].Value[0])
+	require.Equal(t, []string{OtherFunctionName}, functionNames(samples[2].Location))
+	require.Equal(t, int64(4), samples[2].Value[0])
+
+	// Synthetic locations must not collide with the ones of the profile.
+	require.Equal(t, uint64(6), samples[2].Location[0].ID)
+}
+
+func TestSeriesTracker(t *testing.T) {
+	now := time.Unix(1000, 0)
+	tracker := newSeriesTracker(time.Minute)
+	ls := labels.FromStrings("__name__", "cpu", "job", "parca")
+
+	ingest := func(ls labels.Labels, ts int64, ingested bool) error {
+		hash, err := tracker.reserve(now, ls, ts)
+		if err != nil {
+			return err
+		}
+		tracker.done(hash, ts, ingested)
+		return nil
+	}
+
+	require.NoError(t, ingest(ls, 2, true))
+	require.ErrorIs(t, ingest(ls, 2, true), ErrDuplicateSample)
+	require.ErrorIs(t, ingest(ls, 1, true), ErrOutOfOrderSample)
+	require.NoError(t, ingest(ls, 3, true))
+
+	// Label order must not matter.
+	require.ErrorIs(t, ingest(labels.Labels{ls[1], ls[0]}, 3, true), ErrDuplicateSample)
+
+	// Series are tracked independently.
+	require.NoError(t, ingest(labels.FromStrings("__name__", "cpu", "job", "other"), 1, true))
+
+	// Profiles that failed to be ingested can be retried, profiles being
+	// ingested can't be ingested concurrently.
+	require.NoError(t, ingest(ls, 4, false))
+	hash, err := tracker.reserve(now, ls, 4)
+	require.NoError(t, err)
+	require.ErrorIs(t, ingest(ls, 4, true), ErrDuplicateSample)
+	tracker.done(hash, 4, true)
+	require.ErrorIs(t, ingest(ls, 4, true), ErrDuplicateSample)
+
+	// Series are forgotten once their last profile is older than the
+	// retention.
+	recent := labels.FromStrings("__name__", "cpu", "job", "recent")
+	require.NoError(t, ingest(recent, now.Add(time.Minute).UnixNano(), true))
+	now = now.Add(2 * time.Minute)
+	require.NoError(t, ingest(labels.FromStrings("__name__", "cpu", "job", "new"), now.UnixNano(), true))
+	require.Len(t, tracker.series, 2)
+	require.ErrorIs(t, ingest(recent, now.Add(-time.Minute).UnixNano(), true), ErrDuplicateSample)
+}
+
+// failingTable fails the given number of insertions.
+type failingTable struct {
+	failures int
+	inserted int
+}
+
+func (t *failingTable) Schema() *dynparquet.Schema {
+	return Schema()
+}
+
+func (t *failingTable) InsertBuffer(context.Context, *dynparquet.Buffer) (uint64, error) {
+	if t.failures > 0 {
+		t.failures--
+		return 0, errors.New("insert failed")
+	}
+	t.inserted++
+	return uint64(t.inserted), nil
+}
+
+func TestIngestRetry(t *testing.T) {
+	ctx := context.Background()
+	logger := log.NewNopLogger()
+	m := metastore.NewBadgerMetastore(
+		logger,
+		prometheus.NewRegistry(),
+		trace.NewNoopTracerProvider().Tracer(""),
+		metastore.NewRandomUUIDGenerator(),
+	)
+	t.Cleanup(func() {
+		m.Close()
+	})
+
+	table := &failingTable{failures: 1}
+	ing := NewIngester(logger, m, table)
+	ls := labels.FromStrings("__name__", "memory", "job", "parca")
+	p := testFilterProfile()
+	p.PeriodType = &profile.ValueType{Type: "space", Unit: "bytes"}
+	p.TimeNanos = time.Now().UnixNano()
+
+	// Profiles that failed to be inserted are not duplicates when retried.
+	require.Error(t, ing.Ingest(ctx, ls, p, false))
+	require.NoError(t, ing.Ingest(ctx, ls, p, false))
+	require.Equal(t, 1, table.inserted)
+	require.ErrorIs(t, ing.Ingest(ctx, ls, p, false), ErrDuplicateSample)
+}
+
+func TestInsertBatcher(t *testing.T) {
+	ctx := context.Background()
+	errInsert := errors.New("insert failed")
+
+	var (
+		mtx     sync.Mutex
+		batches []int
+	)
+	started, release := make(chan struct{}), make(chan struct{})
+	b := newInsertBatcher(4, func(_ context.Context, samples Samples) error {
+		mtx.Lock()
+		batches = append(batches, len(samples))
+		first := len(batches) == 1
+		mtx.Unlock()
+		if first {
+			close(started)
+			<-release
+		}
+		if len(samples) == 3 {
+			return errInsert
+		}
+		return nil
+	}, nil)
+
+	addCtx := func(ctx context.Context, n int) chan error {
+		errc := make(chan error, 1)
+		go func() { errc <- b.add(ctx, make(Samples, n)) }()
+		return errc
+	}
+	add := func(n int) chan error {
+		return addCtx(ctx, n)
+	}
+	pending := func() int {
+		b.mtx.Lock()
+		defer b.mtx.Unlock()
+		if b.pending == nil {
+			return 0
+		}
+		return len(b.pending.samples)
+	}
+
+	// An idle table doesn't delay insertions.
+	first := add(1)
+	<-started
+
+	// Insertions are merged while the table is busy, up to the maximum
+	// number of rows.
+	canceledCtx, cancel := context.WithCancel(ctx)
+	canceled := addCtx(canceledCtx, 1)
+	merged := []chan error{canceled, add(1), add(1)}
+	require.Eventually(t, func() bool { return pending() == 3 }, time.Second, time.Millisecond)
+	full := add(2)
+	time.Sleep(10 * time.Millisecond)
+	require.Equal(t, 3, pending())
+
+	// Canceling the context of samples that joined a batch doesn't stop
+	// waiting for the batch, as it is inserted regardless.
+	cancel()
+	time.Sleep(10 * time.Millisecond)
+	require.Empty(t, canceled)
+
+	close(release)
+	require.NoError(t, <-first)
+	for _, errc := range merged {
+		require.ErrorIs(t, <-errc, errInsert)
+	}
+	require.NoError(t, <-full)
+	require.Equal(t, []int{1, 3, 2}, batches)
+}
+
+func TestCheckTimestampBounds(t *testing.T) {
+	now := time.Unix(1000, 0)
+
+	require.NoError(t, checkTimestampBounds(now, 0, 0, 0))
+	require.NoError(t, checkTimestampBounds(now, now.Add(-time.Minute).UnixNano(), time.Hour, time.Minute))
+	require.ErrorIs(t, checkTimestampBounds(now, now.Add(-2*time.Hour).UnixNano(), time.Hour, time.Minute), ErrOutOfBounds)
+	require.ErrorIs(t, checkTimestampBounds(now, now.Add(2*time.Minute).UnixNano(), time.Hour, time.Minute), ErrOutOfBounds)
+}
+
+func TestRedactor(t *testing.T) {
+	filenames, err := NewRedactionRule(RedactFunctionFilename, nil, "/home/([^/]+)/", RedactReplace, "/home/redacted/", "")
+	require.NoError(t, err)
+	customers, err := NewRedactionRule(RedactPprofLabels, []string{"customer_id"}, ".+", RedactHash, "", "salt")
+	require.NoError(t, err)
+	all, err := NewRedactionRule(RedactLabels, nil, ".+", RedactReplace, "redacted", "")
+	require.NoError(t, err)
+
+	r := NewRedactor(filenames, customers, all)
+	require.Equal(t, "/home/redacted/src/main.go", r.FunctionFilename("/home/alice/src/main.go"))
+	require.Equal(t, "/home/alice/bin/app", r.MappingFile("/home/alice/bin/app"))
+
+	// Hashes are stable, so equal values stay equal.
+	hashed := r.PprofLabel("customer_id", "acme")
+	require.Len(t, hashed, hashLength)
+	require.NotEqual(t, "acme", hashed)
+	require.Equal(t, hashed, r.PprofLabel("customer_id", "acme"))
+	require.NotEqual(t, hashed, r.PprofLabel("customer_id", "globex"))
+	require.Equal(t, "acme", r.PprofLabel("other", "acme"))
+
+	// The name of the profile is never redacted.
+	require.Equal(t, "redacted", r.Label("instance", "alice-laptop"))
+	require.Equal(t, "memory", r.Label("__name__", "memory"))
+
+	var nilRedactor *Redactor
+	require.Equal(t, "/home/alice/src/main.go", nilRedactor.FunctionFilename("/home/alice/src/main.go"))
+}
+
+func TestCardinalityLimiter(t *testing.T) {
+	l := NewCardinalityLimiter(prometheus.NewRegistry(), CardinalityLimits{
+		MaxLabelValuesPerProfileType: 2,
+		MaxPprofLabelValuesPerSeries: 1,
+	})
+
+	ls := labels.FromStrings("job", "a")
+	kept, stripped, err := l.limit(time.Now(), tenant.Default, "cpu", ls, []labels.Label{{Name: "request_id", Value: "1"}})
+	require.NoError(t, err)
+	require.Equal(t, ls, kept)
+	require.Nil(t, stripped)
+
+	// Known values don't count towards the limits again.
+	_, _, err = l.limit(time.Now(), tenant.Default, "cpu", ls, []labels.Label{{Name: "request_id", Value: "1"}})
+	require.NoError(t, err)
+
+	_, _, err = l.limit(time.Now(), tenant.Default, "cpu", ls, []labels.Label{{Name: "request_id", Value: "2"}})
+	require.ErrorIs(t, err, ErrCardinalityLimit)
+
+	// Rejected profiles don't register any of their labels, so "c" still fits.
+	_, _, err = l.limit(time.Now(), tenant.Default, "cpu", labels.FromStrings("job", "b"), []labels.Label{{Name: "request_id", Value: "1"}, {Name: "request_id", Value: "2"}})
+	require.ErrorIs(t, err, ErrCardinalityLimit)
+	_, _, err = l.limit(time.Now(), tenant.Default, "cpu", labels.FromStrings("job", "c"), nil)
+	require.NoError(t, err)
+	_, _, err = l.limit(time.Now(), tenant.Default, "cpu", labels.FromStrings("job", "d"), nil)
+	require.ErrorIs(t, err, ErrCardinalityLimit)
+
+	// Profile types are limited independently.
+	_, _, err = l.limit(time.Now(), tenant.Default, "memory", labels.FromStrings("job", "d"), nil)
+	require.NoError(t, err)
+
+	// So are tenants, which don't see each other's warnings.
+	_, _, err = l.limit(time.Now(), "team-a", "cpu", labels.FromStrings("job", "d"), nil)
+	require.NoError(t, err)
+	require.Nil(t, l.Warnings("team-a"))
+
+	require.Equal(t, []string{
+		"profile type cpu exceeded the label_values_per_profile_type limit, profiles were rejected",
+		"profile type cpu exceeded the pprof_label_values_per_series limit, profiles were rejected",
+	}, l.Warnings(tenant.Default))
+
+	var nilLimiter *CardinalityLimiter
+	require.Nil(t, nilLimiter.Warnings(tenant.Default))
+}
+
+func TestCardinalityLimiterRetention(t *testing.T) {
+	l := NewCardinalityLimiter(prometheus.NewRegistry(), CardinalityLimits{
+		MaxLabelValuesPerProfileType: 1,
+		Retention:                    time.Minute,
+	})
+	start := time.Unix(1000, 0)
+	a, b := labels.FromStrings("job", "a"), labels.FromStrings("job", "b")
+
+	_, _, err := l.limit(start, tenant.Default, "cpu", a, nil)
+	require.NoError(t, err)
+	_, _, err = l.limit(start.Add(50*time.Second), tenant.Default, "cpu", a, nil)
+	require.NoError(t, err)
+
+	// The values are kept as long as profiles have them.
+	_, _, err = l.limit(start.Add(70*time.Second), tenant.Default, "cpu", b, nil)
+	require.ErrorIs(t, err, ErrCardinalityLimit)
+
+	_, _, err = l.limit(start.Add(140*time.Second), tenant.Default, "cpu", b, nil)
+	require.NoError(t, err)
+	require.Len(t, l.profileTypes, 1)
+	require.False(t, l.profileTypes[profileTypeKey{tenant: tenant.Default, name: "cpu"}].labels.contains("job", "a"))
+}
+
+func TestCardinalityLimiterStrip(t *testing.T) {
+	l := NewCardinalityLimiter(prometheus.NewRegistry(), CardinalityLimits{
+		MaxLabelNamesPerProfileType:  1,
+		MaxPprofLabelValuesPerSeries: 1,
+		Strip:                        true,
+	})
+
+	kept, stripped, err := l.limit(time.Now(), tenant.Default, "cpu", labels.FromStrings("instance", "a", "job", "a"), []labels.Label{
+		{Name: "request_id", Value: "1"},
+		{Name: "request_id", Value: "2"},
+	})
+	require.NoError(t, err)
+	require.Equal(t, labels.FromStrings("instance", "a"), kept)
+	require.False(t, stripped.contains("request_id", "1"))
+	require.True(t, stripped.contains("request_id", "2"))
+
+	require.Equal(t, []string{
+		"profile type cpu exceeded the label_names_per_profile_type limit, labels were stripped",
+		"profile type cpu exceeded the pprof_label_values_per_series limit, labels were stripped",
+	}, l.Warnings(tenant.Default))
+}
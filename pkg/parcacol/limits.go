@@ -0,0 +1,375 @@
+// Copyright 2022 The Parca Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parcacol
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/prometheus/model/labels"
+)
+
+var ErrCardinalityLimit = errors.New("cardinality limit exceeded")
+
+const (
+	limitLabelNamesPerProfileType  = "label_names_per_profile_type"
+	limitLabelValuesPerProfileType = "label_values_per_profile_type"
+	limitPprofLabelNamesPerSeries  = "pprof_label_names_per_series"
+	limitPprofLabelValuesPerSeries = "pprof_label_values_per_series"
+)
+
+// CardinalityLimits bound the number of distinct label names and values, as
+// every label name is a dynamic column of the table and every label value
+// needs to be held in memory. Profile types are identified by the name of
+// the profiles, the limits hold for every tenant separately. A zero limit is
+// disabled.
+//
+// Label values are forgotten once no profile had them for the retention, so
+// that series churn, e.g. of pod names, doesn't exhaust the limits for good.
+type CardinalityLimits struct {
+	// MaxLabelNamesPerProfileType bounds the distinct label names and,
+	// separately, the distinct pprof label names of a profile type.
+	MaxLabelNamesPerProfileType int
+	// MaxLabelValuesPerProfileType bounds the distinct values of every label
+	// and pprof label name of a profile type.
+	MaxLabelValuesPerProfileType int
+	// MaxPprofLabelNamesPerSeries bounds the distinct pprof label names of a
+	// series.
+	MaxPprofLabelNamesPerSeries int
+	// MaxPprofLabelValuesPerSeries bounds the distinct values of every pprof
+	// label name of a series.
+	MaxPprofLabelValuesPerSeries int
+	// Strip removes the labels exceeding a limit instead of rejecting the
+	// profile with ErrCardinalityLimit.
+	Strip bool
+	// Retention is how long label values are counted towards the limits
+	// after the last profile that had them. Defaults to an hour.
+	Retention time.Duration
+}
+
+// defaultCardinalityRetention is the retention of label values if none is
+// configured.
+const defaultCardinalityRetention = time.Hour
+
+// labelValues holds the distinct values of every label name, along with the
+// time in unix nanoseconds a profile last had them.
+type labelValues map[string]map[string]int64
+
+func (v labelValues) contains(name, value string) bool {
+	_, ok := v[name][value]
+	return ok
+}
+
+// add adds the value to the set unless that exceeds one of the limits, in
+// which case the exceeded limit is returned.
+func (v labelValues) add(now int64, name, value string, maxNames, maxValues int, namesLimit, valuesLimit string) string {
+	values, ok := v[name]
+	if !ok {
+		if maxNames > 0 && len(v) >= maxNames {
+			return namesLimit
+		}
+		values = map[string]int64{}
+		v[name] = values
+	}
+	if _, ok := values[value]; ok {
+		return ""
+	}
+	if maxValues > 0 && len(values) >= maxValues {
+		return valuesLimit
+	}
+	values[value] = now
+	return ""
+}
+
+func (v labelValues) remove(name, value string) {
+	delete(v[name], value)
+	if len(v[name]) == 0 {
+		delete(v, name)
+	}
+}
+
+// expire removes the values a profile last had before the cutoff.
+func (v labelValues) expire(cutoff int64) {
+	for name, values := range v {
+		for value, seen := range values {
+			if seen < cutoff {
+				delete(values, value)
+			}
+		}
+		if len(values) == 0 {
+			delete(v, name)
+		}
+	}
+}
+
+type profileTypeLabels struct {
+	labels      labelValues
+	pprofLabels labelValues
+}
+
+// CardinalityLimiter enforces CardinalityLimits on ingested profiles.
+type CardinalityLimiter struct {
+	limits  CardinalityLimits
+	limited *prometheus.CounterVec
+
+	mtx          sync.Mutex
+	profileTypes map[profileTypeKey]*profileTypeLabels
+	series       map[seriesKey]labelValues
+	lastSweep    time.Time
+	// warnings are keyed by tenant.
+	warnings map[string]map[string]struct{}
+}
+
+type profileTypeKey struct {
+	tenant string
+	name   string
+}
+
+type seriesKey struct {
+	tenant string
+	hash   uint64
+}
+
+func NewCardinalityLimiter(reg prometheus.Registerer, limits CardinalityLimits) *CardinalityLimiter {
+	if limits.Retention <= 0 {
+		limits.Retention = defaultCardinalityRetention
+	}
+	l := &CardinalityLimiter{
+		limits: limits,
+		limited: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "parca_ingest_cardinality_limited_total",
+				Help: "Total number of labels stripped or profiles rejected for exceeding a cardinality limit.",
+			},
+			[]string{"limit", "action"},
+		),
+		profileTypes: map[profileTypeKey]*profileTypeLabels{},
+		series:       map[seriesKey]labelValues{},
+		warnings:     map[string]map[string]struct{}{},
+	}
+	reg.MustRegister(l.limited)
+	return l
+}
+
+// Warnings returns a warning for every profile type of the tenant and limit
+// that was exceeded.
+func (l *CardinalityLimiter) Warnings(tenantID string) []string {
+	if l == nil {
+		return nil
+	}
+
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+
+	if len(l.warnings[tenantID]) == 0 {
+		return nil
+	}
+	warnings := make([]string, 0, len(l.warnings[tenantID]))
+	for w := range l.warnings[tenantID] {
+		warnings = append(warnings, w)
+	}
+	sort.Strings(warnings)
+	return warnings
+}
+
+// strippedLabels are the labels of a profile that exceeded a limit.
+type strippedLabels labelValues
+
+func (s strippedLabels) contains(name, value string) bool {
+	return labelValues(s).contains(name, value)
+}
+
+// limit registers the labels of a series of the tenant without the __name__
+// label, and the distinct pprof labels of its samples. It returns the labels
+// without the ones exceeding a limit, and the pprof labels to strip from the
+// samples. If the limiter doesn't strip labels, ErrCardinalityLimit is
+// returned instead and none of the labels are registered.
+func (l *CardinalityLimiter) limit(now time.Time, tenantID, name string, ls labels.Labels, pprofLabels []labels.Label) (labels.Labels, strippedLabels, error) {
+	if l == nil {
+		return ls, nil, nil
+	}
+
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+
+	l.sweep(now)
+	nowNanos := now.UnixNano()
+
+	ptKey := profileTypeKey{tenant: tenantID, name: name}
+	pt, ok := l.profileTypes[ptKey]
+	if !ok {
+		pt = &profileTypeLabels{labels: labelValues{}, pprofLabels: labelValues{}}
+		l.profileTypes[ptKey] = pt
+	}
+	sKey := seriesKey{tenant: tenantID, hash: labels.NewBuilder(ls).Set(labels.MetricName, name).Labels().Hash()}
+	series, ok := l.series[sKey]
+	if !ok {
+		series = labelValues{}
+		l.series[sKey] = series
+	}
+
+	type added struct {
+		values      labelValues
+		name, value string
+	}
+	var (
+		undo []added
+		// seen are the labels that were already registered, of which the
+		// time is only updated if the profile is not rejected.
+		seen     []added
+		exceeded string
+		kept     = make(labels.Labels, 0, len(ls))
+		stripped = strippedLabels{}
+	)
+	// add adds the label to all of the sets, or to none of them if it
+	// exceeds the limit of one of them.
+	add := func(lbl labels.Label, sets ...limitedLabelValues) bool {
+		n := len(undo)
+		for _, s := range sets {
+			if s.values.contains(lbl.Name, lbl.Value) {
+				seen = append(seen, added{s.values, lbl.Name, lbl.Value})
+				continue
+			}
+			if limit := s.values.add(nowNanos, lbl.Name, lbl.Value, s.maxNames, s.maxValues, s.namesLimit, s.valuesLimit); limit != "" {
+				for _, a := range undo[n:] {
+					a.values.remove(a.name, a.value)
+				}
+				undo = undo[:n]
+				exceeded = limit
+				l.exceeded(tenantID, name, limit)
+				return false
+			}
+			undo = append(undo, added{s.values, lbl.Name, lbl.Value})
+		}
+		return true
+	}
+
+	profileTypeLabels := limitedLabelValues{pt.labels, l.limits.MaxLabelNamesPerProfileType, l.limits.MaxLabelValuesPerProfileType, limitLabelNamesPerProfileType, limitLabelValuesPerProfileType}
+	profileTypePprofLabels := limitedLabelValues{pt.pprofLabels, l.limits.MaxLabelNamesPerProfileType, l.limits.MaxLabelValuesPerProfileType, limitLabelNamesPerProfileType, limitLabelValuesPerProfileType}
+	seriesPprofLabels := limitedLabelValues{series, l.limits.MaxPprofLabelNamesPerSeries, l.limits.MaxPprofLabelValuesPerSeries, limitPprofLabelNamesPerSeries, limitPprofLabelValuesPerSeries}
+
+	for _, lbl := range ls {
+		if add(lbl, profileTypeLabels) {
+			kept = append(kept, lbl)
+			continue
+		}
+		if !l.limits.Strip {
+			break
+		}
+		l.limited.WithLabelValues(exceeded, "strip").Inc()
+	}
+
+	for _, lbl := range pprofLabels {
+		if exceeded != "" && !l.limits.Strip {
+			break
+		}
+		// A pprof label needs to fit both the limits of the profile type and
+		// the ones of the series.
+		if add(lbl, profileTypePprofLabels, seriesPprofLabels) {
+			continue
+		}
+		if l.limits.Strip {
+			labelValues(stripped).add(nowNanos, lbl.Name, lbl.Value, 0, 0, "", "")
+			l.limited.WithLabelValues(exceeded, "strip").Inc()
+		}
+	}
+
+	if exceeded == "" || l.limits.Strip {
+		for _, a := range seen {
+			// A label can be seen before it exceeds a limit of another
+			// set, in which case it's not registered anymore.
+			if a.values.contains(a.name, a.value) {
+				a.values[a.name][a.value] = nowNanos
+			}
+		}
+	}
+	if exceeded == "" {
+		return ls, nil, nil
+	}
+	if l.limits.Strip {
+		return kept, stripped, nil
+	}
+
+	for _, a := range undo {
+		a.values.remove(a.name, a.value)
+	}
+	l.limited.WithLabelValues(exceeded, "reject").Inc()
+	return nil, nil, fmt.Errorf("%w: profile type %s exceeds the %s limit", ErrCardinalityLimit, name, exceeded)
+}
+
+// sweep forgets the label values no profile had for the retention, and the
+// profile types and series left without any, at most once per retention. It
+// must be called with the mutex held.
+func (l *CardinalityLimiter) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < l.limits.Retention {
+		return
+	}
+	l.lastSweep = now
+
+	cutoff := now.Add(-l.limits.Retention).UnixNano()
+	for k, pt := range l.profileTypes {
+		pt.labels.expire(cutoff)
+		pt.pprofLabels.expire(cutoff)
+		if len(pt.labels) == 0 && len(pt.pprofLabels) == 0 {
+			delete(l.profileTypes, k)
+		}
+	}
+	for k, series := range l.series {
+		series.expire(cutoff)
+		if len(series) == 0 {
+			delete(l.series, k)
+		}
+	}
+}
+
+// limitedLabelValues are label values along with their limits.
+type limitedLabelValues struct {
+	values      labelValues
+	maxNames    int
+	maxValues   int
+	namesLimit  string
+	valuesLimit string
+}
+
+// exceeded records a warning for the profile type of the tenant. It must be
+// called with the mutex held.
+func (l *CardinalityLimiter) exceeded(tenantID, name, limit string) {
+	action := "profiles were rejected"
+	if l.limits.Strip {
+		action = "labels were stripped"
+	}
+	if l.warnings[tenantID] == nil {
+		l.warnings[tenantID] = map[string]struct{}{}
+	}
+	l.warnings[tenantID][fmt.Sprintf("profile type %s exceeded the %s limit, %s", name, limit, action)] = struct{}{}
+}
+
+// sortedLabels returns the set of labels sorted by name and value.
+func sortedLabels(set map[labels.Label]struct{}) []labels.Label {
+	ls := make([]labels.Label, 0, len(set))
+	for l := range set {
+		ls = append(ls, l)
+	}
+	sort.Slice(ls, func(i, j int) bool {
+		if ls[i].Name != ls[j].Name {
+			return ls[i].Name < ls[j].Name
+		}
+		return ls[i].Value < ls[j].Value
+	})
+	return ls
+}
@@ -0,0 +1,149 @@
+// Copyright 2022 The Parca Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parcacol
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Stages of the ingestion of a profile.
+const (
+	StageParse   = "parse"
+	StageConvert = "convert"
+	StageInsert  = "insert"
+)
+
+// Reasons for skipping samples.
+const (
+	skipZeroValue   = "zero_value"
+	skipNoLocations = "no_locations"
+)
+
+// IngestMetrics instrument the ingestion of profiles. They are labeled by the
+// name of the profiles and, where it applies, their sample type. Arrow
+// records with samples of different names or sample types are labeled with
+// empty ones.
+type IngestMetrics struct {
+	samples        *prometheus.CounterVec
+	skippedSamples *prometheus.CounterVec
+	stacktraces    *prometheus.CounterVec
+	created        *prometheus.CounterVec
+	stageDuration  *prometheus.HistogramVec
+	batchRows      prometheus.Histogram
+}
+
+func NewIngestMetrics(reg prometheus.Registerer) *IngestMetrics {
+	m := &IngestMetrics{
+		samples: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "parca_ingest_samples_total",
+				Help: "Total number of samples ingested.",
+			},
+			[]string{"name", "sample_type"},
+		),
+		skippedSamples: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "parca_ingest_skipped_samples_total",
+				Help: "Total number of samples skipped as they have no value or no locations.",
+			},
+			[]string{"name", "sample_type", "reason"},
+		),
+		stacktraces: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "parca_ingest_stacktraces_total",
+				Help: "Total number of rows written, one per distinct stacktrace of a profile and sample type.",
+			},
+			[]string{"name", "sample_type"},
+		),
+		created: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "parca_ingest_metastore_created_total",
+				Help: "Total number of locations, functions, mappings and stacktraces created in the metastore.",
+			},
+			[]string{"name", "kind"},
+		),
+		stageDuration: prometheus.NewHistogramVec(
+			prometheus.HistogramOpts{
+				Name:    "parca_ingest_stage_duration_seconds",
+				Help:    "Duration of the stages of the ingestion: parsing profiles, converting them by resolving their stacktraces in the metastore, and inserting them into the table.",
+				Buckets: prometheus.ExponentialBuckets(0.0005, 4, 10),
+			},
+			[]string{"name", "sample_type", "stage"},
+		),
+		batchRows: prometheus.NewHistogram(
+			prometheus.HistogramOpts{
+				Name:    "parca_ingest_insert_batch_rows",
+				Help:    "Number of rows of the batches inserted into the table.",
+				Buckets: prometheus.ExponentialBuckets(16, 4, 8),
+			},
+		),
+	}
+	reg.MustRegister(m.samples, m.skippedSamples, m.stacktraces, m.created, m.stageDuration, m.batchRows)
+	return m
+}
+
+// ObserveStage records the duration of a stage since start.
+func (m *IngestMetrics) ObserveStage(name, sampleType, stage string, start time.Time) {
+	if m == nil {
+		return
+	}
+	m.stageDuration.WithLabelValues(name, sampleType, stage).Observe(time.Since(start).Seconds())
+}
+
+func (m *IngestMetrics) observeSamples(name, sampleType string, ingested, zeroValue, noLocations, stacktraces int) {
+	if m == nil {
+		return
+	}
+	m.samples.WithLabelValues(name, sampleType).Add(float64(ingested))
+	m.stacktraces.WithLabelValues(name, sampleType).Add(float64(stacktraces))
+	if zeroValue > 0 {
+		m.skippedSamples.WithLabelValues(name, sampleType, skipZeroValue).Add(float64(zeroValue))
+	}
+	if noLocations > 0 {
+		m.skippedSamples.WithLabelValues(name, sampleType, skipNoLocations).Add(float64(noLocations))
+	}
+}
+
+func (m *IngestMetrics) observeBatch(rows int) {
+	if m == nil {
+		return
+	}
+	m.batchRows.Observe(float64(rows))
+}
+
+func (m *IngestMetrics) observeCreated(name, kind string) {
+	if m == nil {
+		return
+	}
+	m.created.WithLabelValues(name, kind).Inc()
+}
+
+// recordLabel is the value of a label of the samples of an Arrow record, it
+// is empty if the samples have different values.
+type recordLabel struct {
+	value string
+	set   bool
+}
+
+func (l *recordLabel) observe(v string) {
+	if !l.set {
+		l.value, l.set = v, true
+		return
+	}
+	if l.value != v {
+		l.value = ""
+	}
+}
@@ -14,13 +14,18 @@
 package parcacol
 
 import (
+	"errors"
 	"testing"
 
+	"github.com/go-kit/log"
 	"github.com/google/uuid"
+	"github.com/polarsignals/arcticdb"
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/prometheus/model/labels"
 	"github.com/stretchr/testify/require"
 
 	"github.com/parca-dev/parca/pkg/metastore"
+	"github.com/parca-dev/parca/pkg/tenant"
 )
 
 func TestFlatProfileToBuffer(t *testing.T) {
@@ -64,3 +69,37 @@ func TestFlatProfileToBuffer(t *testing.T) {
 		buf.DynamicColumns(),
 	)
 }
+
+func TestTables(t *testing.T) {
+	db, err := arcticdb.New(prometheus.NewRegistry(), 8196, 64*1024*1024).DB("parca")
+	require.NoError(t, err)
+	tables := NewTables(log.NewNopLogger(), db, "stacktraces", WithTenants("team-a"))
+
+	// Lookups don't create tables.
+	_, ok := tables.Lookup("team-a")
+	require.False(t, ok)
+
+	table, err := tables.Table("team-a")
+	require.NoError(t, err)
+	found, ok := tables.Lookup("team-a")
+	require.True(t, ok)
+	require.Same(t, table, found)
+
+	// The default tenant can always write profiles.
+	_, err = tables.Table(tenant.Default)
+	require.NoError(t, err)
+	_, ok = tables.Lookup(tenant.Default)
+	require.True(t, ok)
+
+	_, err = tables.Table("team-b")
+	require.True(t, errors.Is(err, ErrUnknownTenant))
+	_, ok = tables.Lookup("team-b")
+	require.False(t, ok)
+
+	// Without any configured tenants, only the default tenant can.
+	tables = NewTables(log.NewNopLogger(), db, "stacktraces")
+	_, err = tables.Table(tenant.Default)
+	require.NoError(t, err)
+	_, err = tables.Table("team-a")
+	require.True(t, errors.Is(err, ErrUnknownTenant))
+}
@@ -0,0 +1,169 @@
+// Copyright 2022 The Parca Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parcacol
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"regexp"
+
+	"github.com/prometheus/prometheus/model/labels"
+)
+
+// RedactionTarget is the part of a profile a redaction rule applies to.
+type RedactionTarget string
+
+const (
+	// RedactFunctionFilename redacts the filenames of functions.
+	RedactFunctionFilename RedactionTarget = "function_filename"
+	// RedactMappingFile redacts the files of mappings.
+	RedactMappingFile RedactionTarget = "mapping_file"
+	// RedactLabels redacts the values of the labels of a series, except for
+	// the __name__ label.
+	RedactLabels RedactionTarget = "labels"
+	// RedactPprofLabels redacts the values of the string labels of samples.
+	RedactPprofLabels RedactionTarget = "pprof_labels"
+)
+
+// RedactionAction is what a redaction rule replaces matches with.
+type RedactionAction string
+
+const (
+	// RedactReplace replaces every match of the regex with the replacement,
+	// which may reference capture groups like regexp.Regexp.ReplaceAllString.
+	RedactReplace RedactionAction = "replace"
+	// RedactHash replaces every match of the regex with its salted SHA-256
+	// hash, so that equal values can still be told apart from different ones.
+	RedactHash RedactionAction = "hash"
+)
+
+// hashLength is the number of hex characters hashes are truncated to.
+const hashLength = 16
+
+// RedactionRule rewrites values of a profile before they are written to the
+// metastore and the columnstore.
+type RedactionRule struct {
+	target      RedactionTarget
+	labelNames  map[string]struct{}
+	regex       *regexp.Regexp
+	action      RedactionAction
+	replacement string
+	salt        string
+}
+
+// NewRedactionRule returns a RedactionRule. The labelNames limit rules for
+// labels and pprof labels to the given label names, all labels are redacted
+// if it is empty. The salt is only used by the hash action.
+func NewRedactionRule(target RedactionTarget, labelNames []string, regex string, action RedactionAction, replacement, salt string) (*RedactionRule, error) {
+	switch target {
+	case RedactFunctionFilename, RedactMappingFile:
+		if len(labelNames) > 0 {
+			return nil, fmt.Errorf("label names are only supported for the %s and %s targets", RedactLabels, RedactPprofLabels)
+		}
+	case RedactLabels, RedactPprofLabels:
+	default:
+		return nil, fmt.Errorf("unknown redaction target %q", target)
+	}
+
+	switch action {
+	case RedactReplace, RedactHash:
+	default:
+		return nil, fmt.Errorf("unknown redaction action %q", action)
+	}
+
+	rx, err := regexp.Compile(regex)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compile redaction regexp %s: %w", regex, err)
+	}
+
+	r := &RedactionRule{
+		target:      target,
+		regex:       rx,
+		action:      action,
+		replacement: replacement,
+		salt:        salt,
+	}
+	if len(labelNames) > 0 {
+		r.labelNames = make(map[string]struct{}, len(labelNames))
+		for _, n := range labelNames {
+			r.labelNames[n] = struct{}{}
+		}
+	}
+
+	return r, nil
+}
+
+func (r *RedactionRule) redact(s string) string {
+	if r.action == RedactHash {
+		return r.regex.ReplaceAllStringFunc(s, func(match string) string {
+			h := sha256.Sum256([]byte(r.salt + match))
+			return hex.EncodeToString(h[:])[:hashLength]
+		})
+	}
+	return r.regex.ReplaceAllString(s, r.replacement)
+}
+
+func (r *RedactionRule) appliesToLabel(name string) bool {
+	if r.labelNames == nil {
+		return true
+	}
+	_, ok := r.labelNames[name]
+	return ok
+}
+
+// Redactor applies redaction rules in order, each rule is applied to the
+// result of the previous one. A nil Redactor doesn't redact anything.
+type Redactor struct {
+	rules []*RedactionRule
+}
+
+func NewRedactor(rules ...*RedactionRule) *Redactor {
+	return &Redactor{rules: rules}
+}
+
+func (r *Redactor) redact(target RedactionTarget, name, s string) string {
+	if r == nil {
+		return s
+	}
+	for _, rule := range r.rules {
+		if rule.target == target && rule.appliesToLabel(name) {
+			s = rule.redact(s)
+		}
+	}
+	return s
+}
+
+// FunctionFilename returns the redacted filename of a function.
+func (r *Redactor) FunctionFilename(filename string) string {
+	return r.redact(RedactFunctionFilename, "", filename)
+}
+
+// MappingFile returns the redacted file of a mapping.
+func (r *Redactor) MappingFile(file string) string {
+	return r.redact(RedactMappingFile, "", file)
+}
+
+// Label returns the redacted value of a label of a series.
+func (r *Redactor) Label(name, value string) string {
+	if name == labels.MetricName {
+		return value
+	}
+	return r.redact(RedactLabels, name, value)
+}
+
+// PprofLabel returns the redacted value of a string label of a sample.
+func (r *Redactor) PprofLabel(name, value string) string {
+	return r.redact(RedactPprofLabels, name, value)
+}
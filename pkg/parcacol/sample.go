@@ -159,28 +159,16 @@ func (s Sample) ToParquetRow(schema *dynparquet.Schema, row parquet.Row, labelNa
 
 		// All remaining cases take care of dynamic columns
 		case ColumnLabels:
-			labelNamesLen := len(labelNames)
-			i, j := 0, 0
-			for i < labelNamesLen {
-				if labelNames[i] == s.Labels[j].Name {
-					value := parquet.ValueOf(s.Labels[j].Value).Level(0, 1, columnIndex)
-					row = append(row, value)
-					columnIndex++
-					i++
+			j := 0
+			for _, name := range labelNames {
+				if j < len(s.Labels) && s.Labels[j].Name == name {
+					row = append(row, parquet.ValueOf(s.Labels[j].Value).Level(0, 1, columnIndex))
 					j++
-
-					if j >= len(s.Labels) {
-						for ; i < labelNamesLen; i++ {
-							row = append(row, parquet.ValueOf(nil).Level(0, 0, columnIndex))
-						}
-						break
-					}
 				} else {
 					// If nothing matches we add a NULL to the column
 					row = append(row, parquet.ValueOf(nil).Level(0, 0, columnIndex))
-					columnIndex++
-					i++
 				}
+				columnIndex++
 			}
 		case ColumnPprofLabels:
 			for _, name := range pprofLabelNames {
@@ -0,0 +1,146 @@
+// Copyright 2022 The Parca Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parcacol
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/prometheus/prometheus/model/labels"
+)
+
+var (
+	ErrDuplicateSample  = errors.New("duplicate sample")
+	ErrOutOfOrderSample = errors.New("out of order sample")
+	ErrOutOfBounds      = errors.New("sample timestamp out of bounds")
+)
+
+// defaultSeriesRetention is how long series are tracked after the timestamp
+// of their last profile if profiles in the past aren't rejected.
+const defaultSeriesRetention = time.Hour
+
+// seriesTracker tracks the timestamp of the last profile of every series to
+// reject profiles that were already ingested or arrive out of order.
+//
+// Series are forgotten once the timestamp of their last profile is older than
+// the retention, so that the tracker doesn't grow with series churn. With a
+// retention of the out-of-bounds window, profiles of forgotten series are
+// rejected as out of bounds instead.
+type seriesTracker struct {
+	retention time.Duration
+
+	mtx       sync.Mutex
+	series    map[uint64]*trackedSeries
+	lastSweep time.Time
+}
+
+type trackedSeries struct {
+	// last is the timestamp of the last ingested profile, zero if none was
+	// ingested yet.
+	last int64
+	// pending are the timestamps of the profiles being ingested.
+	pending map[int64]struct{}
+}
+
+func newSeriesTracker(retention time.Duration) *seriesTracker {
+	return &seriesTracker{
+		retention: retention,
+		series:    map[uint64]*trackedSeries{},
+	}
+}
+
+// reserve checks that a profile of the series with the timestamp was neither
+// ingested nor is being ingested, and is not older than the last ingested
+// profile. Unless an error is returned, done must be called with the returned
+// hash once the profile was ingested or failed to be.
+func (t *seriesTracker) reserve(now time.Time, ls labels.Labels, timestampNanos int64) (uint64, error) {
+	if !sort.IsSorted(ls) {
+		ls = ls.Copy()
+		sort.Sort(ls)
+	}
+	hash := ls.Hash()
+
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+
+	t.sweep(now)
+
+	s, ok := t.series[hash]
+	if !ok {
+		s = &trackedSeries{pending: map[int64]struct{}{}}
+		t.series[hash] = s
+	}
+	_, pending := s.pending[timestampNanos]
+	if pending || (s.last != 0 && timestampNanos == s.last) {
+		return 0, fmt.Errorf("%w: a profile with timestamp %d was already ingested for series %s", ErrDuplicateSample, timestampNanos, ls)
+	}
+	if s.last != 0 && timestampNanos < s.last {
+		return 0, fmt.Errorf("%w: timestamp %d is older than the last ingested timestamp %d of series %s", ErrOutOfOrderSample, timestampNanos, s.last, ls)
+	}
+
+	s.pending[timestampNanos] = struct{}{}
+	return hash, nil
+}
+
+// done releases the reservation of the timestamp, and records it as the last
+// timestamp of the series if the profile was ingested.
+func (t *seriesTracker) done(hash uint64, timestampNanos int64, ingested bool) {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+
+	s, ok := t.series[hash]
+	if !ok {
+		return
+	}
+	delete(s.pending, timestampNanos)
+	if ingested && timestampNanos > s.last {
+		s.last = timestampNanos
+	}
+	if s.last == 0 && len(s.pending) == 0 {
+		delete(t.series, hash)
+	}
+}
+
+// sweep forgets the series without pending profiles of which the last profile
+// is older than the retention, at most once per retention.
+func (t *seriesTracker) sweep(now time.Time) {
+	if now.Sub(t.lastSweep) < t.retention {
+		return
+	}
+	t.lastSweep = now
+
+	cutoff := now.Add(-t.retention).UnixNano()
+	for hash, s := range t.series {
+		if len(s.pending) == 0 && s.last < cutoff {
+			delete(t.series, hash)
+		}
+	}
+}
+
+// checkTimestampBounds returns an error if the timestamp is further in the
+// past or the future than allowed. A zero duration disables the respective
+// bound.
+func checkTimestampBounds(now time.Time, timestampNanos int64, maxPast, maxFuture time.Duration) error {
+	ts := time.Unix(0, timestampNanos)
+	if maxPast > 0 && ts.Before(now.Add(-maxPast)) {
+		return fmt.Errorf("%w: timestamp %s is more than %s in the past", ErrOutOfBounds, ts.UTC().Format(time.RFC3339), maxPast)
+	}
+	if maxFuture > 0 && ts.After(now.Add(maxFuture)) {
+		return fmt.Errorf("%w: timestamp %s is more than %s in the future", ErrOutOfBounds, ts.UTC().Format(time.RFC3339), maxFuture)
+	}
+	return nil
+}
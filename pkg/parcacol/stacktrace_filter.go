@@ -0,0 +1,318 @@
+// Copyright 2022 The Parca Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parcacol
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/google/pprof/profile"
+
+	parcaprofile "github.com/parca-dev/parca/pkg/profile"
+)
+
+const (
+	// TruncatedFunctionName is the name of the synthetic root frame of
+	// stacks that were cut off at the maximum stack depth.
+	TruncatedFunctionName = "[truncated]"
+	// OtherFunctionName is the name of the synthetic frame of the stack that
+	// all stacks beyond the maximum number of stacks are folded into.
+	OtherFunctionName = "[other]"
+)
+
+// StacktraceFilter rewrites the stacks of a profile before they are written
+// to the metastore and the columnstore.
+type StacktraceFilter struct {
+	dropFrames *regexp.Regexp
+	keepFrames *regexp.Regexp
+	maxDepth   int
+	maxStacks  int
+}
+
+// NewStacktraceFilter returns a StacktraceFilter. The dropFrames and
+// keepFrames regexes have the semantics of pprof's drop_frames and
+// keep_frames, they must match the whole function name. A maxDepth or
+// maxStacks of zero disables the respective limit.
+func NewStacktraceFilter(dropFrames, keepFrames string, maxDepth, maxStacks int) (*StacktraceFilter, error) {
+	f := &StacktraceFilter{
+		maxDepth:  maxDepth,
+		maxStacks: maxStacks,
+	}
+
+	var err error
+	if dropFrames != "" {
+		f.dropFrames, err = regexp.Compile("^(" + dropFrames + ")$")
+		if err != nil {
+			return nil, fmt.Errorf("failed to compile drop frames regexp %s: %w", dropFrames, err)
+		}
+	}
+	if keepFrames != "" {
+		f.keepFrames, err = regexp.Compile("^(" + keepFrames + ")$")
+		if err != nil {
+			return nil, fmt.Errorf("failed to compile keep frames regexp %s: %w", keepFrames, err)
+		}
+	}
+	if maxDepth == 1 {
+		return nil, fmt.Errorf("max depth must be at least 2 to fit the truncation frame")
+	}
+
+	return f, nil
+}
+
+// profileFilter applies a StacktraceFilter to the samples of a single
+// profile. Locations are never modified in place, as the same profile is
+// converted once per sample type.
+type profileFilter struct {
+	filter *StacktraceFilter
+
+	prune        map[uint64]bool
+	pruneBeneath map[uint64]bool
+	trimmed      map[uint64]*profile.Location
+
+	truncated *profile.Location
+	other     *profile.Location
+}
+
+func newProfileFilter(f *StacktraceFilter, p *profile.Profile) *profileFilter {
+	pf := &profileFilter{
+		filter:       f,
+		prune:        map[uint64]bool{},
+		pruneBeneath: map[uint64]bool{},
+		trimmed:      map[uint64]*profile.Location{},
+	}
+
+	// Synthetic frames need IDs that don't collide with the ones of the
+	// profile, as locations and functions are memoized by their ID.
+	var maxLocationID, maxFunctionID uint64
+	for _, l := range p.Location {
+		if l.ID > maxLocationID {
+			maxLocationID = l.ID
+		}
+	}
+	for _, fn := range p.Function {
+		if fn.ID > maxFunctionID {
+			maxFunctionID = fn.ID
+		}
+	}
+	pf.truncated = syntheticLocation(maxLocationID+1, maxFunctionID+1, TruncatedFunctionName)
+	pf.other = syntheticLocation(maxLocationID+2, maxFunctionID+2, OtherFunctionName)
+
+	if f.dropFrames == nil {
+		return pf
+	}
+
+	// This mirrors profile.Prune of the pprof library.
+	for _, loc := range p.Location {
+		var i int
+		for i = len(loc.Line) - 1; i >= 0; i-- {
+			if fn := loc.Line[i].Function; fn != nil && fn.Name != "" {
+				funcName := parcaprofile.SimplifyFunctionName(fn.Name)
+				if f.dropFrames.MatchString(funcName) {
+					if f.keepFrames == nil || !f.keepFrames.MatchString(funcName) {
+						break
+					}
+				}
+			}
+		}
+
+		if i >= 0 {
+			pf.pruneBeneath[loc.ID] = true
+
+			if i == len(loc.Line)-1 {
+				// Matched the top entry: prune the whole location.
+				pf.prune[loc.ID] = true
+			} else {
+				trimmed := *loc
+				trimmed.Line = loc.Line[i+1:]
+				pf.trimmed[loc.ID] = &trimmed
+			}
+		}
+	}
+
+	return pf
+}
+
+func syntheticLocation(locationID, functionID uint64, name string) *profile.Location {
+	return &profile.Location{
+		ID: locationID,
+		Line: []profile.Line{{
+			Function: &profile.Function{
+				ID:         functionID,
+				Name:       name,
+				SystemName: name,
+			},
+		}},
+	}
+}
+
+// samples returns the samples of the given sample type with the filter
+// applied to their stacks.
+func (pf *profileFilter) samples(samples []*profile.Sample, index int) []*profile.Sample {
+	res := make([]*profile.Sample, 0, len(samples))
+	for _, s := range samples {
+		locs := pf.locations(s.Location)
+		if len(locs) == 0 {
+			continue
+		}
+
+		fs := *s
+		fs.Location = locs
+		res = append(res, &fs)
+	}
+
+	if pf.filter.maxStacks > 0 {
+		res = pf.foldStacks(res, index)
+	}
+
+	return res
+}
+
+// locations returns the filtered locations of a single stack. The returned
+// slice never aliases the given one.
+func (pf *profileFilter) locations(locs []*profile.Location) []*profile.Location {
+	if len(pf.pruneBeneath) > 0 {
+		// Scan from the root to the leaves to find the prune location. Do not
+		// prune frames before the first user frame, to avoid pruning
+		// everything.
+		foundUser := false
+		for i := len(locs) - 1; i >= 0; i-- {
+			id := locs[i].ID
+			if !pf.prune[id] && !pf.pruneBeneath[id] {
+				foundUser = true
+				continue
+			}
+			if !foundUser {
+				continue
+			}
+			if pf.prune[id] {
+				locs = locs[i+1:]
+				break
+			}
+			if pf.pruneBeneath[id] {
+				locs = locs[i:]
+				break
+			}
+		}
+	}
+
+	depth := len(locs)
+	truncate := pf.filter.maxDepth > 0 && depth > pf.filter.maxDepth
+	if truncate {
+		depth = pf.filter.maxDepth
+	}
+
+	res := make([]*profile.Location, 0, depth)
+	for _, l := range locs[:depth] {
+		if t, ok := pf.trimmed[l.ID]; ok {
+			l = t
+		}
+		res = append(res, l)
+	}
+
+	if truncate {
+		// The leaf-most frames are kept, the root-most one is replaced.
+		res[depth-1] = pf.truncated
+	}
+
+	return res
+}
+
+// foldStacks keeps the samples of the stacks with the highest values and
+// folds all remaining samples into a single sample.
+func (pf *profileFilter) foldStacks(samples []*profile.Sample, index int) []*profile.Sample {
+	type stack struct {
+		samples []*profile.Sample
+		total   int64
+	}
+
+	stacks := map[string]*stack{}
+	keys := []string{}
+	for _, s := range samples {
+		k := sampleKey(s)
+		st, ok := stacks[k]
+		if !ok {
+			st = &stack{}
+			stacks[k] = st
+			keys = append(keys, k)
+		}
+		st.samples = append(st.samples, s)
+		st.total += s.Value[index]
+	}
+
+	if len(keys) <= pf.filter.maxStacks {
+		return samples
+	}
+
+	sort.SliceStable(keys, func(i, j int) bool {
+		return abs(stacks[keys[i]].total) > abs(stacks[keys[j]].total)
+	})
+
+	res := make([]*profile.Sample, 0, len(samples))
+	for _, k := range keys[:pf.filter.maxStacks] {
+		res = append(res, stacks[k].samples...)
+	}
+
+	other := &profile.Sample{
+		Location: []*profile.Location{pf.other},
+		Value:    make([]int64, len(samples[0].Value)),
+	}
+	for _, k := range keys[pf.filter.maxStacks:] {
+		other.Value[index] += stacks[k].total
+	}
+
+	return append(res, other)
+}
+
+// sampleKey identifies the stack and pprof labels of a sample.
+func sampleKey(s *profile.Sample) string {
+	b := strings.Builder{}
+	for _, l := range s.Location {
+		b.WriteString(strconv.FormatUint(l.ID, 10))
+		b.WriteByte('|')
+	}
+
+	names := make([]string, 0, len(s.Label)+len(s.NumLabel))
+	for k := range s.Label {
+		names = append(names, k)
+	}
+	for k := range s.NumLabel {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+
+	for _, k := range names {
+		b.WriteString(k)
+		b.WriteByte('=')
+		if v, ok := s.Label[k]; ok {
+			b.WriteString(strings.Join(v, ","))
+		}
+		for _, v := range s.NumLabel[k] {
+			b.WriteString(strconv.FormatInt(v, 10))
+			b.WriteByte(',')
+		}
+		b.WriteByte(';')
+	}
+
+	return b.String()
+}
+
+func abs(v int64) int64 {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
@@ -0,0 +1,97 @@
+// Copyright 2022 The Parca Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parcacol
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/go-kit/log"
+	"github.com/polarsignals/arcticdb"
+
+	"github.com/parca-dev/parca/pkg/tenant"
+)
+
+// ErrUnknownTenant is returned when creating the table of a tenant that isn't
+// allowed to write profiles.
+var ErrUnknownTenant = errors.New("unknown tenant")
+
+// Tables holds a table of stacktraces per tenant, so that tenants can't read
+// each other's profiles. The table of the default tenant has the base name,
+// the tables of other tenants have the tenant ID appended. Tables are
+// created when a tenant writes profiles, reads only look them up. Only the
+// default tenant can write profiles unless more tenants are allowed with
+// WithTenants, so that clients can't create an unbounded number of tables.
+type Tables struct {
+	logger log.Logger
+	db     *arcticdb.DB
+	name   string
+	// tenants are the tenants other than the default tenant that can write
+	// profiles.
+	tenants map[string]struct{}
+}
+
+type TablesOption func(*Tables)
+
+// WithTenants allows the given tenants to write profiles in addition to the
+// default tenant.
+func WithTenants(ids ...string) TablesOption {
+	return func(t *Tables) {
+		for _, id := range ids {
+			t.tenants[id] = struct{}{}
+		}
+	}
+}
+
+func NewTables(logger log.Logger, db *arcticdb.DB, name string, opts ...TablesOption) *Tables {
+	t := &Tables{
+		logger:  logger,
+		db:      db,
+		name:    name,
+		tenants: map[string]struct{}{},
+	}
+	for _, opt := range opts {
+		opt(t)
+	}
+	return t
+}
+
+// Name returns the name of the table of the tenant.
+func (t *Tables) Name(tenantID string) string {
+	if tenantID == tenant.Default {
+		return t.name
+	}
+	return t.name + "-" + tenantID
+}
+
+// Table returns the table of the tenant, creating it if it doesn't exist yet.
+// It must only be used to write profiles, ErrUnknownTenant is returned for
+// tenants that aren't allowed to.
+func (t *Tables) Table(tenantID string) (*arcticdb.Table, error) {
+	if tenantID != tenant.Default {
+		if _, ok := t.tenants[tenantID]; !ok {
+			return nil, fmt.Errorf("%w %q", ErrUnknownTenant, tenantID)
+		}
+	}
+	return t.db.Table(t.Name(tenantID), arcticdb.NewTableConfig(Schema()), t.logger)
+}
+
+// Lookup returns the table of the tenant if the tenant wrote profiles before.
+// Unlike Table it never creates a table, so it is safe to use with the tenant
+// IDs of reads.
+func (t *Tables) Lookup(tenantID string) (*arcticdb.Table, bool) {
+	// The provider returns a nil table for unknown names.
+	table, _ := t.db.TableProvider().GetTable(t.Name(tenantID)).(*arcticdb.Table)
+	return table, table != nil
+}
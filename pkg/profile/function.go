@@ -0,0 +1,52 @@
+// Copyright 2022 The Parca Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package profile
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Names containing parentheses that are not argument lists.
+var (
+	reservedNames = []string{"(anonymous namespace)", "operator()"}
+	bracketRx     = func() *regexp.Regexp {
+		quoted := make([]string, 0, len(reservedNames)+1)
+		for _, name := range append(reservedNames, "(") {
+			quoted = append(quoted, regexp.QuoteMeta(name))
+		}
+		return regexp.MustCompile(strings.Join(quoted, "|"))
+	}()
+)
+
+// SimplifyFunctionName strips the argument list of a function name. It is a
+// copy of the unexported simplifyFunc of the pprof library, so that frames
+// are matched exactly like pprof's drop_frames and prune_from match them.
+func SimplifyFunctionName(name string) string {
+	// Account for the leading '.' of the PPC ELF v1 ABI.
+	name = strings.TrimPrefix(name, ".")
+	for _, ind := range bracketRx.FindAllStringIndex(name, -1) {
+		reserved := false
+		for _, r := range reservedNames {
+			if name[ind[0]:ind[1]] == r {
+				reserved = true
+				break
+			}
+		}
+		if !reserved {
+			return name[:ind[0]]
+		}
+	}
+	return name
+}
@@ -0,0 +1,32 @@
+// Copyright 2022 The Parca Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package profile
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSimplifyFunctionName(t *testing.T) {
+	for name, want := range map[string]string{
+		"main.main":                       "main.main",
+		".main.main":                      "main.main",
+		"foo::bar(int, char)":             "foo::bar",
+		"(anonymous namespace)::foo(int)": "(anonymous namespace)::foo",
+		"Foo::operator()(int) const":      "Foo::operator()",
+	} {
+		require.Equal(t, want, SimplifyFunctionName(name), name)
+	}
+}
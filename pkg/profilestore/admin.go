@@ -0,0 +1,74 @@
+// Copyright 2022 The Parca Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package profilestore
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	profilestorepb "github.com/parca-dev/parca/gen/proto/go/parca/profilestore/v1alpha1"
+	"github.com/parca-dev/parca/pkg/tenant"
+)
+
+// AdminServer exposes the usage of the ingest limits of the tenants.
+type AdminServer struct {
+	profilestorepb.UnimplementedAdminServiceServer
+
+	// limiter is nil unless ingest limits are configured.
+	limiter *IngestLimiter
+	// adminTenant sees the usage of all tenants, it is empty if no tenant
+	// does.
+	adminTenant string
+}
+
+var _ profilestorepb.AdminServiceServer = &AdminServer{}
+
+// NewAdminServer returns an admin server that exposes the usage of all tenants
+// to the admin tenant, unless it is empty, and the usage of their own limits
+// to every other tenant.
+func NewAdminServer(limiter *IngestLimiter, adminTenant string) *AdminServer {
+	return &AdminServer{
+		limiter:     limiter,
+		adminTenant: adminTenant,
+	}
+}
+
+// Usage returns the usage of the limits of all tenants to the admin tenant,
+// and the usage of their own limits to other tenants, including the default
+// tenant.
+func (s *AdminServer) Usage(ctx context.Context, _ *profilestorepb.UsageRequest) (*profilestorepb.UsageResponse, error) {
+	if s.limiter == nil {
+		return nil, status.Error(codes.FailedPrecondition, "no ingest limits are configured")
+	}
+
+	tenantID, err := tenant.FromContext(ctx)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	usage := s.limiter.Usage()
+	if s.adminTenant == "" || tenantID != s.adminTenant {
+		own := usage[:0]
+		for _, u := range usage {
+			if u.Tenant == tenantID {
+				own = append(own, u)
+			}
+		}
+		usage = own
+	}
+
+	return &profilestorepb.UsageResponse{Usage: usage}, nil
+}
@@ -0,0 +1,98 @@
+// Copyright 2022 The Parca Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package profilestore
+
+import (
+	"sync"
+	"time"
+
+	"github.com/prometheus/prometheus/model/labels"
+)
+
+// replicaDeduplicator deduplicates the series sent by a highly available
+// pair of scrapers. Both scrapers attach the replica label with a distinct
+// value to otherwise identical series. For every series one replica is
+// elected whose profiles are stored, the profiles of all other replicas are
+// dropped. When the elected replica hasn't sent a profile for the failover
+// timeout, the next replica to send a profile is elected.
+type replicaDeduplicator struct {
+	replicaLabel    string
+	failoverTimeout time.Duration
+	now             func() time.Time
+
+	mtx       sync.Mutex
+	elected   map[uint64]*electedReplica
+	lastSweep time.Time
+}
+
+type electedReplica struct {
+	replica  string
+	lastSeen time.Time
+}
+
+func newReplicaDeduplicator(replicaLabel string, failoverTimeout time.Duration) *replicaDeduplicator {
+	return &replicaDeduplicator{
+		replicaLabel:    replicaLabel,
+		failoverTimeout: failoverTimeout,
+		now:             time.Now,
+		elected:         map[uint64]*electedReplica{},
+	}
+}
+
+// accept returns the labels of the series without the replica label, and
+// whether profiles of this replica of the series should be stored.
+func (d *replicaDeduplicator) accept(ls labels.Labels) (labels.Labels, bool) {
+	replica := ls.Get(d.replicaLabel)
+	if replica == "" {
+		return ls, true
+	}
+
+	series := labels.NewBuilder(ls).Del(d.replicaLabel).Labels()
+	hash := series.Hash()
+	now := d.now()
+
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+
+	d.sweep(now)
+
+	e, ok := d.elected[hash]
+	if !ok || now.Sub(e.lastSeen) > d.failoverTimeout {
+		d.elected[hash] = &electedReplica{replica: replica, lastSeen: now}
+		return series, true
+	}
+
+	if e.replica != replica {
+		return series, false
+	}
+
+	e.lastSeen = now
+	return series, true
+}
+
+// sweep removes the elected replicas of series that haven't been written
+// within the failover timeout, as they would be re-elected anyway. Must be
+// called with the lock held.
+func (d *replicaDeduplicator) sweep(now time.Time) {
+	if now.Sub(d.lastSweep) < d.failoverTimeout {
+		return
+	}
+	d.lastSweep = now
+
+	for hash, e := range d.elected {
+		if now.Sub(e.lastSeen) > d.failoverTimeout {
+			delete(d.elected, hash)
+		}
+	}
+}
@@ -0,0 +1,102 @@
+// Copyright 2022 The Parca Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package profilestore
+
+import (
+	"errors"
+	"fmt"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/runtime/protoiface"
+
+	"github.com/parca-dev/parca/pkg/parcacol"
+	"github.com/parca-dev/parca/pkg/rejection"
+)
+
+// rejectedProfileError converts the errors of the ingester and the ingest
+// limiter for rejected profiles into a gRPC status with the reason attached.
+// It returns nil if the error is not a rejection.
+func rejectedProfileError(err error) error {
+	var (
+		code   codes.Code
+		reason string
+	)
+	switch {
+	case errors.Is(err, parcacol.ErrDuplicateSample):
+		code, reason = codes.AlreadyExists, rejection.DuplicateSample
+	case errors.Is(err, parcacol.ErrOutOfOrderSample):
+		code, reason = codes.OutOfRange, rejection.OutOfOrderSample
+	case errors.Is(err, parcacol.ErrOutOfBounds):
+		code, reason = codes.OutOfRange, rejection.OutOfBounds
+	case errors.Is(err, parcacol.ErrCardinalityLimit):
+		code, reason = codes.ResourceExhausted, rejection.CardinalityLimit
+	case errors.Is(err, ErrRateLimit):
+		code, reason = codes.ResourceExhausted, rejection.RateLimit
+	case errors.Is(err, ErrSamplesLimit):
+		code, reason = codes.ResourceExhausted, rejection.SamplesLimit
+	case errors.Is(err, ErrSeriesLimit):
+		code, reason = codes.ResourceExhausted, rejection.SeriesLimit
+	default:
+		return nil
+	}
+
+	st, detailsErr := status.New(code, err.Error()).WithDetails(&errdetails.ErrorInfo{
+		Reason: reason,
+		Domain: rejection.Domain,
+	})
+	if detailsErr != nil {
+		return status.Error(code, err.Error())
+	}
+	return st.Err()
+}
+
+// rejectedSeriesError combines the rejections of the series of a WriteRaw
+// request. The status has the code of the first rejection and carries the
+// reason of every rejected series, with the labels of the series in the
+// metadata of the details. It returns nil if no series was rejected.
+func rejectedSeriesError(series []rawSeries, rejected []error) error {
+	var (
+		first   *status.Status
+		details []protoiface.MessageV1
+	)
+	for i, err := range rejected {
+		if err == nil {
+			continue
+		}
+		st, _ := status.FromError(err)
+		if first == nil {
+			first = st
+		}
+		details = append(details, &errdetails.ErrorInfo{
+			Reason:   rejection.Reason(err),
+			Domain:   rejection.Domain,
+			Metadata: map[string]string{"series": series[i].labels.String()},
+		})
+	}
+	if first == nil {
+		return nil
+	}
+
+	msg := first.Message()
+	if len(details) > 1 {
+		msg = fmt.Sprintf("%d series rejected, first: %s", len(details), msg)
+	}
+	st, err := status.New(first.Code(), msg).WithDetails(details...)
+	if err != nil {
+		return first.Err()
+	}
+	return st.Err()
+}
@@ -48,3 +48,11 @@ func (s *GRPCForwarder) WriteRaw(ctx context.Context, req *profilestorepb.WriteR
 	}
 	return resp, err
 }
+
+func (s *GRPCForwarder) WriteArrow(ctx context.Context, req *profilestorepb.WriteArrowRequest) (*profilestorepb.WriteArrowResponse, error) {
+	resp, err := s.client.WriteArrow(ctx, req)
+	if err != nil {
+		level.Warn(s.logger).Log("msg", "failed to forward arrow records", "err", err)
+	}
+	return resp, err
+}
@@ -0,0 +1,502 @@
+// Copyright 2022 The Parca Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package profilestore
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/prometheus/prometheus/promql/parser"
+
+	profilestorepb "github.com/parca-dev/parca/gen/proto/go/parca/profilestore/v1alpha1"
+	"github.com/parca-dev/parca/pkg/rejection"
+	"github.com/parca-dev/parca/pkg/tenant"
+)
+
+var (
+	ErrRateLimit    = errors.New("ingestion rate limit exceeded")
+	ErrSamplesLimit = errors.New("samples per profile limit exceeded")
+	ErrSeriesLimit  = errors.New("active series limit exceeded")
+)
+
+// IngestLimits bound the profiles a tenant writes via WriteRaw and WriteArrow,
+// where the samples of a series and timestamp count as a profile. The rates are
+// enforced with token buckets that hold up to the burst, which defaults to
+// the tokens of one second. A zero limit is disabled.
+type IngestLimits struct {
+	ProfilesPerSecond float64 `yaml:"profiles_per_second,omitempty"`
+	ProfilesBurst     int     `yaml:"profiles_burst,omitempty"`
+	// Profiles larger than the bytes burst are always rejected.
+	BytesPerSecond       float64 `yaml:"bytes_per_second,omitempty"`
+	BytesBurst           int     `yaml:"bytes_burst,omitempty"`
+	MaxSamplesPerProfile int     `yaml:"max_samples_per_profile,omitempty"`
+	// Series are active until they didn't receive a profile for the active
+	// series timeout.
+	MaxActiveSeries int `yaml:"max_active_series,omitempty"`
+}
+
+// override returns the limits with the ones that aren't set taken from the
+// given defaults. Bursts are only inherited along with their rate.
+func (l IngestLimits) override(defaults IngestLimits) IngestLimits {
+	if l.ProfilesPerSecond == 0 {
+		l.ProfilesPerSecond = defaults.ProfilesPerSecond
+		if l.ProfilesBurst == 0 {
+			l.ProfilesBurst = defaults.ProfilesBurst
+		}
+	}
+	if l.BytesPerSecond == 0 {
+		l.BytesPerSecond = defaults.BytesPerSecond
+		if l.BytesBurst == 0 {
+			l.BytesBurst = defaults.BytesBurst
+		}
+	}
+	if l.MaxSamplesPerProfile == 0 {
+		l.MaxSamplesPerProfile = defaults.MaxSamplesPerProfile
+	}
+	if l.MaxActiveSeries == 0 {
+		l.MaxActiveSeries = defaults.MaxActiveSeries
+	}
+	return l
+}
+
+// Validate returns an error if one of the limits is negative.
+func (l IngestLimits) Validate() error {
+	if l.ProfilesPerSecond < 0 || l.ProfilesBurst < 0 ||
+		l.BytesPerSecond < 0 || l.BytesBurst < 0 ||
+		l.MaxSamplesPerProfile < 0 || l.MaxActiveSeries < 0 {
+		return errors.New("limits must not be negative")
+	}
+	return nil
+}
+
+// IngestLimitsConfig overrides the default ingest limits for tenants and for
+// the series matching label selectors. Limits that aren't set by an override
+// are inherited.
+type IngestLimitsConfig struct {
+	// Tenants override the default limits of the given tenants.
+	Tenants map[string]IngestLimits `yaml:"tenants,omitempty"`
+	// Labels override the limits of the series of every tenant matching
+	// the selector. They are limited separately from the remaining series
+	// of the tenant. Of the configured selectors, the first matching one
+	// applies.
+	Labels []*LabelIngestLimits `yaml:"labels,omitempty"`
+}
+
+// LabelIngestLimits override the ingest limits of the series matching the
+// selector, e.g. {job="noisy"}.
+type LabelIngestLimits struct {
+	Selector     string `yaml:"selector"`
+	IngestLimits `yaml:",inline"`
+}
+
+// Validate returns an error if the ingest limits config is not valid.
+func (c *IngestLimitsConfig) Validate() error {
+	for id, l := range c.Tenants {
+		if err := tenant.Validate(id); err != nil {
+			return err
+		}
+		if err := l.Validate(); err != nil {
+			return fmt.Errorf("tenant %s: %w", id, err)
+		}
+	}
+	for i, l := range c.Labels {
+		if _, err := parser.ParseMetricSelector(l.Selector); err != nil {
+			return fmt.Errorf("label limits %d: invalid selector: %w", i, err)
+		}
+		if err := l.Validate(); err != nil {
+			return fmt.Errorf("label limits %d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+type labelIngestLimits struct {
+	selector string
+	matchers []*labels.Matcher
+	limits   IngestLimits
+}
+
+func (l labelIngestLimits) matches(ls labels.Labels) bool {
+	for _, m := range l.matchers {
+		if !m.Matches(ls.Get(m.Name)) {
+			return false
+		}
+	}
+	return true
+}
+
+// IngestLimiter enforces the ingest limits of the tenants. Every tenant, and
+// every label selector of a tenant, has its own token buckets and active
+// series. Its usage is exposed as metrics.
+type IngestLimiter struct {
+	defaults            IngestLimits
+	tenants             map[string]IngestLimits
+	labels              []labelIngestLimits
+	activeSeriesTimeout time.Duration
+	now                 func() time.Time
+
+	mtx       sync.Mutex
+	scopes    map[scopeKey]*limitsScope
+	lastSweep time.Time
+
+	activeSeriesDesc      *prometheus.Desc
+	availableProfilesDesc *prometheus.Desc
+	availableBytesDesc    *prometheus.Desc
+	rejectedProfilesDesc  *prometheus.Desc
+}
+
+// scopeKey identifies the series of a tenant that share limits. The label
+// limits are referenced by their index, -1 for the series not matching any.
+type scopeKey struct {
+	tenant string
+	labels int
+}
+
+type limitsScope struct {
+	selector string
+	limits   IngestLimits
+	// profiles and bytes are nil if their rate is not limited.
+	profiles *tokenBucket
+	bytes    *tokenBucket
+
+	series    map[uint64]time.Time
+	lastSweep time.Time
+	rejected  map[string]int64
+	// lastSeen is the time the scope last received a profile.
+	lastSeen time.Time
+}
+
+func NewIngestLimiter(
+	reg prometheus.Registerer,
+	defaults IngestLimits,
+	cfg *IngestLimitsConfig,
+	activeSeriesTimeout time.Duration,
+) (*IngestLimiter, error) {
+	l := &IngestLimiter{
+		defaults:            defaults,
+		tenants:             map[string]IngestLimits{},
+		activeSeriesTimeout: activeSeriesTimeout,
+		now:                 time.Now,
+		scopes:              map[scopeKey]*limitsScope{},
+		activeSeriesDesc: prometheus.NewDesc(
+			"parca_profilestore_limits_active_series",
+			"Number of series that received profiles within the active series timeout.",
+			[]string{"tenant", "selector"}, nil,
+		),
+		availableProfilesDesc: prometheus.NewDesc(
+			"parca_profilestore_limits_available_profiles",
+			"Number of profiles that can be written before the profiles per second limit is hit.",
+			[]string{"tenant", "selector"}, nil,
+		),
+		availableBytesDesc: prometheus.NewDesc(
+			"parca_profilestore_limits_available_bytes",
+			"Number of bytes that can be written before the bytes per second limit is hit.",
+			[]string{"tenant", "selector"}, nil,
+		),
+		rejectedProfilesDesc: prometheus.NewDesc(
+			"parca_profilestore_limits_rejected_profiles_total",
+			"Total number of profiles rejected for exceeding an ingest limit.",
+			[]string{"tenant", "selector", "reason"}, nil,
+		),
+	}
+	if err := defaults.Validate(); err != nil {
+		return nil, err
+	}
+	if cfg != nil {
+		if err := cfg.Validate(); err != nil {
+			return nil, err
+		}
+		for id, limits := range cfg.Tenants {
+			l.tenants[id] = limits
+		}
+		for _, c := range cfg.Labels {
+			matchers, err := parser.ParseMetricSelector(c.Selector)
+			if err != nil {
+				return nil, err
+			}
+			l.labels = append(l.labels, labelIngestLimits{
+				selector: c.Selector,
+				matchers: matchers,
+				limits:   c.IngestLimits,
+			})
+		}
+	}
+	reg.MustRegister(l)
+	return l, nil
+}
+
+// scope returns the limits of the series of the tenant. Must be called with
+// the lock held.
+func (l *IngestLimiter) scope(tenantID string, ls labels.Labels) *limitsScope {
+	now := l.now()
+	l.sweepScopes(now)
+
+	key := scopeKey{tenant: tenantID, labels: -1}
+	for i, ll := range l.labels {
+		if ll.matches(ls) {
+			key.labels = i
+			break
+		}
+	}
+	if s, ok := l.scopes[key]; ok {
+		s.lastSeen = now
+		return s
+	}
+
+	limits := l.tenants[tenantID].override(l.defaults)
+	s := &limitsScope{
+		series:   map[uint64]time.Time{},
+		rejected: map[string]int64{},
+		lastSeen: now,
+	}
+	if key.labels >= 0 {
+		s.selector = l.labels[key.labels].selector
+		limits = l.labels[key.labels].limits.override(limits)
+	}
+	s.limits = limits
+	if limits.ProfilesPerSecond > 0 {
+		s.profiles = newTokenBucket(limits.ProfilesPerSecond, limits.ProfilesBurst, now)
+	}
+	if limits.BytesPerSecond > 0 {
+		s.bytes = newTokenBucket(limits.BytesPerSecond, limits.BytesBurst, now)
+	}
+	l.scopes[key] = s
+	return s
+}
+
+// Allow takes a profile of the given size from the token buckets of the
+// series, and checks its number of samples and the active series limit. It
+// enforces the limits for the ingester of samples written via WriteArrow.
+func (l *IngestLimiter) Allow(tenantID string, ls labels.Labels, size, samples int) error {
+	if err := l.allowRate(tenantID, ls, size); err != nil {
+		return err
+	}
+	return l.allowProfile(tenantID, ls, samples)
+}
+
+// allowRate takes a profile of the given size from the token buckets of the
+// series. If either of them doesn't hold enough tokens, ErrRateLimit is
+// returned and no tokens are taken.
+func (l *IngestLimiter) allowRate(tenantID string, ls labels.Labels, size int) error {
+	if l == nil {
+		return nil
+	}
+
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+
+	s := l.scope(tenantID, ls)
+	now := l.now()
+	if s.profiles != nil && s.profiles.available(now) < 1 {
+		s.rejected[rejection.RateLimit]++
+		return fmt.Errorf("%w: more than %g profiles per second", ErrRateLimit, s.limits.ProfilesPerSecond)
+	}
+	if s.bytes != nil && s.bytes.available(now) < float64(size) {
+		s.rejected[rejection.RateLimit]++
+		return fmt.Errorf("%w: more than %g bytes per second", ErrRateLimit, s.limits.BytesPerSecond)
+	}
+	if s.profiles != nil {
+		s.profiles.take(1)
+	}
+	if s.bytes != nil {
+		s.bytes.take(float64(size))
+	}
+	return nil
+}
+
+// allowProfile checks the number of samples of a profile of the series, and
+// marks the series as active unless that exceeds the active series limit.
+func (l *IngestLimiter) allowProfile(tenantID string, ls labels.Labels, samples int) error {
+	if l == nil {
+		return nil
+	}
+
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+
+	s := l.scope(tenantID, ls)
+	if s.limits.MaxSamplesPerProfile > 0 && samples > s.limits.MaxSamplesPerProfile {
+		s.rejected[rejection.SamplesLimit]++
+		return fmt.Errorf("%w: profile has %d samples, the limit is %d", ErrSamplesLimit, samples, s.limits.MaxSamplesPerProfile)
+	}
+
+	now := l.now()
+	hash := ls.Hash()
+	l.sweep(s, now, false)
+	if _, ok := s.series[hash]; !ok && s.limits.MaxActiveSeries > 0 && len(s.series) >= s.limits.MaxActiveSeries {
+		// Inactive series may not have been swept yet.
+		l.sweep(s, now, true)
+		if len(s.series) >= s.limits.MaxActiveSeries {
+			s.rejected[rejection.SeriesLimit]++
+			return fmt.Errorf("%w: the limit is %d series", ErrSeriesLimit, s.limits.MaxActiveSeries)
+		}
+	}
+	s.series[hash] = now
+	return nil
+}
+
+// sweepScopes removes the scopes that didn't receive a profile within the
+// active series timeout and of which the token buckets are full again, as
+// apart from their rejected profiles they are the same as new scopes. This
+// keeps the scopes of tenants that stopped writing profiles, or that only
+// wrote a few, from piling up. Scopes are swept at most once per timeout.
+// Must be called with the lock held.
+func (l *IngestLimiter) sweepScopes(now time.Time) {
+	if now.Sub(l.lastSweep) < l.activeSeriesTimeout {
+		return
+	}
+	l.lastSweep = now
+
+	for key, s := range l.scopes {
+		if now.Sub(s.lastSeen) <= l.activeSeriesTimeout {
+			continue
+		}
+		if s.profiles != nil && s.profiles.available(now) < s.profiles.burst {
+			continue
+		}
+		if s.bytes != nil && s.bytes.available(now) < s.bytes.burst {
+			continue
+		}
+		delete(l.scopes, key)
+	}
+}
+
+// sweep removes the series that didn't receive a profile within the active
+// series timeout. Unless forced, series are swept at most once per timeout.
+// Must be called with the lock held.
+func (l *IngestLimiter) sweep(s *limitsScope, now time.Time, force bool) {
+	if !force && now.Sub(s.lastSweep) < l.activeSeriesTimeout {
+		return
+	}
+	s.lastSweep = now
+
+	for hash, lastSeen := range s.series {
+		if now.Sub(lastSeen) > l.activeSeriesTimeout {
+			delete(s.series, hash)
+		}
+	}
+}
+
+// Usage returns the usage of the limits of every tenant, and of every label
+// selector of a tenant, that received profiles.
+func (l *IngestLimiter) Usage() []*profilestorepb.LimitsUsage {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+
+	now := l.now()
+	usage := make([]*profilestorepb.LimitsUsage, 0, len(l.scopes))
+	for key, s := range l.scopes {
+		l.sweep(s, now, true)
+
+		u := &profilestorepb.LimitsUsage{
+			Tenant:   key.tenant,
+			Selector: s.selector,
+			Limits: &profilestorepb.IngestLimits{
+				ProfilesPerSecond:    s.limits.ProfilesPerSecond,
+				ProfilesBurst:        int64(s.limits.ProfilesBurst),
+				BytesPerSecond:       s.limits.BytesPerSecond,
+				BytesBurst:           int64(s.limits.BytesBurst),
+				MaxSamplesPerProfile: int64(s.limits.MaxSamplesPerProfile),
+				MaxActiveSeries:      int64(s.limits.MaxActiveSeries),
+			},
+			ActiveSeries:     int64(len(s.series)),
+			RejectedProfiles: make(map[string]int64, len(s.rejected)),
+		}
+		if s.profiles != nil {
+			u.AvailableProfiles = s.profiles.available(now)
+		}
+		if s.bytes != nil {
+			u.AvailableBytes = s.bytes.available(now)
+		}
+		for reason, n := range s.rejected {
+			u.RejectedProfiles[reason] = n
+		}
+		usage = append(usage, u)
+	}
+
+	sort.Slice(usage, func(i, j int) bool {
+		if usage[i].Tenant != usage[j].Tenant {
+			return usage[i].Tenant < usage[j].Tenant
+		}
+		return usage[i].Selector < usage[j].Selector
+	})
+	return usage
+}
+
+func (l *IngestLimiter) Describe(ch chan<- *prometheus.Desc) {
+	ch <- l.activeSeriesDesc
+	ch <- l.availableProfilesDesc
+	ch <- l.availableBytesDesc
+	ch <- l.rejectedProfilesDesc
+}
+
+func (l *IngestLimiter) Collect(ch chan<- prometheus.Metric) {
+	for _, u := range l.Usage() {
+		ch <- prometheus.MustNewConstMetric(l.activeSeriesDesc, prometheus.GaugeValue, float64(u.ActiveSeries), u.Tenant, u.Selector)
+		if u.Limits.ProfilesPerSecond > 0 {
+			ch <- prometheus.MustNewConstMetric(l.availableProfilesDesc, prometheus.GaugeValue, u.AvailableProfiles, u.Tenant, u.Selector)
+		}
+		if u.Limits.BytesPerSecond > 0 {
+			ch <- prometheus.MustNewConstMetric(l.availableBytesDesc, prometheus.GaugeValue, u.AvailableBytes, u.Tenant, u.Selector)
+		}
+		for reason, n := range u.RejectedProfiles {
+			ch <- prometheus.MustNewConstMetric(l.rejectedProfilesDesc, prometheus.CounterValue, float64(n), u.Tenant, u.Selector, reason)
+		}
+	}
+}
+
+// tokenBucket holds up to burst tokens and is refilled at the rate of tokens
+// per second.
+type tokenBucket struct {
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+// newTokenBucket returns a full token bucket. A zero burst defaults to the
+// tokens of one second, but at least one.
+func newTokenBucket(rate float64, burst int, now time.Time) *tokenBucket {
+	b := float64(burst)
+	if b == 0 {
+		b = math.Max(rate, 1)
+	}
+	return &tokenBucket{
+		rate:   rate,
+		burst:  b,
+		tokens: b,
+		last:   now,
+	}
+}
+
+// available refills the bucket and returns the tokens it holds.
+func (b *tokenBucket) available(now time.Time) float64 {
+	if now.After(b.last) {
+		b.tokens = math.Min(b.burst, b.tokens+now.Sub(b.last).Seconds()*b.rate)
+		b.last = now
+	}
+	return b.tokens
+}
+
+// take removes the tokens from the bucket, available must have been called
+// before to check that it holds enough of them.
+func (b *tokenBucket) take(n float64) {
+	b.tokens -= n
+}
@@ -0,0 +1,86 @@
+// Copyright 2022 The Parca Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package profilestore
+
+import (
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Reasons of write errors other than rejections.
+const (
+	errorInvalidLabelName = "invalid_label_name"
+	errorParse            = "parse"
+	errorInvalidProfile   = "invalid_profile"
+	errorInvalidRecord    = "invalid_record"
+	errorInternal         = "internal"
+)
+
+type metrics struct {
+	receivedBytes    *prometheus.CounterVec
+	profilesIngested *prometheus.CounterVec
+	profilesRejected *prometheus.CounterVec
+	profileSize      *prometheus.HistogramVec
+	writeErrors      *prometheus.CounterVec
+}
+
+func newMetrics(reg prometheus.Registerer) *metrics {
+	m := &metrics{
+		receivedBytes: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "parca_profilestore_received_bytes_total",
+				Help: "Total number of bytes of raw profiles and Arrow records received per tenant.",
+			},
+			[]string{"tenant"},
+		),
+		profilesIngested: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "parca_profilestore_profiles_ingested_total",
+				Help: "Total number of raw profiles ingested per tenant.",
+			},
+			[]string{"tenant"},
+		),
+		profilesRejected: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "parca_profilestore_profiles_rejected_total",
+				Help: "Total number of raw profiles and Arrow records with rejected samples per tenant and reason.",
+			},
+			[]string{"tenant", "reason"},
+		),
+		profileSize: prometheus.NewHistogramVec(
+			prometheus.HistogramOpts{
+				Name:    "parca_profilestore_profile_size_bytes",
+				Help:    "Size of the raw profiles received per profile name.",
+				Buckets: prometheus.ExponentialBuckets(1024, 4, 10),
+			},
+			[]string{"name"},
+		),
+		writeErrors: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "parca_profilestore_write_errors_total",
+				Help: "Total number of raw profiles and Arrow records that failed to be written per profile name and reason. Arrow records have an empty profile name.",
+			},
+			[]string{"name", "reason"},
+		),
+	}
+	reg.MustRegister(m.receivedBytes, m.profilesIngested, m.profilesRejected, m.profileSize, m.writeErrors)
+	return m
+}
+
+// writeError records a write error of the profiles of the given name. The
+// reasons of rejections are lower cased, e.g. duplicate_sample.
+func (m *metrics) writeError(name, reason string) {
+	m.writeErrors.WithLabelValues(name, strings.ToLower(reason)).Inc()
+}
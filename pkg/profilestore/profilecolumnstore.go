@@ -17,15 +17,20 @@ import (
 	"bytes"
 	"context"
 	"encoding/base64"
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"os"
+	"runtime"
+	"strings"
+	"sync"
 	"time"
 
+	"github.com/apache/arrow/go/v8/arrow/ipc"
 	"github.com/go-kit/log"
 	"github.com/go-kit/log/level"
 	"github.com/google/pprof/profile"
-	"github.com/polarsignals/arcticdb"
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/common/model"
 	"github.com/prometheus/prometheus/model/labels"
 	"github.com/prometheus/prometheus/model/timestamp"
@@ -36,16 +41,41 @@ import (
 	profilestorepb "github.com/parca-dev/parca/gen/proto/go/parca/profilestore/v1alpha1"
 	"github.com/parca-dev/parca/pkg/metastore"
 	"github.com/parca-dev/parca/pkg/parcacol"
+	"github.com/parca-dev/parca/pkg/rawprofile"
+	"github.com/parca-dev/parca/pkg/rejection"
+	"github.com/parca-dev/parca/pkg/tenant"
 )
 
 type ProfileColumnStore struct {
 	profilestorepb.UnimplementedProfileStoreServiceServer
 
-	logger    log.Logger
-	tracer    trace.Tracer
-	metaStore metastore.ProfileMetaStore
+	logger        log.Logger
+	metrics       *metrics
+	ingestMetrics *parcacol.IngestMetrics
+	tracer        trace.Tracer
+	metaStore     metastore.ProfileMetaStore
 
-	table *arcticdb.Table
+	tables       *parcacol.Tables
+	ingesterOpts []parcacol.IngesterOption
+
+	// When a replica label is configured, the series of highly available
+	// scrapers are deduplicated.
+	replicaLabel    string
+	failoverTimeout time.Duration
+
+	mtx     sync.Mutex
+	tenants map[string]*tenantStore
+
+	// limiter is nil unless ingest limits are configured.
+	limiter *IngestLimiter
+
+	// workers bounds the number of profiles ingested concurrently, nil if
+	// the profiles are ingested one after another.
+	workers chan struct{}
+
+	// rawProfiles archives the profiles written with WriteRaw exactly as they
+	// were received, nil if they are not archived.
+	rawProfiles *rawprofile.Archive
 
 	// When the debug-value-log is enabled, every profile is first written to
 	// tmp/<labels>/<timestamp>.pb.gz before it's parsed and written to the
@@ -55,34 +85,162 @@ type ProfileColumnStore struct {
 	debugValueLog bool
 }
 
+// tenantStore holds the state of a tenant, the series of different tenants
+// are independent of each other.
+type tenantStore struct {
+	ingester *parcacol.Ingester
+	// dedup is nil unless a replica label is configured.
+	dedup *replicaDeduplicator
+}
+
 var _ profilestorepb.ProfileStoreServiceServer = &ProfileColumnStore{}
 
+type Option func(*ProfileColumnStore)
+
+// WithIngesterOptions configures the ingester that writes the profiles to the
+// columnstore.
+func WithIngesterOptions(opts ...parcacol.IngesterOption) Option {
+	return func(s *ProfileColumnStore) {
+		s.ingesterOpts = append(s.ingesterOpts, opts...)
+	}
+}
+
+// WithReplicaDeduplication deduplicates series that only differ in the value
+// of the given replica label. The replica label is removed before the
+// profiles are stored.
+func WithReplicaDeduplication(replicaLabel string, failoverTimeout time.Duration) Option {
+	return func(s *ProfileColumnStore) {
+		s.replicaLabel = replicaLabel
+		s.failoverTimeout = failoverTimeout
+	}
+}
+
+// WithIngestLimiter enforces the ingest limits of the tenants on the profiles
+// written with WriteRaw.
+func WithIngestLimiter(l *IngestLimiter) Option {
+	return func(s *ProfileColumnStore) {
+		s.limiter = l
+	}
+}
+
+// WithIngestConcurrency ingests the series of the WriteRaw requests
+// concurrently, with at most n profiles being parsed and ingested at a time
+// across all requests. If n is zero or less it defaults to GOMAXPROCS.
+func WithIngestConcurrency(n int) Option {
+	return func(s *ProfileColumnStore) {
+		if n <= 0 {
+			n = runtime.GOMAXPROCS(0)
+		}
+		s.workers = make(chan struct{}, n)
+	}
+}
+
+// WithRawProfiles archives the raw profiles written with WriteRaw, keyed by
+// their series and timestamp.
+func WithRawProfiles(a *rawprofile.Archive) Option {
+	return func(s *ProfileColumnStore) {
+		s.rawProfiles = a
+	}
+}
+
 func NewProfileColumnStore(
 	logger log.Logger,
+	reg prometheus.Registerer,
 	tracer trace.Tracer,
 	metaStore metastore.ProfileMetaStore,
-	table *arcticdb.Table,
+	tables *parcacol.Tables,
 	debugValueLog bool,
+	opts ...Option,
 ) *ProfileColumnStore {
-	return &ProfileColumnStore{
+	s := &ProfileColumnStore{
 		logger:        logger,
+		metrics:       newMetrics(reg),
+		ingestMetrics: parcacol.NewIngestMetrics(reg),
 		tracer:        tracer,
 		metaStore:     metaStore,
-		table:         table,
+		tables:        tables,
+		tenants:       map[string]*tenantStore{},
 		debugValueLog: debugValueLog,
 	}
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s
+}
+
+// tenant returns the state of the tenant, creating its table if the tenant
+// didn't write profiles before.
+func (s *ProfileColumnStore) tenant(tenantID string) (*tenantStore, error) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	if t, ok := s.tenants[tenantID]; ok {
+		return t, nil
+	}
+
+	table, err := s.tables.Table(tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := append([]parcacol.IngesterOption{
+		parcacol.WithTenant(tenantID),
+		parcacol.WithIngestMetrics(s.ingestMetrics),
+	}, s.ingesterOpts...)
+	if s.limiter != nil {
+		opts = append(opts, parcacol.WithArrowLimiter(s.limiter))
+	}
+	t := &tenantStore{
+		ingester: parcacol.NewIngester(s.logger, s.metaStore, table, opts...),
+	}
+	if s.replicaLabel != "" {
+		t.dedup = newReplicaDeduplicator(s.replicaLabel, s.failoverTimeout)
+	}
+	s.tenants[tenantID] = t
+	return t, nil
+}
+
+// requestTenant returns the state of the tenant of the request.
+func (s *ProfileColumnStore) requestTenant(ctx context.Context) (string, *tenantStore, error) {
+	tenantID, err := tenant.FromContext(ctx)
+	if err != nil {
+		return "", nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	t, err := s.tenant(tenantID)
+	if errors.Is(err, parcacol.ErrUnknownTenant) {
+		return "", nil, status.Error(codes.PermissionDenied, err.Error())
+	}
+	if err != nil {
+		return "", nil, status.Errorf(codes.Internal, "failed to create table of tenant: %v", err)
+	}
+	return tenantID, t, nil
 }
 
 func (s *ProfileColumnStore) WriteRaw(ctx context.Context, r *profilestorepb.WriteRawRequest) (*profilestorepb.WriteRawResponse, error) {
 	ctx, span := s.tracer.Start(ctx, "write-raw")
 	defer span.End()
 
-	ingester := parcacol.NewIngester(s.logger, s.metaStore, s.table)
+	tenantID, t, err := s.requestTenant(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// The labels of all series are validated before any profile is ingested.
+	series := make([]rawSeries, 0, len(r.Series))
+	for _, rs := range r.Series {
+		var name string
+		for _, l := range rs.Labels.Labels {
+			if l.Name == labels.MetricName {
+				name = l.Value
+			}
+		}
 
-	for _, series := range r.Series {
-		ls := make(labels.Labels, 0, len(series.Labels.Labels))
-		for _, l := range series.Labels.Labels {
+		ls := make(labels.Labels, 0, len(rs.Labels.Labels))
+		for _, l := range rs.Labels.Labels {
 			if valid := model.LabelName(l.Name).IsValid(); !valid {
+				s.metrics.writeError(name, errorInvalidLabelName)
 				return nil, status.Errorf(codes.InvalidArgument, "invalid label name: %v", l.Name)
 			}
 
@@ -92,34 +250,299 @@ func (s *ProfileColumnStore) WriteRaw(ctx context.Context, r *profilestorepb.Wri
 			})
 		}
 
-		for _, sample := range series.Samples {
-			p, err := profile.Parse(bytes.NewBuffer(sample.RawProfile))
-			if err != nil {
-				return nil, status.Errorf(codes.InvalidArgument, "failed to parse profile: %v", err)
+		if t.dedup != nil {
+			var accept bool
+			ls, accept = t.dedup.accept(ls)
+			if !accept {
+				level.Debug(s.logger).Log("msg", "dropping profiles of non-elected replica", "labels", ls.String())
+				continue
+			}
+		}
+
+		series = append(series, rawSeries{name: name, labels: ls, samples: rs.Samples})
+	}
+
+	// Rejected profiles don't prevent the remaining profiles of the request
+	// from being ingested, the rejections of all series are returned at the
+	// end.
+	rejected := make([]error, len(series))
+
+	if s.workers == nil {
+		for i, rs := range series {
+			if rejected[i], err = s.writeRawSeries(ctx, tenantID, t, rs, r.Normalized); err != nil {
+				return nil, err
 			}
+		}
+	} else {
+		// The series are ingested concurrently, the profiles of a series
+		// in order. The first error cancels the remaining profiles.
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
 
-			if s.debugValueLog {
-				dir := fmt.Sprintf("tmp/%s", base64.URLEncoding.EncodeToString([]byte(ls.String())))
-				err := os.MkdirAll(dir, os.ModePerm)
-				if err != nil {
-					level.Error(s.logger).Log("msg", "failed to create debug-value-log directory", "err", err)
-				} else {
-					err := ioutil.WriteFile(fmt.Sprintf("%s/%d.pb.gz", dir, timestamp.FromTime(time.Now())), sample.RawProfile, 0o644)
-					if err != nil {
-						level.Error(s.logger).Log("msg", "failed to write debug-value-log", "err", err)
-					}
+		var (
+			wg      sync.WaitGroup
+			errOnce sync.Once
+		)
+		for i := range series {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				var seriesErr error
+				rejected[i], seriesErr = s.writeRawSeries(ctx, tenantID, t, series[i], r.Normalized)
+				if seriesErr != nil {
+					errOnce.Do(func() {
+						err = seriesErr
+						cancel()
+					})
 				}
+			}(i)
+		}
+		wg.Wait()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err := rejectedSeriesError(series, rejected); err != nil {
+		return nil, err
+	}
+
+	return &profilestorepb.WriteRawResponse{}, nil
+}
+
+type rawSeries struct {
+	name    string
+	labels  labels.Labels
+	samples []*profilestorepb.RawSample
+}
+
+// writeRawSeries ingests the profiles of a series in order. It returns the
+// first rejection of a profile, and an error if a profile could not be
+// ingested at all.
+func (s *ProfileColumnStore) writeRawSeries(ctx context.Context, tenantID string, t *tenantStore, rs rawSeries, normalized bool) (rejected, err error) {
+	for _, sample := range rs.samples {
+		if s.workers != nil {
+			select {
+			case s.workers <- struct{}{}:
+			case <-ctx.Done():
+				return rejected, status.FromContextError(ctx.Err()).Err()
 			}
+		}
+		err := s.writeRawProfile(ctx, tenantID, t, rs, sample.RawProfile, normalized)
+		if s.workers != nil {
+			<-s.workers
+		}
+		if rejectedErr := s.reject(tenantID, rs.name, err); rejectedErr != nil {
+			if rejected == nil {
+				rejected = rejectedErr
+			}
+			continue
+		}
+		if err != nil {
+			return rejected, err
+		}
+	}
+	return rejected, nil
+}
 
-			if err := p.CheckValid(); err != nil {
-				return nil, status.Errorf(codes.InvalidArgument, "invalid profile: %v", err)
+// reject records the rejection of a profile of the given name. It returns
+// nil if the error is not a rejection.
+func (s *ProfileColumnStore) reject(tenantID, name string, err error) error {
+	rejectedErr := rejectedProfileError(err)
+	if rejectedErr == nil {
+		return nil
+	}
+	level.Debug(s.logger).Log("msg", "rejected profile", "tenant", tenantID, "err", err)
+	s.metrics.profilesRejected.WithLabelValues(tenantID, rejection.Reason(rejectedErr)).Inc()
+	s.metrics.writeError(name, rejection.Reason(rejectedErr))
+	return rejectedErr
+}
+
+func (s *ProfileColumnStore) writeRawProfile(ctx context.Context, tenantID string, t *tenantStore, rs rawSeries, raw []byte, normalized bool) error {
+	name, ls := rs.name, rs.labels
+
+	s.metrics.receivedBytes.WithLabelValues(tenantID).Add(float64(len(raw)))
+	s.metrics.profileSize.WithLabelValues(name).Observe(float64(len(raw)))
+
+	// Rate limits are enforced before parsing, so that exceeding them is
+	// cheap.
+	if err := s.limiter.allowRate(tenantID, ls, len(raw)); err != nil {
+		return err
+	}
+
+	start := time.Now()
+	p, err := profile.Parse(bytes.NewBuffer(raw))
+	if err != nil {
+		s.metrics.writeError(name, errorParse)
+		return status.Errorf(codes.InvalidArgument, "failed to parse profile: %v", err)
+	}
+	s.ingestMetrics.ObserveStage(name, "", parcacol.StageParse, start)
+
+	// The debug value log has no notion of tenants, so it only holds the
+	// profiles of the default tenant.
+	if s.debugValueLog && tenantID == tenant.Default {
+		dir := fmt.Sprintf("tmp/%s", base64.URLEncoding.EncodeToString([]byte(ls.String())))
+		err := os.MkdirAll(dir, os.ModePerm)
+		if err != nil {
+			level.Error(s.logger).Log("msg", "failed to create debug-value-log directory", "err", err)
+		} else {
+			err := ioutil.WriteFile(fmt.Sprintf("%s/%d.pb.gz", dir, timestamp.FromTime(time.Now())), raw, 0o644)
+			if err != nil {
+				level.Error(s.logger).Log("msg", "failed to write debug-value-log", "err", err)
 			}
+		}
+	}
+
+	if err := p.CheckValid(); err != nil {
+		s.metrics.writeError(name, errorInvalidProfile)
+		return status.Errorf(codes.InvalidArgument, "invalid profile: %v", err)
+	}
+
+	if err := s.limiter.allowProfile(tenantID, ls, len(p.Sample)); err != nil {
+		return err
+	}
+
+	if err := t.ingester.Ingest(ctx, ls, p, normalized); err != nil {
+		if rejectedProfileError(err) != nil {
+			return err
+		}
+		s.metrics.writeError(name, errorInternal)
+		return status.Errorf(codes.Internal, "failed to ingest profile: %v", err)
+	}
+
+	s.metrics.profilesIngested.WithLabelValues(tenantID).Inc()
+
+	// Only ingested profiles are archived, so that rejected duplicates don't
+	// overwrite the original profile.
+	if s.rawProfiles != nil {
+		if err := s.rawProfiles.Put(ctx, tenantID, ls, p.TimeNanos/time.Millisecond.Nanoseconds(), raw); err != nil {
+			level.Error(s.logger).Log("msg", "failed to archive raw profile", "err", err)
+		}
+	}
+	return nil
+}
+
+// WriteArrow writes samples that are already in columnar form. Replicas are
+// not deduplicated, agents sending columnar data are expected to be the only
+// writer of their series.
+func (s *ProfileColumnStore) WriteArrow(ctx context.Context, r *profilestorepb.WriteArrowRequest) (*profilestorepb.WriteArrowResponse, error) {
+	ctx, span := s.tracer.Start(ctx, "write-arrow")
+	defer span.End()
 
-			if err := ingester.Ingest(ctx, ls, p, r.Normalized); err != nil {
-				return nil, status.Errorf(codes.Internal, "failed to ingest profile: %v", err)
+	tenantID, t, err := s.requestTenant(ctx)
+	if err != nil {
+		return nil, err
+	}
+	s.metrics.receivedBytes.WithLabelValues(tenantID).Add(float64(len(r.Record)))
+
+	locations, err := arrowLocations(r)
+	if err != nil {
+		s.metrics.writeError("", errorInvalidRecord)
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	reader, err := ipc.NewReader(bytes.NewReader(r.Record))
+	if err != nil {
+		s.metrics.writeError("", errorInvalidRecord)
+		return nil, status.Errorf(codes.InvalidArgument, "failed to read arrow record: %v", err)
+	}
+	defer reader.Release()
+
+	for _, f := range reader.Schema().Fields() {
+		if !strings.HasPrefix(f.Name, parcacol.ColumnLabels+".") {
+			continue
+		}
+		if name := strings.TrimPrefix(f.Name, parcacol.ColumnLabels+"."); !model.LabelName(name).IsValid() {
+			s.metrics.writeError("", errorInvalidLabelName)
+			return nil, status.Errorf(codes.InvalidArgument, "invalid label name: %v", name)
+		}
+	}
+
+	// Just like for WriteRaw, rejected samples don't prevent the remaining
+	// samples from being ingested.
+	var rejected error
+
+	for reader.Next() {
+		if err := t.ingester.IngestArrow(ctx, reader.Record(), locations, r.Normalized); err != nil {
+			if errors.Is(err, parcacol.ErrInvalidArrowRecord) {
+				s.metrics.writeError("", errorInvalidRecord)
+				return nil, status.Error(codes.InvalidArgument, err.Error())
+			}
+			if rejectedErr := rejectedProfileError(err); rejectedErr != nil {
+				level.Debug(s.logger).Log("msg", "rejected samples", "tenant", tenantID, "err", err)
+				s.metrics.profilesRejected.WithLabelValues(tenantID, rejection.Reason(rejectedErr)).Inc()
+				s.metrics.writeError("", rejection.Reason(rejectedErr))
+				if rejected == nil {
+					rejected = rejectedErr
+				}
+				continue
 			}
+			s.metrics.writeError("", errorInternal)
+			return nil, status.Errorf(codes.Internal, "failed to ingest arrow record: %v", err)
 		}
 	}
+	if err := reader.Err(); err != nil {
+		s.metrics.writeError("", errorInvalidRecord)
+		return nil, status.Errorf(codes.InvalidArgument, "failed to read arrow record: %v", err)
+	}
 
-	return &profilestorepb.WriteRawResponse{}, nil
+	if rejected != nil {
+		return nil, rejected
+	}
+
+	return &profilestorepb.WriteArrowResponse{}, nil
+}
+
+// arrowLocations resolves the location, function and mapping dictionaries of
+// the request to the locations referenced by the stacktrace column.
+func arrowLocations(r *profilestorepb.WriteArrowRequest) (map[uint64]*profile.Location, error) {
+	functions := make(map[uint64]*profile.Function, len(r.Functions))
+	for _, f := range r.Functions {
+		functions[f.Id] = &profile.Function{
+			ID:         f.Id,
+			Name:       f.Name,
+			SystemName: f.SystemName,
+			Filename:   f.Filename,
+			StartLine:  f.StartLine,
+		}
+	}
+
+	mappings := make(map[uint64]*profile.Mapping, len(r.Mappings))
+	for _, m := range r.Mappings {
+		mappings[m.Id] = &profile.Mapping{
+			ID:      m.Id,
+			Start:   m.Start,
+			Limit:   m.Limit,
+			Offset:  m.Offset,
+			File:    m.File,
+			BuildID: m.BuildId,
+		}
+	}
+
+	locations := make(map[uint64]*profile.Location, len(r.Locations))
+	for _, l := range r.Locations {
+		loc := &profile.Location{
+			ID:       l.Id,
+			Address:  l.Address,
+			IsFolded: l.IsFolded,
+			Line:     make([]profile.Line, 0, len(l.Lines)),
+		}
+		if l.MappingId != 0 {
+			m, ok := mappings[l.MappingId]
+			if !ok {
+				return nil, fmt.Errorf("location %d references unknown mapping %d", l.Id, l.MappingId)
+			}
+			loc.Mapping = m
+		}
+		for _, ln := range l.Lines {
+			f, ok := functions[ln.FunctionId]
+			if !ok {
+				return nil, fmt.Errorf("location %d references unknown function %d", l.Id, ln.FunctionId)
+			}
+			loc.Line = append(loc.Line, profile.Line{Function: f, Line: ln.Line})
+		}
+		locations[l.Id] = loc
+	}
+
+	return locations, nil
 }
@@ -14,20 +14,40 @@
 package profilestore
 
 import (
+	"bytes"
 	"context"
+	"fmt"
+	"io/ioutil"
+	"sort"
+	"strconv"
+	"sync/atomic"
 	"testing"
+	"time"
 
+	"github.com/apache/arrow/go/v8/arrow"
+	"github.com/apache/arrow/go/v8/arrow/array"
+	"github.com/apache/arrow/go/v8/arrow/ipc"
+	"github.com/apache/arrow/go/v8/arrow/memory"
 	"github.com/go-kit/log"
+	"github.com/google/pprof/profile"
 	"github.com/polarsignals/arcticdb"
 	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/prometheus/model/labels"
 	"github.com/stretchr/testify/require"
+	"github.com/thanos-io/objstore"
 	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 
 	profilestorepb "github.com/parca-dev/parca/gen/proto/go/parca/profilestore/v1alpha1"
 	"github.com/parca-dev/parca/pkg/metastore"
 	"github.com/parca-dev/parca/pkg/parcacol"
+	"github.com/parca-dev/parca/pkg/rawprofile"
+	"github.com/parca-dev/parca/pkg/rejection"
+	"github.com/parca-dev/parca/pkg/sourcepath"
+	"github.com/parca-dev/parca/pkg/tenant"
 )
 
 func Test_LabelName_Invalid(t *testing.T) {
@@ -42,14 +62,6 @@ func Test_LabelName_Invalid(t *testing.T) {
 	)
 	colDB, err := col.DB("parca")
 	require.NoError(t, err)
-	table, err := colDB.Table(
-		"stacktraces",
-		arcticdb.NewTableConfig(
-			parcacol.Schema(),
-		),
-		logger,
-	)
-	require.NoError(t, err)
 	m := metastore.NewBadgerMetastore(
 		logger,
 		reg,
@@ -62,9 +74,10 @@ func Test_LabelName_Invalid(t *testing.T) {
 
 	api := NewProfileColumnStore(
 		logger,
+		reg,
 		tracer,
 		m,
-		table,
+		parcacol.NewTables(logger, colDB, "stacktraces"),
 		false,
 	)
 
@@ -84,3 +97,1046 @@ func Test_LabelName_Invalid(t *testing.T) {
 
 	require.Equal(t, st.Code(), codes.InvalidArgument)
 }
+
+func TestReplicaDeduplicator(t *testing.T) {
+	now := time.Unix(0, 0)
+	d := newReplicaDeduplicator("__replica__", 30*time.Second)
+	d.now = func() time.Time { return now }
+
+	series := labels.FromStrings("__name__", "cpu", "job", "parca")
+	replicaA := labels.FromStrings("__name__", "cpu", "job", "parca", "__replica__", "a")
+	replicaB := labels.FromStrings("__name__", "cpu", "job", "parca", "__replica__", "b")
+
+	// The first replica to send a profile is elected.
+	ls, accept := d.accept(replicaA)
+	require.True(t, accept)
+	require.Equal(t, series, ls)
+
+	ls, accept = d.accept(replicaB)
+	require.False(t, accept)
+	require.Equal(t, series, ls)
+
+	now = now.Add(20 * time.Second)
+	_, accept = d.accept(replicaA)
+	require.True(t, accept)
+
+	// Replica a stops sending, so b takes over after the failover timeout.
+	now = now.Add(20 * time.Second)
+	_, accept = d.accept(replicaB)
+	require.False(t, accept)
+
+	now = now.Add(20 * time.Second)
+	_, accept = d.accept(replicaB)
+	require.True(t, accept)
+
+	_, accept = d.accept(replicaA)
+	require.False(t, accept)
+
+	// Series without the replica label are passed through.
+	ls, accept = d.accept(series)
+	require.True(t, accept)
+	require.Equal(t, series, ls)
+}
+
+func TestIngestLimiter(t *testing.T) {
+	now := time.Unix(0, 0)
+	l, err := NewIngestLimiter(prometheus.NewRegistry(), IngestLimits{
+		ProfilesPerSecond: 1,
+		ProfilesBurst:     2,
+		BytesPerSecond:    100,
+		MaxActiveSeries:   2,
+	}, &IngestLimitsConfig{
+		Tenants: map[string]IngestLimits{"team-a": {ProfilesPerSecond: 10}},
+		Labels: []*LabelIngestLimits{{
+			Selector:     `{job="noisy"}`,
+			IngestLimits: IngestLimits{MaxSamplesPerProfile: 1},
+		}},
+	}, time.Minute)
+	require.NoError(t, err)
+	l.now = func() time.Time { return now }
+
+	parca := labels.FromStrings("__name__", "cpu", "job", "parca")
+	noisy := labels.FromStrings("__name__", "cpu", "job", "noisy")
+
+	// The burst allows two profiles at once, after which the bucket is
+	// refilled at one profile per second.
+	require.NoError(t, l.allowRate(tenant.Default, parca, 10))
+	require.NoError(t, l.allowRate(tenant.Default, parca, 10))
+	require.ErrorIs(t, l.allowRate(tenant.Default, parca, 10), ErrRateLimit)
+	now = now.Add(time.Second)
+	require.NoError(t, l.allowRate(tenant.Default, parca, 10))
+
+	// Profiles exceeding the remaining bytes don't take a profile token.
+	require.ErrorIs(t, l.allowRate(tenant.Default, noisy, 101), ErrRateLimit)
+	require.NoError(t, l.allowRate(tenant.Default, noisy, 100))
+
+	// Tenants and label selectors have their own limits.
+	for i := 0; i < 10; i++ {
+		require.NoError(t, l.allowRate("team-a", parca, 10))
+	}
+	require.ErrorIs(t, l.allowRate("team-a", parca, 10), ErrRateLimit)
+	require.NoError(t, l.allowProfile(tenant.Default, parca, 2))
+	require.ErrorIs(t, l.allowProfile(tenant.Default, noisy, 2), ErrSamplesLimit)
+
+	// Series are no longer active after the timeout.
+	require.NoError(t, l.allowProfile(tenant.Default, labels.FromStrings("__name__", "memory", "job", "parca"), 1))
+	require.ErrorIs(t, l.allowProfile(tenant.Default, labels.FromStrings("__name__", "block", "job", "parca"), 1), ErrSeriesLimit)
+	now = now.Add(time.Minute)
+	require.NoError(t, l.allowProfile(tenant.Default, parca, 1))
+	now = now.Add(time.Second)
+	require.NoError(t, l.allowProfile(tenant.Default, labels.FromStrings("__name__", "block", "job", "parca"), 1))
+
+	usage := l.Usage()
+	require.Len(t, usage, 3)
+	require.Equal(t, "", usage[0].Tenant)
+	require.Equal(t, "", usage[0].Selector)
+	require.Equal(t, int64(2), usage[0].ActiveSeries)
+	require.Equal(t, map[string]int64{rejection.RateLimit: 1, rejection.SeriesLimit: 1}, usage[0].RejectedProfiles)
+	require.Equal(t, `{job="noisy"}`, usage[1].Selector)
+	require.Equal(t, int64(1), usage[1].Limits.MaxSamplesPerProfile)
+	require.Equal(t, float64(1), usage[1].Limits.ProfilesPerSecond)
+	require.Equal(t, "team-a", usage[2].Tenant)
+	require.Equal(t, float64(10), usage[2].Limits.ProfilesPerSecond)
+
+	// The limits of series that didn't receive profiles within the timeout
+	// are forgotten once their token buckets are full again.
+	now = now.Add(2 * time.Minute)
+	require.NoError(t, l.allowProfile(tenant.Default, parca, 1))
+	usage = l.Usage()
+	require.Len(t, usage, 1)
+	require.Equal(t, "", usage[0].Tenant)
+	require.Equal(t, int64(1), usage[0].ActiveSeries)
+	require.Empty(t, usage[0].RejectedProfiles)
+
+	// Unless the token buckets are still refilling.
+	slow, err := NewIngestLimiter(prometheus.NewRegistry(), IngestLimits{ProfilesPerSecond: 0.001}, nil, time.Minute)
+	require.NoError(t, err)
+	slow.now = func() time.Time { return now }
+	require.NoError(t, slow.allowRate("team-a", parca, 10))
+	now = now.Add(2 * time.Minute)
+	require.NoError(t, slow.allowProfile(tenant.Default, parca, 1))
+	require.Len(t, slow.Usage(), 2)
+}
+
+func newTestProfileColumnStore(t testing.TB, opts ...Option) *ProfileColumnStore {
+	t.Helper()
+	return newTestProfileColumnStoreWithRegistry(t, prometheus.NewRegistry(), opts...)
+}
+
+func newTestProfileColumnStoreWithRegistry(t testing.TB, reg *prometheus.Registry, opts ...Option) *ProfileColumnStore {
+	t.Helper()
+
+	logger := log.NewNopLogger()
+	tracer := trace.NewNoopTracerProvider().Tracer("")
+	col := arcticdb.New(
+		reg,
+		8196,
+		64*1024*1024,
+	)
+	colDB, err := col.DB("parca")
+	require.NoError(t, err)
+	m := metastore.NewBadgerMetastore(
+		logger,
+		reg,
+		tracer,
+		metastore.NewRandomUUIDGenerator(),
+	)
+	t.Cleanup(func() {
+		m.Close()
+	})
+
+	return NewProfileColumnStore(
+		logger,
+		reg,
+		tracer,
+		m,
+		parcacol.NewTables(logger, colDB, "stacktraces", parcacol.WithTenants("team-a", "admin")),
+		false,
+		opts...,
+	)
+}
+
+func writeRawRequest(t testing.TB, ls ...string) *profilestorepb.WriteRawRequest {
+	t.Helper()
+
+	b, err := ioutil.ReadFile("../query/testdata/alloc_objects.pb.gz")
+	require.NoError(t, err)
+
+	lset := &profilestorepb.LabelSet{}
+	for i := 0; i < len(ls); i += 2 {
+		lset.Labels = append(lset.Labels, &profilestorepb.Label{Name: ls[i], Value: ls[i+1]})
+	}
+
+	return &profilestorepb.WriteRawRequest{
+		Series: []*profilestorepb.RawProfileSeries{{
+			Labels:  lset,
+			Samples: []*profilestorepb.RawSample{{RawProfile: b}},
+		}},
+	}
+}
+
+func TestWriteRawDuplicate(t *testing.T) {
+	ctx := context.Background()
+	api := newTestProfileColumnStore(t)
+
+	_, err := api.WriteRaw(ctx, writeRawRequest(t, "__name__", "memory", "job", "parca"))
+	require.NoError(t, err)
+
+	_, err = api.WriteRaw(ctx, writeRawRequest(t, "__name__", "memory", "job", "parca"))
+	require.Equal(t, codes.AlreadyExists, status.Code(err))
+	require.Equal(t, rejection.DuplicateSample, rejection.Reason(err))
+
+	// The same profile of another series is not a duplicate.
+	_, err = api.WriteRaw(ctx, writeRawRequest(t, "__name__", "memory", "job", "other"))
+	require.NoError(t, err)
+
+	// Every rejected series of a request is reported, the others are
+	// ingested.
+	r := writeRawRequest(t, "__name__", "memory", "job", "parca")
+	r.Series = append(r.Series,
+		writeRawRequest(t, "__name__", "memory", "job", "new").Series[0],
+		writeRawRequest(t, "__name__", "memory", "job", "other").Series[0],
+	)
+	_, err = api.WriteRaw(ctx, r)
+	require.Equal(t, codes.AlreadyExists, status.Code(err))
+
+	var rejected []string
+	for _, d := range status.Convert(err).Details() {
+		info, ok := d.(*errdetails.ErrorInfo)
+		require.True(t, ok)
+		require.Equal(t, rejection.DuplicateSample, info.Reason)
+		rejected = append(rejected, info.Metadata["series"])
+	}
+	require.Equal(t, []string{
+		`{__name__="memory", job="parca"}`,
+		`{__name__="memory", job="other"}`,
+	}, rejected)
+
+	_, err = api.WriteRaw(ctx, writeRawRequest(t, "__name__", "memory", "job", "new"))
+	require.Equal(t, codes.AlreadyExists, status.Code(err))
+}
+
+func TestWriteRawOutOfBounds(t *testing.T) {
+	ctx := context.Background()
+	api := newTestProfileColumnStore(t, WithIngesterOptions(
+		parcacol.WithTimestampBounds(time.Hour, time.Hour),
+	))
+
+	// The test profile was taken long before now.
+	_, err := api.WriteRaw(ctx, writeRawRequest(t, "__name__", "memory", "job", "parca"))
+	require.Equal(t, codes.OutOfRange, status.Code(err))
+	require.Equal(t, rejection.OutOfBounds, rejection.Reason(err))
+}
+
+// writeArrowRequest converts the test profile to a WriteArrowRequest with one
+// row per sample and sample type.
+func writeArrowRequest(t testing.TB, timestamp int64, ls ...string) *profilestorepb.WriteArrowRequest {
+	t.Helper()
+
+	f, err := ioutil.ReadFile("../query/testdata/alloc_objects.pb.gz")
+	require.NoError(t, err)
+	p, err := profile.Parse(bytes.NewBuffer(f))
+	require.NoError(t, err)
+
+	var name string
+	lset := map[string]string{}
+	for i := 0; i < len(ls); i += 2 {
+		if ls[i] == labels.MetricName {
+			name = ls[i+1]
+			continue
+		}
+		lset[ls[i]] = ls[i+1]
+	}
+
+	numLabels := map[string]struct{}{}
+	for _, s := range p.Sample {
+		for k := range s.NumLabel {
+			numLabels[k] = struct{}{}
+		}
+	}
+
+	fields := []arrow.Field{
+		{Name: parcacol.ColumnDuration, Type: arrow.PrimitiveTypes.Int64},
+		{Name: parcacol.ColumnName, Type: arrow.BinaryTypes.String},
+		{Name: parcacol.ColumnPeriod, Type: arrow.PrimitiveTypes.Int64},
+		{Name: parcacol.ColumnPeriodType, Type: arrow.BinaryTypes.String},
+		{Name: parcacol.ColumnPeriodUnit, Type: arrow.BinaryTypes.String},
+		{Name: parcacol.ColumnSampleType, Type: arrow.BinaryTypes.String},
+		{Name: parcacol.ColumnSampleUnit, Type: arrow.BinaryTypes.String},
+		{Name: parcacol.ColumnStacktrace, Type: arrow.ListOf(arrow.PrimitiveTypes.Uint64)},
+		{Name: parcacol.ColumnTimestamp, Type: arrow.PrimitiveTypes.Int64},
+		{Name: parcacol.ColumnValue, Type: arrow.PrimitiveTypes.Int64},
+	}
+	labelNames := make([]string, 0, len(lset))
+	for k := range lset {
+		labelNames = append(labelNames, k)
+	}
+	sort.Strings(labelNames)
+	for _, k := range labelNames {
+		fields = append(fields, arrow.Field{Name: parcacol.ColumnLabels + "." + k, Type: arrow.BinaryTypes.String, Nullable: true})
+	}
+	numLabelNames := make([]string, 0, len(numLabels))
+	for k := range numLabels {
+		numLabelNames = append(numLabelNames, k)
+	}
+	sort.Strings(numLabelNames)
+	for _, k := range numLabelNames {
+		fields = append(fields, arrow.Field{Name: parcacol.ColumnPprofNumLabels + "." + k, Type: arrow.PrimitiveTypes.Int64, Nullable: true})
+	}
+
+	schema := arrow.NewSchema(fields, nil)
+	b := array.NewRecordBuilder(memory.NewGoAllocator(), schema)
+	defer b.Release()
+
+	for i, st := range p.SampleType {
+		for _, s := range p.Sample {
+			b.Field(0).(*array.Int64Builder).Append(p.DurationNanos)
+			b.Field(1).(*array.StringBuilder).Append(name)
+			b.Field(2).(*array.Int64Builder).Append(p.Period)
+			b.Field(3).(*array.StringBuilder).Append(p.PeriodType.Type)
+			b.Field(4).(*array.StringBuilder).Append(p.PeriodType.Unit)
+			b.Field(5).(*array.StringBuilder).Append(st.Type)
+			b.Field(6).(*array.StringBuilder).Append(st.Unit)
+
+			stacktrace := b.Field(7).(*array.ListBuilder)
+			stacktrace.Append(true)
+			for _, l := range s.Location {
+				stacktrace.ValueBuilder().(*array.Uint64Builder).Append(l.ID)
+			}
+
+			b.Field(8).(*array.Int64Builder).Append(timestamp)
+			b.Field(9).(*array.Int64Builder).Append(s.Value[i])
+
+			for j, k := range labelNames {
+				b.Field(10 + j).(*array.StringBuilder).Append(lset[k])
+			}
+			for j, k := range numLabelNames {
+				nb := b.Field(10 + len(labelNames) + j).(*array.Int64Builder)
+				if v := s.NumLabel[k]; len(v) == 1 {
+					nb.Append(v[0])
+				} else {
+					nb.AppendNull()
+				}
+			}
+		}
+	}
+
+	rec := b.NewRecord()
+	defer rec.Release()
+
+	buf := &bytes.Buffer{}
+	w := ipc.NewWriter(buf, ipc.WithSchema(schema))
+	require.NoError(t, w.Write(rec))
+	require.NoError(t, w.Close())
+
+	req := &profilestorepb.WriteArrowRequest{Record: buf.Bytes()}
+	for _, m := range p.Mapping {
+		req.Mappings = append(req.Mappings, &profilestorepb.ArrowMapping{
+			Id:      m.ID,
+			Start:   m.Start,
+			Limit:   m.Limit,
+			Offset:  m.Offset,
+			File:    m.File,
+			BuildId: m.BuildID,
+		})
+	}
+	for _, f := range p.Function {
+		req.Functions = append(req.Functions, &profilestorepb.ArrowFunction{
+			Id:         f.ID,
+			Name:       f.Name,
+			SystemName: f.SystemName,
+			Filename:   f.Filename,
+			StartLine:  f.StartLine,
+		})
+	}
+	for _, l := range p.Location {
+		loc := &profilestorepb.ArrowLocation{
+			Id:       l.ID,
+			Address:  l.Address,
+			IsFolded: l.IsFolded,
+		}
+		if l.Mapping != nil {
+			loc.MappingId = l.Mapping.ID
+		}
+		for _, ln := range l.Line {
+			loc.Lines = append(loc.Lines, &profilestorepb.ArrowLine{
+				FunctionId: ln.Function.ID,
+				Line:       ln.Line,
+			})
+		}
+		req.Locations = append(req.Locations, loc)
+	}
+
+	return req
+}
+
+// sampleTypeTotals returns the sum of the values of every sample type in the
+// table of the tenant.
+func sampleTypeTotals(t *testing.T, s *ProfileColumnStore, tenantID string) map[string]int64 {
+	t.Helper()
+
+	table, err := s.tables.Table(tenantID)
+	require.NoError(t, err)
+
+	totals := map[string]int64{}
+	err = table.Iterator(context.Background(), memory.NewGoAllocator(), nil, nil, nil, func(r arrow.Record) error {
+		var sampleTypes *array.Binary
+		var values *array.Int64
+		for i, f := range r.Schema().Fields() {
+			switch f.Name {
+			case parcacol.ColumnSampleType:
+				sampleTypes = r.Column(i).(*array.Binary)
+			case parcacol.ColumnValue:
+				values = r.Column(i).(*array.Int64)
+			}
+		}
+		for i := 0; i < int(r.NumRows()); i++ {
+			totals[string(sampleTypes.Value(i))] += values.Value(i)
+		}
+		return nil
+	})
+	require.NoError(t, err)
+
+	return totals
+}
+
+func TestWriteArrow(t *testing.T) {
+	ctx := context.Background()
+
+	f, err := ioutil.ReadFile("../query/testdata/alloc_objects.pb.gz")
+	require.NoError(t, err)
+	p, err := profile.Parse(bytes.NewBuffer(f))
+	require.NoError(t, err)
+
+	expected := map[string]int64{}
+	for i, st := range p.SampleType {
+		for _, s := range p.Sample {
+			expected[st.Type] += s.Value[i]
+		}
+	}
+
+	col := newTestProfileColumnStore(t)
+	req := writeArrowRequest(t, 1, "__name__", "memory", "job", "parca")
+	_, err = col.WriteArrow(ctx, req)
+	require.NoError(t, err)
+
+	require.Equal(t, expected, sampleTypeTotals(t, col, tenant.Default))
+
+	_, err = col.WriteArrow(ctx, req)
+	require.Equal(t, codes.AlreadyExists, status.Code(err))
+	require.Equal(t, rejection.DuplicateSample, rejection.Reason(err))
+
+	// Stacktraces referencing locations missing from the request are invalid.
+	req = writeArrowRequest(t, 2, "__name__", "memory", "job", "parca")
+	req.Locations = req.Locations[1:]
+	_, err = col.WriteArrow(ctx, req)
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	// The corrected request is not a duplicate of the invalid one.
+	req = writeArrowRequest(t, 2, "__name__", "memory", "job", "parca")
+	_, err = col.WriteArrow(ctx, req)
+	require.NoError(t, err)
+	for k, v := range expected {
+		expected[k] = 2 * v
+	}
+	require.Equal(t, expected, sampleTypeTotals(t, col, tenant.Default))
+}
+
+func newTestRedactor(t *testing.T) *parcacol.Redactor {
+	t.Helper()
+
+	paths, err := parcacol.NewRedactionRule(parcacol.RedactFunctionFilename, nil, "/home/[^/]+/", parcacol.RedactReplace, "/home/redacted/", "")
+	require.NoError(t, err)
+	files, err := parcacol.NewRedactionRule(parcacol.RedactMappingFile, nil, "/home/[^/]+/", parcacol.RedactReplace, "/home/redacted/", "")
+	require.NoError(t, err)
+	ls, err := parcacol.NewRedactionRule(parcacol.RedactLabels, []string{"customer"}, ".+", parcacol.RedactHash, "", "salt")
+	require.NoError(t, err)
+	pprofLabels, err := parcacol.NewRedactionRule(parcacol.RedactPprofLabels, []string{"customer_id"}, ".+", parcacol.RedactHash, "", "salt")
+	require.NoError(t, err)
+
+	return parcacol.NewRedactor(paths, files, ls, pprofLabels)
+}
+
+// requireNotPersisted fails if any of the strings appears in the metastore or
+// the table of the store.
+func requireNotPersisted(t *testing.T, s *ProfileColumnStore, forbidden ...string) {
+	t.Helper()
+
+	ctx := context.Background()
+	check := func(v string) {
+		for _, f := range forbidden {
+			require.NotContains(t, v, f)
+		}
+	}
+
+	functions, err := s.metaStore.GetFunctions(ctx)
+	require.NoError(t, err)
+	require.NotEmpty(t, functions)
+	for _, f := range functions {
+		check(f.Name)
+		check(f.SystemName)
+		check(f.Filename)
+	}
+
+	locations, _, err := s.metaStore.GetLocations(ctx)
+	require.NoError(t, err)
+	mappingIDs := [][]byte{}
+	for _, l := range locations {
+		if len(l.MappingId) > 0 {
+			mappingIDs = append(mappingIDs, l.MappingId)
+		}
+	}
+	mappings, err := s.metaStore.GetMappingsByIDs(ctx, mappingIDs...)
+	require.NoError(t, err)
+	require.NotEmpty(t, mappings)
+	for _, m := range mappings {
+		check(m.File)
+	}
+
+	table, err := s.tables.Table(tenant.Default)
+	require.NoError(t, err)
+
+	stacktraceIDs := [][]byte{}
+	err = table.Iterator(ctx, memory.NewGoAllocator(), nil, nil, nil, func(r arrow.Record) error {
+		for i, f := range r.Schema().Fields() {
+			col, ok := r.Column(i).(*array.Binary)
+			if !ok {
+				continue
+			}
+			for j := 0; j < col.Len(); j++ {
+				if f.Name == parcacol.ColumnStacktrace {
+					stacktraceIDs = append(stacktraceIDs, append([]byte{}, col.Value(j)...))
+					continue
+				}
+				check(string(col.Value(j)))
+			}
+		}
+		return nil
+	})
+	require.NoError(t, err)
+
+	stacktraces, err := s.metaStore.GetStacktraceByIDs(ctx, stacktraceIDs...)
+	require.NoError(t, err)
+	require.NotEmpty(t, stacktraces)
+	for _, st := range stacktraces {
+		for _, l := range st.Labels {
+			for _, v := range l.Labels {
+				check(v)
+			}
+		}
+	}
+}
+
+func TestWriteRawRedaction(t *testing.T) {
+	ctx := context.Background()
+
+	f, err := ioutil.ReadFile("../query/testdata/alloc_objects.pb.gz")
+	require.NoError(t, err)
+	p, err := profile.Parse(bytes.NewBuffer(f))
+	require.NoError(t, err)
+	for _, fn := range p.Function {
+		fn.Filename = "/home/alice/src/" + fn.Filename
+	}
+	for _, m := range p.Mapping {
+		m.File = "/home/alice/bin/app"
+	}
+	for _, s := range p.Sample {
+		s.Label = map[string][]string{"customer_id": {"acme-42"}}
+	}
+	buf := bytes.NewBuffer(nil)
+	require.NoError(t, p.Write(buf))
+
+	req := writeRawRequest(t, "__name__", "memory", "job", "parca", "customer", "acme-42")
+	req.Series[0].Samples[0].RawProfile = buf.Bytes()
+
+	s := newTestProfileColumnStore(t, WithIngesterOptions(parcacol.WithRedactor(newTestRedactor(t))))
+	_, err = s.WriteRaw(ctx, req)
+	require.NoError(t, err)
+
+	requireNotPersisted(t, s, "alice", "acme-42")
+}
+
+func TestWriteArrowRedaction(t *testing.T) {
+	ctx := context.Background()
+
+	req := writeArrowRequest(t, 1, "__name__", "memory", "job", "parca", "customer", "acme-42")
+	for _, fn := range req.Functions {
+		fn.Filename = "/home/alice/src/" + fn.Filename
+	}
+	for _, m := range req.Mappings {
+		m.File = "/home/alice/bin/app"
+	}
+
+	s := newTestProfileColumnStore(t, WithIngesterOptions(parcacol.WithRedactor(newTestRedactor(t))))
+	_, err := s.WriteArrow(ctx, req)
+	require.NoError(t, err)
+
+	requireNotPersisted(t, s, "alice", "acme-42")
+}
+
+func TestWriteRawSourcePathMapping(t *testing.T) {
+	ctx := context.Background()
+
+	gomod, err := sourcepath.NewRegexRule(`^.*/pkg/mod/([^@]+)@[^/]+/`, "$1/")
+	require.NoError(t, err)
+
+	s := newTestProfileColumnStore(t, WithIngesterOptions(parcacol.WithSourcePathMapper(sourcepath.NewMapper(gomod))))
+	_, err = s.WriteRaw(ctx, writeRawRequest(t, "__name__", "memory", "job", "parca"))
+	require.NoError(t, err)
+
+	functions, err := s.metaStore.GetFunctions(ctx)
+	require.NoError(t, err)
+
+	filenames := map[string]struct{}{}
+	for _, f := range functions {
+		require.NotContains(t, f.Filename, "/pkg/mod/")
+		filenames[f.Filename] = struct{}{}
+	}
+	require.Contains(t, filenames, "github.com/go-kit/kit/log/value.go")
+}
+
+func TestWriteRawArchive(t *testing.T) {
+	ctx := context.Background()
+
+	archive := rawprofile.NewArchive(objstore.NewInMemBucket())
+	s := newTestProfileColumnStore(t, WithRawProfiles(archive))
+
+	req := writeRawRequest(t, "__name__", "memory", "job", "parca")
+	_, err := s.WriteRaw(ctx, req)
+	require.NoError(t, err)
+
+	p, err := profile.Parse(bytes.NewBuffer(req.Series[0].Samples[0].RawProfile))
+	require.NoError(t, err)
+
+	ls := labels.FromStrings("__name__", "memory", "job", "parca")
+	b, err := archive.Get(ctx, tenant.Default, ls, p.TimeNanos/time.Millisecond.Nanoseconds())
+	require.NoError(t, err)
+	require.Equal(t, req.Series[0].Samples[0].RawProfile, b)
+
+	// Rejected profiles don't overwrite the archived one.
+	dup := writeRawRequest(t, "__name__", "memory", "job", "parca")
+	p.Comments = []string{"duplicate"}
+	buf := bytes.NewBuffer(nil)
+	require.NoError(t, p.Write(buf))
+	dup.Series[0].Samples[0].RawProfile = buf.Bytes()
+	_, err = s.WriteRaw(ctx, dup)
+	require.Equal(t, rejection.DuplicateSample, rejection.Reason(err))
+
+	b, err = archive.Get(ctx, tenant.Default, ls, p.TimeNanos/time.Millisecond.Nanoseconds())
+	require.NoError(t, err)
+	require.Equal(t, req.Series[0].Samples[0].RawProfile, b)
+}
+
+func TestWriteRawCardinalityLimit(t *testing.T) {
+	ctx := context.Background()
+
+	limiter := parcacol.NewCardinalityLimiter(prometheus.NewRegistry(), parcacol.CardinalityLimits{
+		MaxLabelValuesPerProfileType: 1,
+	})
+	s := newTestProfileColumnStore(t, WithIngesterOptions(parcacol.WithCardinalityLimiter(limiter)))
+
+	_, err := s.WriteRaw(ctx, writeRawRequest(t, "__name__", "memory", "job", "parca"))
+	require.NoError(t, err)
+
+	_, err = s.WriteRaw(ctx, writeRawRequest(t, "__name__", "memory", "job", "other"))
+	require.Equal(t, codes.ResourceExhausted, status.Code(err))
+	require.Equal(t, rejection.CardinalityLimit, rejection.Reason(err))
+}
+
+func TestWriteRawCardinalityLimitStrip(t *testing.T) {
+	ctx := context.Background()
+
+	f, err := ioutil.ReadFile("../query/testdata/alloc_objects.pb.gz")
+	require.NoError(t, err)
+	p, err := profile.Parse(bytes.NewBuffer(f))
+	require.NoError(t, err)
+	for i, s := range p.Sample {
+		s.Label = map[string][]string{"request_id": {fmt.Sprintf("request-b%d", i)}}
+	}
+	p.Sample[0].Label["request_id"] = []string{"request-a"}
+	buf := bytes.NewBuffer(nil)
+	require.NoError(t, p.Write(buf))
+
+	req := writeRawRequest(t, "__name__", "memory", "job", "parca")
+	req.Series[0].Samples[0].RawProfile = buf.Bytes()
+
+	limiter := parcacol.NewCardinalityLimiter(prometheus.NewRegistry(), parcacol.CardinalityLimits{
+		MaxPprofLabelValuesPerSeries: 1,
+		Strip:                        true,
+	})
+	s := newTestProfileColumnStore(t, WithIngesterOptions(parcacol.WithCardinalityLimiter(limiter)))
+	_, err = s.WriteRaw(ctx, req)
+	require.NoError(t, err)
+
+	// Only the first request ID fits the limit, the samples are written
+	// without the others.
+	requireNotPersisted(t, s, "request-b")
+	require.Equal(t, []string{
+		"profile type memory exceeded the pprof_label_values_per_series limit, labels were stripped",
+	}, limiter.Warnings(tenant.Default))
+}
+
+func TestWriteArrowCardinalityLimit(t *testing.T) {
+	ctx := context.Background()
+
+	limiter := parcacol.NewCardinalityLimiter(prometheus.NewRegistry(), parcacol.CardinalityLimits{
+		MaxLabelValuesPerProfileType: 1,
+	})
+	s := newTestProfileColumnStore(t, WithIngesterOptions(parcacol.WithCardinalityLimiter(limiter)))
+
+	_, err := s.WriteArrow(ctx, writeArrowRequest(t, 1, "__name__", "memory", "job", "parca"))
+	require.NoError(t, err)
+
+	_, err = s.WriteArrow(ctx, writeArrowRequest(t, 2, "__name__", "memory", "job", "other-job"))
+	require.Equal(t, codes.ResourceExhausted, status.Code(err))
+	require.Equal(t, rejection.CardinalityLimit, rejection.Reason(err))
+	requireNotPersisted(t, s, "other-job")
+}
+
+func TestWriteArrowCardinalityLimitStrip(t *testing.T) {
+	ctx := context.Background()
+
+	limiter := parcacol.NewCardinalityLimiter(prometheus.NewRegistry(), parcacol.CardinalityLimits{
+		MaxLabelValuesPerProfileType: 1,
+		Strip:                        true,
+	})
+	s := newTestProfileColumnStore(t, WithIngesterOptions(parcacol.WithCardinalityLimiter(limiter)))
+
+	_, err := s.WriteArrow(ctx, writeArrowRequest(t, 1, "__name__", "memory", "job", "parca"))
+	require.NoError(t, err)
+	expected := sampleTypeTotals(t, s, tenant.Default)
+
+	// The samples are written without the job label.
+	_, err = s.WriteArrow(ctx, writeArrowRequest(t, 2, "__name__", "memory", "job", "other-job"))
+	require.NoError(t, err)
+	requireNotPersisted(t, s, "other-job")
+
+	for k, v := range expected {
+		expected[k] = 2 * v
+	}
+	require.Equal(t, expected, sampleTypeTotals(t, s, tenant.Default))
+}
+
+func TestWriteRawTenants(t *testing.T) {
+	ctx := context.Background()
+	teamA := metadata.NewIncomingContext(ctx, metadata.Pairs(tenant.Header, "team-a"))
+
+	archive := rawprofile.NewArchive(objstore.NewInMemBucket())
+	s := newTestProfileColumnStore(t, WithRawProfiles(archive))
+
+	req := writeRawRequest(t, "__name__", "memory", "job", "parca")
+	_, err := s.WriteRaw(teamA, req)
+	require.NoError(t, err)
+	require.NotEmpty(t, sampleTypeTotals(t, s, "team-a"))
+	require.Empty(t, sampleTypeTotals(t, s, tenant.Default))
+
+	// Duplicates are detected per tenant.
+	_, err = s.WriteRaw(ctx, writeRawRequest(t, "__name__", "memory", "job", "parca"))
+	require.NoError(t, err)
+	require.NotEmpty(t, sampleTypeTotals(t, s, tenant.Default))
+
+	_, err = s.WriteRaw(teamA, writeRawRequest(t, "__name__", "memory", "job", "parca"))
+	require.Equal(t, rejection.DuplicateSample, rejection.Reason(err))
+
+	p, err := profile.Parse(bytes.NewBuffer(req.Series[0].Samples[0].RawProfile))
+	require.NoError(t, err)
+	ls := labels.FromStrings("__name__", "memory", "job", "parca")
+	_, err = archive.Get(ctx, "team-a", ls, p.TimeNanos/time.Millisecond.Nanoseconds())
+	require.NoError(t, err)
+
+	_, err = s.WriteRaw(
+		metadata.NewIncomingContext(ctx, metadata.Pairs(tenant.Header, "../team-a")),
+		writeRawRequest(t, "__name__", "memory", "job", "parca"),
+	)
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	// Only the configured tenants can write profiles.
+	db, err := arcticdb.New(prometheus.NewRegistry(), 8196, 64*1024*1024).DB("parca")
+	require.NoError(t, err)
+	s.tables = parcacol.NewTables(log.NewNopLogger(), db, "stacktraces", parcacol.WithTenants("team-a"))
+	_, err = s.WriteRaw(
+		metadata.NewIncomingContext(ctx, metadata.Pairs(tenant.Header, "team-b")),
+		writeRawRequest(t, "__name__", "memory", "job", "parca"),
+	)
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+}
+
+func TestWriteRawIngestLimits(t *testing.T) {
+	ctx := context.Background()
+	teamA := metadata.NewIncomingContext(ctx, metadata.Pairs(tenant.Header, "team-a"))
+
+	limiter, err := NewIngestLimiter(prometheus.NewRegistry(), IngestLimits{
+		ProfilesPerSecond: 0.001,
+	}, nil, time.Minute)
+	require.NoError(t, err)
+	s := newTestProfileColumnStore(t, WithIngestLimiter(limiter))
+
+	_, err = s.WriteRaw(ctx, writeRawRequest(t, "__name__", "memory", "job", "parca"))
+	require.NoError(t, err)
+
+	_, err = s.WriteRaw(ctx, writeRawRequest(t, "__name__", "memory", "job", "other"))
+	require.Equal(t, codes.ResourceExhausted, status.Code(err))
+	require.Equal(t, rejection.RateLimit, rejection.Reason(err))
+
+	// Other tenants are limited separately.
+	_, err = s.WriteRaw(teamA, writeRawRequest(t, "__name__", "memory", "job", "other"))
+	require.NoError(t, err)
+
+	// Only the admin tenant sees the usage of all tenants.
+	admin := NewAdminServer(limiter, "admin")
+	res, err := admin.Usage(
+		metadata.NewIncomingContext(ctx, metadata.Pairs(tenant.Header, "admin")),
+		&profilestorepb.UsageRequest{},
+	)
+	require.NoError(t, err)
+	require.Len(t, res.Usage, 2)
+	require.Equal(t, map[string]int64{rejection.RateLimit: 1}, res.Usage[0].RejectedProfiles)
+
+	// Other tenants, including the default tenant, only see the usage of
+	// their own limits.
+	res, err = admin.Usage(teamA, &profilestorepb.UsageRequest{})
+	require.NoError(t, err)
+	require.Len(t, res.Usage, 1)
+	require.Equal(t, "team-a", res.Usage[0].Tenant)
+	require.Equal(t, int64(1), res.Usage[0].ActiveSeries)
+	res, err = admin.Usage(ctx, &profilestorepb.UsageRequest{})
+	require.NoError(t, err)
+	require.Len(t, res.Usage, 1)
+	require.Equal(t, tenant.Default, res.Usage[0].Tenant)
+	res, err = NewAdminServer(limiter, "").Usage(ctx, &profilestorepb.UsageRequest{})
+	require.NoError(t, err)
+	require.Len(t, res.Usage, 1)
+
+	_, err = NewAdminServer(nil, "").Usage(ctx, &profilestorepb.UsageRequest{})
+	require.Equal(t, codes.FailedPrecondition, status.Code(err))
+}
+
+func TestWriteArrowIngestLimits(t *testing.T) {
+	ctx := context.Background()
+
+	limiter, err := NewIngestLimiter(prometheus.NewRegistry(), IngestLimits{
+		ProfilesPerSecond: 0.001,
+	}, &IngestLimitsConfig{
+		Labels: []*LabelIngestLimits{{
+			Selector:     `{job="big"}`,
+			IngestLimits: IngestLimits{MaxSamplesPerProfile: 1},
+		}, {
+			Selector:     `{job="large"}`,
+			IngestLimits: IngestLimits{BytesPerSecond: 1024},
+		}},
+	}, time.Minute)
+	require.NoError(t, err)
+	s := newTestProfileColumnStore(t, WithIngestLimiter(limiter))
+
+	_, err = s.WriteArrow(ctx, writeArrowRequest(t, 1, "__name__", "memory", "job", "parca"))
+	require.NoError(t, err)
+	totals := sampleTypeTotals(t, s, tenant.Default)
+	require.NotEmpty(t, totals)
+
+	// The limits apply to the samples of every series and timestamp of the
+	// record, just like to the profiles written via WriteRaw.
+	_, err = s.WriteArrow(ctx, writeArrowRequest(t, 1, "__name__", "memory", "job", "other"))
+	require.Equal(t, codes.ResourceExhausted, status.Code(err))
+	require.Equal(t, rejection.RateLimit, rejection.Reason(err))
+
+	_, err = s.WriteArrow(ctx, writeArrowRequest(t, 1, "__name__", "memory", "job", "big"))
+	require.Equal(t, rejection.SamplesLimit, rejection.Reason(err))
+
+	_, err = s.WriteArrow(ctx, writeArrowRequest(t, 1, "__name__", "memory", "job", "large"))
+	require.Equal(t, rejection.RateLimit, rejection.Reason(err))
+
+	require.Equal(t, totals, sampleTypeTotals(t, s, tenant.Default))
+}
+
+// gatherMetrics returns the sum of the values of the metric with the given
+// labels, counting histograms by their number of observations.
+func gatherMetrics(t *testing.T, reg *prometheus.Registry, name string, ls ...string) float64 {
+	t.Helper()
+
+	mfs, err := reg.Gather()
+	require.NoError(t, err)
+
+	var sum float64
+	for _, mf := range mfs {
+		if mf.GetName() != name {
+			continue
+		}
+	metrics:
+		for _, m := range mf.GetMetric() {
+			for i := 0; i < len(ls); i += 2 {
+				var found bool
+				for _, l := range m.GetLabel() {
+					if l.GetName() == ls[i] && l.GetValue() == ls[i+1] {
+						found = true
+					}
+				}
+				if !found {
+					continue metrics
+				}
+			}
+			switch {
+			case m.Counter != nil:
+				sum += m.Counter.GetValue()
+			case m.Histogram != nil:
+				sum += float64(m.Histogram.GetSampleCount())
+			}
+		}
+	}
+	return sum
+}
+
+func TestWriteRawMetrics(t *testing.T) {
+	ctx := context.Background()
+	reg := prometheus.NewRegistry()
+	s := newTestProfileColumnStoreWithRegistry(t, reg)
+
+	req := writeRawRequest(t, "__name__", "memory", "job", "parca")
+	_, err := s.WriteRaw(ctx, req)
+	require.NoError(t, err)
+
+	p, err := profile.Parse(bytes.NewBuffer(req.Series[0].Samples[0].RawProfile))
+	require.NoError(t, err)
+	// Samples are skipped if all of their values are zero.
+	var zeroValue int
+samples:
+	for _, s := range p.Sample {
+		for _, v := range s.Value {
+			if v != 0 {
+				continue samples
+			}
+		}
+		zeroValue++
+	}
+
+	require.Equal(t, float64(1), gatherMetrics(t, reg, "parca_profilestore_profile_size_bytes", "name", "memory"))
+	require.Equal(t, float64(len(p.Sample)-zeroValue), gatherMetrics(t, reg, "parca_ingest_samples_total", "name", "memory", "sample_type", p.SampleType[0].Type))
+	require.Equal(t, float64(zeroValue), gatherMetrics(t, reg, "parca_ingest_skipped_samples_total", "sample_type", p.SampleType[0].Type, "reason", "zero_value"))
+	require.NotZero(t, gatherMetrics(t, reg, "parca_ingest_stacktraces_total", "name", "memory"))
+	require.Equal(t, float64(len(p.Location)), gatherMetrics(t, reg, "parca_ingest_metastore_created_total", "name", "memory", "kind", "location"))
+	require.Equal(t, float64(len(p.Function)), gatherMetrics(t, reg, "parca_ingest_metastore_created_total", "name", "memory", "kind", "function"))
+	require.Equal(t, float64(1), gatherMetrics(t, reg, "parca_ingest_stage_duration_seconds", "name", "memory", "stage", "parse"))
+	require.Equal(t, float64(len(p.SampleType)), gatherMetrics(t, reg, "parca_ingest_stage_duration_seconds", "name", "memory", "stage", "convert"))
+	// The samples of all sample types are inserted at once.
+	require.Equal(t, float64(1), gatherMetrics(t, reg, "parca_ingest_stage_duration_seconds", "name", "memory", "stage", "insert"))
+
+	_, err = s.WriteRaw(ctx, req)
+	require.Equal(t, rejection.DuplicateSample, rejection.Reason(err))
+	require.Equal(t, float64(1), gatherMetrics(t, reg, "parca_profilestore_write_errors_total", "name", "memory", "reason", "duplicate_sample"))
+
+	req.Series[0].Samples[0].RawProfile = []byte("not a profile")
+	_, err = s.WriteRaw(ctx, req)
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+	require.Equal(t, float64(1), gatherMetrics(t, reg, "parca_profilestore_write_errors_total", "name", "memory", "reason", "parse"))
+}
+
+func TestWriteRawConcurrent(t *testing.T) {
+	ctx := context.Background()
+	reg := prometheus.NewRegistry()
+	s := newTestProfileColumnStoreWithRegistry(t, reg,
+		WithIngestConcurrency(4),
+		WithIngesterOptions(parcacol.WithInsertBatching(100000)),
+	)
+
+	req := &profilestorepb.WriteRawRequest{}
+	for i := 0; i < 4; i++ {
+		req.Series = append(req.Series, writeRawRequest(t, "__name__", "memory", "i", strconv.Itoa(i)).Series...)
+	}
+	_, err := s.WriteRaw(ctx, req)
+	require.NoError(t, err)
+	require.NotEmpty(t, sampleTypeTotals(t, s, tenant.Default))
+	require.NotZero(t, gatherMetrics(t, reg, "parca_ingest_insert_batch_rows"))
+
+	// Rejections of some series don't prevent the others from being
+	// ingested.
+	req.Series = append(req.Series, writeRawRequest(t, "__name__", "memory", "i", "4").Series...)
+	_, err = s.WriteRaw(ctx, req)
+	require.Equal(t, rejection.DuplicateSample, rejection.Reason(err))
+	_, err = s.WriteRaw(ctx, writeRawRequest(t, "__name__", "memory", "i", "4"))
+	require.Equal(t, rejection.DuplicateSample, rejection.Reason(err))
+
+	// Profiles that can't be parsed fail the request.
+	req = writeRawRequest(t, "__name__", "memory", "i", "5")
+	req.Series = append(req.Series, &profilestorepb.RawProfileSeries{
+		Labels:  &profilestorepb.LabelSet{Labels: []*profilestorepb.Label{{Name: "__name__", Value: "memory"}}},
+		Samples: []*profilestorepb.RawSample{{RawProfile: []byte("not a profile")}},
+	})
+	_, err = s.WriteRaw(ctx, req)
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+// BenchmarkWriteRawAndArrow writes the same profile to a new series with
+// every request, once as pprof and once as an Arrow record.
+//
+// go test -bench=WriteRawAndArrow -benchmem ./pkg/profilestore
+func BenchmarkWriteRawAndArrow(b *testing.B) {
+	ctx := context.Background()
+
+	b.Run("WriteRaw", func(b *testing.B) {
+		s := newTestProfileColumnStore(b)
+		reqs := make([]*profilestorepb.WriteRawRequest, 0, b.N)
+		for i := 0; i < b.N; i++ {
+			reqs = append(reqs, writeRawRequest(b, "__name__", "memory", "i", strconv.Itoa(i)))
+		}
+
+		b.ReportAllocs()
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			_, err := s.WriteRaw(ctx, reqs[i])
+			require.NoError(b, err)
+		}
+	})
+
+	b.Run("WriteArrow", func(b *testing.B) {
+		s := newTestProfileColumnStore(b)
+		reqs := make([]*profilestorepb.WriteArrowRequest, 0, b.N)
+		for i := 0; i < b.N; i++ {
+			reqs = append(reqs, writeArrowRequest(b, 1, "__name__", "memory", "i", strconv.Itoa(i)))
+		}
+
+		b.ReportAllocs()
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			_, err := s.WriteArrow(ctx, reqs[i])
+			require.NoError(b, err)
+		}
+	})
+}
+
+// go test -bench=WriteRawConcurrent -benchmem ./pkg/profilestore
+func BenchmarkWriteRawConcurrent(b *testing.B) {
+	ctx := context.Background()
+
+	for _, bc := range []struct {
+		name string
+		opts []Option
+	}{{
+		name: "serial",
+	}, {
+		name: "workers",
+		opts: []Option{WithIngestConcurrency(0)},
+	}, {
+		name: "workers-batched",
+		opts: []Option{
+			WithIngestConcurrency(0),
+			WithIngesterOptions(parcacol.WithInsertBatching(100000)),
+		},
+	}} {
+		b.Run(bc.name, func(b *testing.B) {
+			s := newTestProfileColumnStore(b, bc.opts...)
+			reqs := make([]*profilestorepb.WriteRawRequest, 0, b.N)
+			for i := 0; i < b.N; i++ {
+				reqs = append(reqs, writeRawRequest(b, "__name__", "memory", "i", strconv.Itoa(i)))
+			}
+
+			var next int64
+			b.ReportAllocs()
+			b.ResetTimer()
+			b.RunParallel(func(pb *testing.PB) {
+				for pb.Next() {
+					_, err := s.WriteRaw(ctx, reqs[atomic.AddInt64(&next, 1)-1])
+					require.NoError(b, err)
+				}
+			})
+		})
+	}
+}
@@ -18,6 +18,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math"
 	"sort"
 	"strings"
 	"time"
@@ -25,8 +26,10 @@ import (
 	"github.com/apache/arrow/go/v8/arrow"
 	"github.com/apache/arrow/go/v8/arrow/array"
 	"github.com/go-kit/log"
+	"github.com/go-kit/log/level"
 	"github.com/polarsignals/arcticdb/query"
 	"github.com/polarsignals/arcticdb/query/logicalplan"
+	"github.com/prometheus/common/model"
 	"github.com/prometheus/prometheus/model/labels"
 	"github.com/prometheus/prometheus/model/timestamp"
 	"github.com/prometheus/prometheus/promql/parser"
@@ -41,6 +44,8 @@ import (
 	"github.com/parca-dev/parca/pkg/metastore"
 	"github.com/parca-dev/parca/pkg/parcacol"
 	"github.com/parca-dev/parca/pkg/profile"
+	"github.com/parca-dev/parca/pkg/rawprofile"
+	"github.com/parca-dev/parca/pkg/tenant"
 )
 
 type Engine interface {
@@ -56,8 +61,50 @@ type ColumnQueryAPI struct {
 	logger    log.Logger
 	tracer    trace.Tracer
 	engine    Engine
-	tableName string
+	tables    *parcacol.Tables
 	metaStore metastore.ProfileMetaStore
+
+	// rawProfiles is nil if raw profiles are not archived.
+	rawProfiles *rawprofile.Archive
+	// limiter is nil if no cardinality limits are configured.
+	limiter *parcacol.CardinalityLimiter
+	// sources is nil if source reports don't include the source files.
+	sources SourceFetcher
+	// disassembler is nil if disassembly reports don't include the
+	// instructions of the functions.
+	disassembler Disassembler
+}
+
+type Option func(*ColumnQueryAPI)
+
+// WithRawProfiles serves the raw profiles of the archive with GetRawProfile.
+func WithRawProfiles(a *rawprofile.Archive) Option {
+	return func(q *ColumnQueryAPI) {
+		q.rawProfiles = a
+	}
+}
+
+// WithCardinalityLimiter returns the warnings of the limiter with the labels
+// and values, as they may be incomplete once a limit was hit.
+func WithCardinalityLimiter(l *parcacol.CardinalityLimiter) Option {
+	return func(q *ColumnQueryAPI) {
+		q.limiter = l
+	}
+}
+
+// WithSources includes the source files of the fetcher in source reports.
+func WithSources(f SourceFetcher) Option {
+	return func(q *ColumnQueryAPI) {
+		q.sources = f
+	}
+}
+
+// WithDisassembler includes the instructions of the functions disassembled by
+// the disassembler in disassembly reports.
+func WithDisassembler(d Disassembler) Option {
+	return func(q *ColumnQueryAPI) {
+		q.disassembler = d
+	}
 }
 
 func NewColumnQueryAPI(
@@ -65,94 +112,258 @@ func NewColumnQueryAPI(
 	tracer trace.Tracer,
 	metaStore metastore.ProfileMetaStore,
 	engine Engine,
-	tableName string,
+	tables *parcacol.Tables,
+	opts ...Option,
 ) *ColumnQueryAPI {
-	return &ColumnQueryAPI{
+	q := &ColumnQueryAPI{
 		logger:    logger,
 		tracer:    tracer,
 		engine:    engine,
-		tableName: tableName,
+		tables:    tables,
 		metaStore: metaStore,
 	}
+	for _, opt := range opts {
+		opt(q)
+	}
+	return q
 }
 
-// Labels issues a labels request against the storage.
-func (q *ColumnQueryAPI) Labels(ctx context.Context, req *pb.LabelsRequest) (*pb.LabelsResponse, error) {
-	seen := map[string]struct{}{}
-
-	err := q.engine.ScanSchema(q.tableName).
-		Distinct(logicalplan.Col("name")).
-		Filter(logicalplan.Col("name").RegexMatch("^labels\\..+$")).
-		Execute(ctx, func(ar arrow.Record) error {
-			if ar.NumCols() != 1 {
-				return fmt.Errorf("expected 1 column, got %d", ar.NumCols())
-			}
-
-			col := ar.Column(0)
-			stringCol, ok := col.(*array.String)
-			if !ok {
-				return fmt.Errorf("expected string column, got %T", col)
-			}
+// table returns the tenant of the request and the name of its table. Queries
+// never create tables, NotFound is returned if the tenant didn't write any
+// profiles yet.
+func (q *ColumnQueryAPI) table(ctx context.Context) (string, string, error) {
+	tenantID, err := tenant.FromContext(ctx)
+	if err != nil {
+		return "", "", status.Error(codes.InvalidArgument, err.Error())
+	}
+	if _, ok := q.tables.Lookup(tenantID); !ok {
+		return "", "", status.Errorf(codes.NotFound, "tenant %q has no profiles", tenantID)
+	}
+	return tenantID, q.tables.Name(tenantID), nil
+}
 
-			for i := 0; i < stringCol.Len(); i++ {
-				val := stringCol.Value(i)
-				seen[strings.TrimPrefix(val, "labels.")] = struct{}{}
-			}
+// Labels issues a labels request against the storage. Only the names of the
+// labels of the series matching any of the selectors in the time range are
+// returned, all names if neither is given.
+func (q *ColumnQueryAPI) Labels(ctx context.Context, req *pb.LabelsRequest) (*pb.LabelsResponse, error) {
+	tenantID, table, err := q.table(ctx)
+	if err != nil {
+		return nil, err
+	}
 
-			return nil
-		})
+	filters, err := seriesFilters(req.Match, req.Start, req.End)
 	if err != nil {
 		return nil, err
 	}
 
-	vals := make([]string, 0, len(seen))
-	for val := range seen {
-		vals = append(vals, val)
+	var vals []string
+	if len(filters) == 0 {
+		vals, err = q.labelNames(ctx, table)
+	} else {
+		vals, err = q.matchingLabelNames(ctx, table, filters)
+	}
+	if err != nil {
+		return nil, err
 	}
 
-	sort.Strings(vals)
+	vals, warnings := limitValues(vals, req.Prefix, req.Limit, "label names")
 
 	return &pb.LabelsResponse{
 		LabelNames: vals,
+		Warnings:   append(q.limiter.Warnings(tenantID), warnings...),
 	}, nil
 }
 
-// Values issues a values request against the storage.
+// Values issues a values request against the storage. Only the values of the
+// series matching any of the selectors in the time range are returned, all
+// values if neither is given.
 func (q *ColumnQueryAPI) Values(ctx context.Context, req *pb.ValuesRequest) (*pb.ValuesResponse, error) {
+	tenantID, table, err := q.table(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	filters, err := seriesFilters(req.Match, req.Start, req.End)
+	if err != nil {
+		return nil, err
+	}
+
 	name := req.LabelName
 	vals := []string{}
 
-	err := q.engine.ScanTable(q.tableName).
-		Distinct(logicalplan.Col("labels."+name)).
-		Execute(ctx, func(ar arrow.Record) error {
-			if ar.NumCols() != 1 {
-				return fmt.Errorf("expected 1 column, got %d", ar.NumCols())
-			}
+	if len(filters) == 0 {
+		err = q.engine.ScanTable(table).
+			Distinct(logicalplan.Col("labels."+name)).
+			Execute(ctx, func(ar arrow.Record) error {
+				if ar.NumCols() != 1 {
+					return fmt.Errorf("expected 1 column, got %d", ar.NumCols())
+				}
 
-			col := ar.Column(0)
-			stringCol, ok := col.(*array.Binary)
-			if !ok {
-				return fmt.Errorf("expected string column, got %T", col)
-			}
+				col := ar.Column(0)
+				stringCol, ok := col.(*array.Binary)
+				if !ok {
+					return fmt.Errorf("expected string column, got %T", col)
+				}
 
-			for i := 0; i < stringCol.Len(); i++ {
-				val := stringCol.Value(i)
-				vals = append(vals, string(val))
-			}
+				for i := 0; i < stringCol.Len(); i++ {
+					val := stringCol.Value(i)
+					vals = append(vals, string(val))
+				}
 
-			return nil
-		})
+				return nil
+			})
+	} else {
+		vals, err = q.matchingLabelValues(ctx, table, name, filters)
+	}
 	if err != nil {
 		return nil, err
 	}
 
-	sort.Strings(vals)
+	vals, warnings := limitValues(vals, req.Prefix, req.Limit, "label values")
 
 	return &pb.ValuesResponse{
 		LabelValues: vals,
+		Warnings:    append(q.limiter.Warnings(tenantID), warnings...),
 	}, nil
 }
 
+// seriesFilters returns a filter expression per selector, each restricted to
+// the time range of which either bound is optional. It returns no filters if
+// there are neither selectors nor bounds.
+func seriesFilters(match []string, start, end *timestamppb.Timestamp) ([]logicalplan.Expr, error) {
+	timeExprs := []logicalplan.Expr{}
+	if start != nil {
+		timeExprs = append(timeExprs, logicalplan.Col(parcacol.ColumnTimestamp).GT(logicalplan.Literal(timestamp.FromTime(start.AsTime()))))
+	}
+	if end != nil {
+		timeExprs = append(timeExprs, logicalplan.Col(parcacol.ColumnTimestamp).LT(logicalplan.Literal(timestamp.FromTime(end.AsTime()))))
+	}
+
+	if len(match) == 0 {
+		if len(timeExprs) == 0 {
+			return nil, nil
+		}
+		return []logicalplan.Expr{logicalplan.And(timeExprs...)}, nil
+	}
+
+	filters := make([]logicalplan.Expr, 0, len(match))
+	for _, m := range match {
+		exprs, err := selectorToFilterExprs(m, false)
+		if err != nil {
+			return nil, err
+		}
+		exprs = append(exprs, timeExprs...)
+		if len(exprs) == 0 {
+			// An empty selector matches all series in the time range.
+			return nil, nil
+		}
+		filters = append(filters, logicalplan.And(exprs...))
+	}
+	return filters, nil
+}
+
+// matchingLabelNames returns the names of the labels of the series matching
+// any of the filters.
+func (q *ColumnQueryAPI) matchingLabelNames(ctx context.Context, table string, filters []logicalplan.Expr) ([]string, error) {
+	seen := map[string]struct{}{}
+	for _, filter := range filters {
+		err := q.engine.ScanTable(table).
+			Filter(filter).
+			Aggregate(
+				logicalplan.Sum(logicalplan.Col(parcacol.ColumnValue)),
+				logicalplan.DynCol(parcacol.ColumnLabels),
+			).
+			Execute(ctx, func(ar arrow.Record) error {
+				for i, field := range ar.Schema().Fields() {
+					if !strings.HasPrefix(field.Name, parcacol.ColumnLabels+".") {
+						continue
+					}
+					col, ok := ar.Column(i).(*array.Binary)
+					if !ok {
+						return fmt.Errorf("expected binary column, got %T", ar.Column(i))
+					}
+					for j := 0; j < col.Len(); j++ {
+						if !col.IsNull(j) && len(col.Value(j)) > 0 {
+							seen[strings.TrimPrefix(field.Name, parcacol.ColumnLabels+".")] = struct{}{}
+							break
+						}
+					}
+				}
+				return nil
+			})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	names := make([]string, 0, len(seen))
+	for name := range seen {
+		names = append(names, name)
+	}
+	return names, nil
+}
+
+// matchingLabelValues returns the values of the label of the series matching
+// any of the filters.
+func (q *ColumnQueryAPI) matchingLabelValues(ctx context.Context, table, name string, filters []logicalplan.Expr) ([]string, error) {
+	seen := map[string]struct{}{}
+	for _, filter := range filters {
+		err := q.engine.ScanTable(table).
+			Filter(filter).
+			Aggregate(
+				logicalplan.Sum(logicalplan.Col(parcacol.ColumnValue)),
+				logicalplan.Col(parcacol.ColumnLabels+"."+name),
+			).
+			Execute(ctx, func(ar arrow.Record) error {
+				indices := ar.Schema().FieldIndices(parcacol.ColumnLabels + "." + name)
+				if len(indices) == 0 {
+					// None of the matching series have the label.
+					return nil
+				}
+				col, ok := ar.Column(indices[0]).(*array.Binary)
+				if !ok {
+					return fmt.Errorf("expected binary column, got %T", ar.Column(indices[0]))
+				}
+				for i := 0; i < col.Len(); i++ {
+					if !col.IsNull(i) && len(col.Value(i)) > 0 {
+						seen[string(col.Value(i))] = struct{}{}
+					}
+				}
+				return nil
+			})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	vals := make([]string, 0, len(seen))
+	for v := range seen {
+		vals = append(vals, v)
+	}
+	return vals, nil
+}
+
+// limitValues returns the sorted values with the prefix, truncated to the
+// limit. If they are truncated, a warning is returned.
+func limitValues(vals []string, prefix string, limit uint32, what string) ([]string, []string) {
+	if prefix != "" {
+		matching := vals[:0]
+		for _, v := range vals {
+			if strings.HasPrefix(v, prefix) {
+				matching = append(matching, v)
+			}
+		}
+		vals = matching
+	}
+
+	sort.Strings(vals)
+
+	if limit == 0 || len(vals) <= int(limit) {
+		return vals, nil
+	}
+	return vals[:limit], []string{fmt.Sprintf("%d %s matched, only the first %d are returned", len(vals), what, limit)}
+}
+
 func matcherToBooleanExpression(matcher *labels.Matcher) (logicalplan.Expr, error) {
 	ref := logicalplan.Col("labels." + matcher.Name)
 	switch matcher.Type {
@@ -190,11 +401,22 @@ var (
 )
 
 func queryToFilterExprs(query string) ([]logicalplan.Expr, error) {
+	return selectorToFilterExprs(query, true)
+}
+
+// selectorToFilterExprs returns the filter expressions of the selector. If
+// the profile type is optional, a selector without one matches the profiles
+// of all types.
+func selectorToFilterExprs(query string, profileTypeRequired bool) ([]logicalplan.Expr, error) {
 	parsedSelector, err := parser.ParseMetricSelector(query)
 	if err != nil {
 		return nil, status.Error(codes.InvalidArgument, "failed to parse query")
 	}
 
+	return matchersToFilterExprs(parsedSelector, profileTypeRequired)
+}
+
+func matchersToFilterExprs(parsedSelector []*labels.Matcher, profileTypeRequired bool) ([]logicalplan.Expr, error) {
 	sel := make([]*labels.Matcher, 0, len(parsedSelector)-1)
 	var nameLabel *labels.Matcher
 	for _, matcher := range parsedSelector {
@@ -205,7 +427,14 @@ func queryToFilterExprs(query string) ([]logicalplan.Expr, error) {
 		}
 	}
 	if nameLabel == nil {
-		return nil, status.Error(codes.InvalidArgument, "query must contain a profile-type selection")
+		if profileTypeRequired {
+			return nil, status.Error(codes.InvalidArgument, "query must contain a profile-type selection")
+		}
+		exprs, err := matchersToBooleanExpressions(sel)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, "failed to build query")
+		}
+		return exprs, nil
 	}
 
 	parts := strings.Split(nameLabel.Value, ":")
@@ -246,7 +475,25 @@ func (q *ColumnQueryAPI) QueryRange(ctx context.Context, req *pb.QueryRangeReque
 		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
 
-	selectorExprs, err := queryToFilterExprs(req.Query)
+	_, table, err := q.table(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	e, err := parseRangeExpr(req.Query)
+	if err != nil {
+		return nil, err
+	}
+	if e.rate && req.StepFunction != pb.QueryRangeRequest_STEP_FUNCTION_SUM_UNSPECIFIED {
+		return nil, status.Error(codes.InvalidArgument, "rates sum the values within their range, other step functions are not supported")
+	}
+
+	selectorExprs, err := matchersToFilterExprs(e.matchers, true)
+	if err != nil {
+		return nil, err
+	}
+
+	groupExprs, err := q.groupExprs(ctx, table, e)
 	if err != nil {
 		return nil, err
 	}
@@ -262,18 +509,20 @@ func (q *ColumnQueryAPI) QueryRange(ctx context.Context, req *pb.QueryRangeReque
 
 	filterExpr := logicalplan.And(exprs...)
 
-	res := &pb.QueryRangeResponse{}
-	labelsetToIndex := map[string]int{}
-
-	labelSet := labels.Labels{}
-
+	// The values of the samples of a profile are summed by the aggregation,
+	// along with the ones of the other series of its group, so the result
+	// has a row per group and timestamp rather than per sample. The values
+	// of the profiles within a step are then aggregated while reading the
+	// result. The aggregation can't bucket the timestamps by step itself:
+	// the logical plan has no arithmetic expressions to truncate the
+	// timestamp with, it can only group by columns, and sum is its only
+	// aggregation function, which rules out the avg and max step functions.
 	var ar arrow.Record
-	err = q.engine.ScanTable(q.tableName).
+	err = q.engine.ScanTable(table).
 		Filter(filterExpr).
 		Aggregate(
 			logicalplan.Sum(logicalplan.Col("value")),
-			logicalplan.DynCol("labels"),
-			logicalplan.Col("timestamp"),
+			groupExprs...,
 		).
 		Execute(ctx, func(r arrow.Record) error {
 			r.Retain()
@@ -283,6 +532,10 @@ func (q *ColumnQueryAPI) QueryRange(ctx context.Context, req *pb.QueryRangeReque
 	if err != nil {
 		return nil, err
 	}
+	if ar == nil || ar.NumRows() == 0 {
+		return &pb.QueryRangeResponse{}, nil
+	}
+	defer ar.Release()
 
 	timestampColumnIndex := 0
 	timestampColumnFound := false
@@ -316,6 +569,9 @@ func (q *ColumnQueryAPI) QueryRange(ctx context.Context, req *pb.QueryRangeReque
 		return nil, ErrValueColumnNotFound
 	}
 
+	series := map[string]*rangeSeries{}
+	labelSet := labels.Labels{}
+
 	for i := 0; i < int(ar.NumRows()); i++ {
 		labelSet = labelSet[:0]
 		for _, labelColumnIndex := range labelColumnIndices {
@@ -331,45 +587,354 @@ func (q *ColumnQueryAPI) QueryRange(ctx context.Context, req *pb.QueryRangeReque
 		}
 
 		sort.Sort(labelSet)
-		s := labelSet.String()
-		index, ok := labelsetToIndex[s]
+		key := labelSet.String()
+		rs, ok := series[key]
 		if !ok {
-			pbLabelSet := make([]*profilestorepb.Label, 0, len(labelSet))
-			for _, l := range labelSet {
-				pbLabelSet = append(pbLabelSet, &profilestorepb.Label{
-					Name:  l.Name,
-					Value: l.Value,
-				})
+			rs = &rangeSeries{
+				key:    key,
+				labels: labelSet.Copy(),
+				values: map[int64]int64{},
 			}
-			res.Series = append(res.Series, &pb.MetricsSeries{Labelset: &profilestorepb.LabelSet{Labels: pbLabelSet}})
-			index = len(res.Series) - 1
-			labelsetToIndex[s] = index
+			series[key] = rs
 		}
 
-		series := res.Series[index]
-		series.Samples = append(series.Samples, &pb.MetricsSample{
-			Timestamp: timestamppb.New(timestamp.Time(ar.Column(timestampColumnIndex).(*array.Int64).Value(i))),
-			Value:     ar.Column(valueColumnIndex).(*array.Int64).Value(i),
+		rs.add(
+			ar.Column(timestampColumnIndex).(*array.Int64).Value(i),
+			ar.Column(valueColumnIndex).(*array.Int64).Value(i),
+		)
+	}
+
+	matched := make([]*rangeSeries, 0, len(series))
+	for _, rs := range series {
+		matched = append(matched, rs)
+	}
+
+	// The series with the highest totals are kept.
+	limit := int(req.Limit)
+	if e.topk > 0 && (limit == 0 || e.topk < limit) {
+		limit = e.topk
+	}
+	if limit > 0 && len(matched) > limit {
+		sort.Slice(matched, func(i, j int) bool {
+			if matched[i].total != matched[j].total {
+				return matched[i].total > matched[j].total
+			}
+			return matched[i].key < matched[j].key
 		})
+		matched = matched[:limit]
+	}
+	sort.Slice(matched, func(i, j int) bool {
+		return matched[i].key < matched[j].key
+	})
+
+	// The range of the rate is the default step.
+	step := req.Step.AsDuration().Milliseconds()
+	if req.Step == nil && e.rate {
+		step = e.rateRange.Milliseconds()
 	}
 
-	// This is horrible and should be fixed. The data is sorted in the storage, we should not have to sort it here.
-	for _, series := range res.Series {
-		sort.Slice(series.Samples, func(i, j int) bool {
-			return series.Samples[i].Timestamp.AsTime().Before(series.Samples[j].Timestamp.AsTime())
+	res := &pb.QueryRangeResponse{Series: make([]*pb.MetricsSeries, 0, len(matched))}
+	for _, rs := range matched {
+		var samples []*pb.MetricsSample
+		if e.rate {
+			samples = rs.rateSamples(start, step, e.rateRange.Milliseconds())
+		} else {
+			samples = rs.stepSamples(start, step, req.StepFunction)
+		}
+		res.Series = append(res.Series, &pb.MetricsSeries{
+			Labelset: rs.labelSet(),
+			Samples:  samples,
 		})
 	}
 
 	return res, nil
 }
 
+// rangeSeries holds the values of the profiles of a series by timestamp.
+type rangeSeries struct {
+	key    string
+	labels labels.Labels
+	total  int64
+	values map[int64]int64
+}
+
+func (s *rangeSeries) add(ts, value int64) {
+	s.total += value
+	s.values[ts] += value
+}
+
+type stepBucket struct {
+	ts    int64
+	sum   int64
+	max   int64
+	count int64
+}
+
+func (s *rangeSeries) labelSet() *profilestorepb.LabelSet {
+	pbLabelSet := make([]*profilestorepb.Label, 0, len(s.labels))
+	for _, l := range s.labels {
+		pbLabelSet = append(pbLabelSet, &profilestorepb.Label{
+			Name:  l.Name,
+			Value: l.Value,
+		})
+	}
+	return &profilestorepb.LabelSet{Labels: pbLabelSet}
+}
+
+// timestamps returns the timestamps of the profiles of the series in order.
+func (s *rangeSeries) timestamps() []int64 {
+	timestamps := make([]int64, 0, len(s.values))
+	for ts := range s.values {
+		timestamps = append(timestamps, ts)
+	}
+	sort.Slice(timestamps, func(i, j int) bool { return timestamps[i] < timestamps[j] })
+	return timestamps
+}
+
+// stepSamples returns a sample per step aligned to start, or per profile if
+// the step is zero.
+func (s *rangeSeries) stepSamples(start, step int64, f pb.QueryRangeRequest_StepFunction) []*pb.MetricsSample {
+	timestamps := s.timestamps()
+	buckets := make([]*stepBucket, 0, len(timestamps))
+	for _, ts := range timestamps {
+		value := s.values[ts]
+		if step > 0 {
+			ts = start + (ts-start)/step*step
+		}
+
+		if n := len(buckets); n > 0 && buckets[n-1].ts == ts {
+			b := buckets[n-1]
+			b.sum += value
+			b.count++
+			if value > b.max {
+				b.max = value
+			}
+			continue
+		}
+		buckets = append(buckets, &stepBucket{ts: ts, sum: value, max: value, count: 1})
+	}
+
+	samples := make([]*pb.MetricsSample, 0, len(buckets))
+	for _, b := range buckets {
+		value := b.sum
+		switch f {
+		case pb.QueryRangeRequest_STEP_FUNCTION_AVG:
+			value = int64(math.Round(float64(b.sum) / float64(b.count)))
+		case pb.QueryRangeRequest_STEP_FUNCTION_MAX:
+			value = b.max
+		}
+		samples = append(samples, &pb.MetricsSample{
+			Timestamp: timestamppb.New(timestamp.Time(b.ts)),
+			Value:     value,
+		})
+	}
+	return samples
+}
+
+// rateSamples returns a sample per step aligned to start, of which the range
+// window starting at the step holds profiles. Its value per second is the sum
+// of the values of the profiles in the window per second of the range, its
+// value that rounded to an integer. Windows
+// overlap if the range is longer than the step, and skip profiles if it's
+// shorter.
+func (s *rangeSeries) rateSamples(start, step, rng int64) []*pb.MetricsSample {
+	sums := map[int64]int64{}
+	for ts, value := range s.values {
+		// The profile is in the windows starting at the steps within
+		// (ts-rng, ts].
+		first := start
+		if from := ts - rng + 1 - start; from > 0 {
+			first = start + (from+step-1)/step*step
+		}
+		for w := first; w <= ts; w += step {
+			sums[w] += value
+		}
+	}
+
+	windows := make([]int64, 0, len(sums))
+	for w := range sums {
+		windows = append(windows, w)
+	}
+	sort.Slice(windows, func(i, j int) bool { return windows[i] < windows[j] })
+
+	seconds := time.Duration(rng * time.Millisecond.Nanoseconds()).Seconds()
+	samples := make([]*pb.MetricsSample, 0, len(windows))
+	for _, w := range windows {
+		rate := float64(sums[w]) / seconds
+		samples = append(samples, &pb.MetricsSample{
+			Timestamp:      timestamppb.New(timestamp.Time(w)),
+			Value:          int64(math.Round(rate)),
+			ValuePerSecond: rate,
+		})
+	}
+	return samples
+}
+
+// Series returns the distinct label sets and profile types of the series
+// matching any of the selectors in the time range, sorted by profile type and
+// labels.
+func (q *ColumnQueryAPI) Series(ctx context.Context, req *pb.SeriesRequest) (*pb.SeriesResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	_, table, err := q.table(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Without selectors all series in the time range match.
+	filters, err := seriesFilters(req.Match, req.Start, req.End)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := map[string]*pb.Series{}
+	for _, filter := range filters {
+		err := q.engine.ScanTable(table).
+			Filter(filter).
+			Aggregate(
+				logicalplan.Sum(logicalplan.Col(parcacol.ColumnValue)),
+				logicalplan.DynCol(parcacol.ColumnLabels),
+				logicalplan.Col(parcacol.ColumnName),
+				logicalplan.Col(parcacol.ColumnSampleType),
+				logicalplan.Col(parcacol.ColumnSampleUnit),
+				logicalplan.Col(parcacol.ColumnPeriodType),
+				logicalplan.Col(parcacol.ColumnPeriodUnit),
+				logicalplan.Col(parcacol.ColumnDuration),
+			).
+			Execute(ctx, func(ar arrow.Record) error {
+				return addSeries(seen, ar)
+			})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	keys := make([]string, 0, len(seen))
+	for key := range seen {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	res := &pb.SeriesResponse{}
+	if req.Limit > 0 && len(keys) > int(req.Limit) {
+		keys = keys[:req.Limit]
+		res.Truncated = true
+	}
+	res.Series = make([]*pb.Series, 0, len(keys))
+	for _, key := range keys {
+		res.Series = append(res.Series, seen[key])
+	}
+
+	return res, nil
+}
+
+// addSeries adds the series of the aggregated record that weren't seen yet,
+// keyed by their profile type and labels.
+func addSeries(seen map[string]*pb.Series, ar arrow.Record) error {
+	if ar.NumRows() == 0 {
+		return nil
+	}
+
+	nameColumn, err := binaryFieldFromRecord(ar, parcacol.ColumnName)
+	if err != nil {
+		return err
+	}
+	sampleTypeColumn, err := binaryFieldFromRecord(ar, parcacol.ColumnSampleType)
+	if err != nil {
+		return err
+	}
+	sampleUnitColumn, err := binaryFieldFromRecord(ar, parcacol.ColumnSampleUnit)
+	if err != nil {
+		return err
+	}
+	periodTypeColumn, err := binaryFieldFromRecord(ar, parcacol.ColumnPeriodType)
+	if err != nil {
+		return err
+	}
+	periodUnitColumn, err := binaryFieldFromRecord(ar, parcacol.ColumnPeriodUnit)
+	if err != nil {
+		return err
+	}
+	durationIndices := ar.Schema().FieldIndices(parcacol.ColumnDuration)
+	if len(durationIndices) != 1 {
+		return fmt.Errorf("expected 1 column named %q, got %d", parcacol.ColumnDuration, len(durationIndices))
+	}
+	durationColumn, ok := ar.Column(durationIndices[0]).(*array.Int64)
+	if !ok {
+		return fmt.Errorf("expected column %q to be an int64 column, got %T", parcacol.ColumnDuration, ar.Column(durationIndices[0]))
+	}
+
+	fields := ar.Schema().Fields()
+	labelColumnIndices := []int{}
+	for i, field := range fields {
+		if strings.HasPrefix(field.Name, parcacol.ColumnLabels+".") {
+			labelColumnIndices = append(labelColumnIndices, i)
+		}
+	}
+
+	labelSet := labels.Labels{}
+	for i := 0; i < int(ar.NumRows()); i++ {
+		labelSet = labelSet[:0]
+		for _, labelColumnIndex := range labelColumnIndices {
+			col := ar.Column(labelColumnIndex).(*array.Binary)
+			if col.IsNull(i) {
+				continue
+			}
+
+			v := col.Value(i)
+			if len(v) > 0 {
+				labelSet = append(labelSet, labels.Label{Name: strings.TrimPrefix(fields[labelColumnIndex].Name, parcacol.ColumnLabels+"."), Value: string(v)})
+			}
+		}
+		sort.Sort(labelSet)
+
+		profileType := &pb.ProfileType{
+			Name:       string(nameColumn.Value(i)),
+			SampleType: string(sampleTypeColumn.Value(i)),
+			SampleUnit: string(sampleUnitColumn.Value(i)),
+			PeriodType: string(periodTypeColumn.Value(i)),
+			PeriodUnit: string(periodUnitColumn.Value(i)),
+			Delta:      durationColumn.Value(i) != 0,
+		}
+		key := fmt.Sprintf("%s:%s:%s:%s:%s", profileType.Name, profileType.SampleType, profileType.SampleUnit, profileType.PeriodType, profileType.PeriodUnit)
+		if profileType.Delta {
+			key = fmt.Sprintf("%s:delta", key)
+		}
+		key = key + labelSet.String()
+		if _, ok := seen[key]; ok {
+			continue
+		}
+
+		pbLabelSet := make([]*profilestorepb.Label, 0, len(labelSet))
+		for _, l := range labelSet {
+			pbLabelSet = append(pbLabelSet, &profilestorepb.Label{
+				Name:  l.Name,
+				Value: l.Value,
+			})
+		}
+		seen[key] = &pb.Series{
+			Labelset:    &profilestorepb.LabelSet{Labels: pbLabelSet},
+			ProfileType: profileType,
+		}
+	}
+
+	return nil
+}
+
 // Types returns the available types of profiles.
 func (q *ColumnQueryAPI) ProfileTypes(ctx context.Context, req *pb.ProfileTypesRequest) (*pb.ProfileTypesResponse, error) {
+	_, table, err := q.table(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	res := &pb.ProfileTypesResponse{}
 
 	seen := map[string]struct{}{}
 
-	err := q.engine.ScanTable(q.tableName).
+	err = q.engine.ScanTable(table).
 		Distinct(
 			logicalplan.Col(parcacol.ColumnName),
 			logicalplan.Col(parcacol.ColumnSampleType),
@@ -484,20 +1049,44 @@ func (q *ColumnQueryAPI) Query(ctx context.Context, req *pb.QueryRequest) (*pb.Q
 		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
 
+	filter, err := newStackFilter(req.Filter)
+	if err != nil {
+		return nil, err
+	}
+	report := reportOptions{
+		typ:      req.GetReportType(),
+		filter:   filter,
+		function: req.GetFunction(),
+		graph:    req.GetGraphOptions(),
+		source:   req.GetSourceOptions(),
+	}
+
 	switch req.Mode {
 	case pb.QueryRequest_MODE_SINGLE_UNSPECIFIED:
-		return q.singleRequest(ctx, req.GetSingle(), req.GetReportType())
+		return q.singleRequest(ctx, req.GetSingle(), report)
 	case pb.QueryRequest_MODE_MERGE:
-		return q.mergeRequest(ctx, req.GetMerge(), req.GetReportType())
+		return q.mergeRequest(ctx, req.GetMerge(), report)
 	case pb.QueryRequest_MODE_DIFF:
-		return q.diffRequest(ctx, req.GetDiff(), req.GetReportType())
+		return q.diffRequest(ctx, req.GetDiff(), report)
 	default:
 		return nil, status.Error(codes.InvalidArgument, "unknown query mode")
 	}
 }
 
-func (q *ColumnQueryAPI) renderReport(ctx context.Context, p *profile.StacktraceSamples, typ pb.QueryRequest_ReportType) (*pb.QueryResponse, error) {
-	switch typ {
+// reportOptions are the options of the report of a query.
+type reportOptions struct {
+	typ    pb.QueryRequest_ReportType
+	filter *stackFilter
+	// function is the function of callgraph neighborhood reports.
+	function string
+	graph    *pb.GraphOptions
+	source   *pb.SourceOptions
+}
+
+func (q *ColumnQueryAPI) renderReport(ctx context.Context, p *profile.StacktraceSamples, report reportOptions) (*pb.QueryResponse, error) {
+	p = report.filter.apply(p)
+
+	switch report.typ {
 	case pb.QueryRequest_REPORT_TYPE_FLAMEGRAPH_UNSPECIFIED:
 		fg, err := GenerateFlamegraphFlat(ctx, q.tracer, q.metaStore, p)
 		if err != nil {
@@ -531,23 +1120,124 @@ func (q *ColumnQueryAPI) renderReport(ctx context.Context, p *profile.Stacktrace
 		return &pb.QueryResponse{
 			Report: &pb.QueryResponse_Top{Top: top},
 		}, nil
+	case pb.QueryRequest_REPORT_TYPE_CALLGRAPH_NEIGHBORHOOD:
+		n, err := GenerateCallgraphNeighborhood(ctx, p, report.function)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to generate callgraph neighborhood: %v", err.Error())
+		}
+
+		return &pb.QueryResponse{
+			Report: &pb.QueryResponse_CallgraphNeighborhood{CallgraphNeighborhood: n},
+		}, nil
+	case pb.QueryRequest_REPORT_TYPE_GRAPH:
+		g, err := GenerateGraph(ctx, p, report.graph)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to generate graph: %v", err.Error())
+		}
+
+		return &pb.QueryResponse{
+			Report: &pb.QueryResponse_Graph{Graph: g},
+		}, nil
+	case pb.QueryRequest_REPORT_TYPE_SOURCE:
+		src, err := GenerateSource(ctx, p, report.source)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to generate source: %v", err.Error())
+		}
+		if err := q.fetchSources(ctx, src); err != nil {
+			return nil, err
+		}
+
+		return &pb.QueryResponse{
+			Report: &pb.QueryResponse_Source{Source: src},
+		}, nil
+	case pb.QueryRequest_REPORT_TYPE_DISASSEMBLY:
+		d, err := GenerateDisassembly(ctx, p, report.function)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to generate disassembly: %v", err.Error())
+		}
+		if err := q.disassemble(ctx, d); err != nil {
+			return nil, err
+		}
+
+		return &pb.QueryResponse{
+			Report: &pb.QueryResponse_Disassembly{Disassembly: d},
+		}, nil
 	default:
 		return nil, status.Error(codes.InvalidArgument, "requested report type does not exist")
 	}
 }
 
-func (q *ColumnQueryAPI) singleRequest(ctx context.Context, s *pb.SingleProfile, reportType pb.QueryRequest_ReportType) (*pb.QueryResponse, error) {
+// fetchSources sets the content of the files of the source report, if the
+// fetcher has them. Files that can't be fetched are reported without content.
+func (q *ColumnQueryAPI) fetchSources(ctx context.Context, src *pb.Source) error {
+	if q.sources == nil {
+		return nil
+	}
+
+	for _, f := range src.Files {
+		if f.BuildId == "" {
+			continue
+		}
+		b, err := q.sources.FetchSource(ctx, f.BuildId, f.Filename)
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			if status.Code(err) != codes.NotFound {
+				level.Warn(q.logger).Log("msg", "failed to fetch source file", "buildid", f.BuildId, "filename", f.Filename, "err", err)
+			}
+			continue
+		}
+		f.Source = string(b)
+	}
+	return nil
+}
+
+// disassemble adds the instructions of the functions of the disassembly
+// report, if the disassembler has their binaries. Functions of binaries that
+// can't be disassembled are reported with the sampled addresses only.
+func (q *ColumnQueryAPI) disassemble(ctx context.Context, d *pb.Disassembly) error {
+	if q.disassembler == nil {
+		return nil
+	}
+
+	for _, f := range d.Functions {
+		buildID := f.Mapping.GetBuildId()
+		if buildID == "" {
+			continue
+		}
+		addrs := make([]uint64, 0, len(f.Instructions))
+		for _, inst := range f.Instructions {
+			addrs = append(addrs, inst.Address)
+		}
+
+		insts, err := q.disassembler.Disassemble(ctx, buildID, addrs)
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			if code := status.Code(err); code != codes.NotFound && code != codes.Unimplemented {
+				level.Warn(q.logger).Log("msg", "failed to disassemble function", "buildid", buildID, "function", f.Function.GetName(), "err", err)
+			}
+			continue
+		}
+		addInstructions(f, insts)
+	}
+	return nil
+}
+
+func (q *ColumnQueryAPI) singleRequest(ctx context.Context, s *pb.SingleProfile, report reportOptions) (*pb.QueryResponse, error) {
 	p, err := q.selectSingle(ctx, s)
 	if err != nil {
 		return nil, err
 	}
 
-	return q.renderReport(ctx, p, reportType)
+	return q.renderReport(ctx, p, report)
 }
 
 func (q *ColumnQueryAPI) selectSingle(ctx context.Context, s *pb.SingleProfile) (*profile.StacktraceSamples, error) {
 	t := s.Time.AsTime()
-	p, err := q.findSingle(ctx, s.Query, t)
+	p, err := q.findSingle(ctx, s.Query, s.SampleSelector, t)
 	if err != nil {
 		return nil, err
 	}
@@ -559,28 +1249,39 @@ func (q *ColumnQueryAPI) selectSingle(ctx context.Context, s *pb.SingleProfile)
 	return p, nil
 }
 
-func (q *ColumnQueryAPI) findSingle(ctx context.Context, query string, t time.Time) (*profile.StacktraceSamples, error) {
+func (q *ColumnQueryAPI) findSingle(ctx context.Context, query, sampleSelector string, t time.Time) (*profile.StacktraceSamples, error) {
 	requestedTime := timestamp.FromTime(t)
 
 	ctx, span := q.tracer.Start(ctx, "findSingle")
 	span.SetAttributes(attribute.String("query", query))
+	span.SetAttributes(attribute.String("sample_selector", sampleSelector))
 	span.SetAttributes(attribute.Int64("time", t.Unix()))
 	defer span.End()
 
+	_, table, err := q.table(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	selectorExprs, err := queryToFilterExprs(query)
 	if err != nil {
 		return nil, err
 	}
 
+	sampleExprs, err := sampleSelectorToFilterExprs(sampleSelector)
+	if err != nil {
+		return nil, err
+	}
+
 	filterExpr := logicalplan.And(
 		append(
-			selectorExprs,
+			append(selectorExprs, sampleExprs...),
 			logicalplan.Col("timestamp").Eq(logicalplan.Literal(requestedTime)),
 		)...,
 	)
 
 	var ar arrow.Record
-	err = q.engine.ScanTable(q.tableName).
+	err = q.engine.ScanTable(table).
 		Filter(filterExpr).
 		Aggregate(
 			logicalplan.Sum(logicalplan.Col("value")),
@@ -601,44 +1302,167 @@ func (q *ColumnQueryAPI) findSingle(ctx context.Context, query string, t time.Ti
 	return parcacol.ArrowRecordToStacktraceSamples(ctx, q.metaStore, ar, "sum(value)")
 }
 
-func (q *ColumnQueryAPI) mergeRequest(ctx context.Context, m *pb.MergeProfile, reportType pb.QueryRequest_ReportType) (*pb.QueryResponse, error) {
+func (q *ColumnQueryAPI) mergeRequest(ctx context.Context, m *pb.MergeProfile, report reportOptions) (*pb.QueryResponse, error) {
 	ctx, span := q.tracer.Start(ctx, "mergeRequest")
 	defer span.End()
 
+	if len(m.GroupBy) > 0 {
+		return q.groupedMergeRequest(ctx, m, report)
+	}
+
 	p, err := q.selectMerge(ctx, m)
 	if err != nil {
 		return nil, err
 	}
 
-	return q.renderReport(ctx, p, reportType)
+	return q.renderReport(ctx, p, report)
+}
+
+// groupedMergeRequest renders a report per group of a merge with group_by.
+func (q *ColumnQueryAPI) groupedMergeRequest(ctx context.Context, m *pb.MergeProfile, report reportOptions) (*pb.QueryResponse, error) {
+	groupColumns, err := groupByColumns(m.GroupBy)
+	if err != nil {
+		return nil, err
+	}
+
+	groups, err := q.selectMergeGroups(ctx, m, groupColumns)
+	if err != nil {
+		return nil, err
+	}
+
+	reports := make([]*pb.GroupedReport, 0, len(groups))
+	for _, g := range groups {
+		res, err := q.renderReport(ctx, g.Samples, report)
+		if err != nil {
+			return nil, err
+		}
+
+		gr := &pb.GroupedReport{
+			Labels: make([]*profilestorepb.Label, 0, len(g.Values)),
+		}
+		for i, v := range g.Values {
+			if v != "" {
+				gr.Labels = append(gr.Labels, &profilestorepb.Label{
+					Name:  m.GroupBy[i],
+					Value: v,
+				})
+			}
+		}
+
+		switch r := res.Report.(type) {
+		case *pb.QueryResponse_Flamegraph:
+			gr.Report = &pb.GroupedReport_Flamegraph{Flamegraph: r.Flamegraph}
+		case *pb.QueryResponse_Pprof:
+			gr.Report = &pb.GroupedReport_Pprof{Pprof: r.Pprof}
+		case *pb.QueryResponse_Top:
+			gr.Report = &pb.GroupedReport_Top{Top: r.Top}
+		case *pb.QueryResponse_CallgraphNeighborhood:
+			gr.Report = &pb.GroupedReport_CallgraphNeighborhood{CallgraphNeighborhood: r.CallgraphNeighborhood}
+		case *pb.QueryResponse_Graph:
+			gr.Report = &pb.GroupedReport_Graph{Graph: r.Graph}
+		case *pb.QueryResponse_Source:
+			gr.Report = &pb.GroupedReport_Source{Source: r.Source}
+		case *pb.QueryResponse_Disassembly:
+			gr.Report = &pb.GroupedReport_Disassembly{Disassembly: r.Disassembly}
+		default:
+			return nil, status.Errorf(codes.Internal, "unexpected report %T", res.Report)
+		}
+
+		reports = append(reports, gr)
+	}
+
+	return &pb.QueryResponse{
+		Report: &pb.QueryResponse_Groups{
+			Groups: &pb.GroupedReports{Reports: reports},
+		},
+	}, nil
+}
+
+// pprofGroupByPrefix is the prefix of group_by labels referring to pprof
+// labels rather than series labels.
+const pprofGroupByPrefix = "pprof."
+
+// groupByColumns returns the columns of the group_by labels of a merge.
+func groupByColumns(groupBy []string) ([]string, error) {
+	columns := make([]string, 0, len(groupBy))
+	seen := make(map[string]struct{}, len(groupBy))
+	for _, name := range groupBy {
+		if _, ok := seen[name]; ok {
+			return nil, status.Errorf(codes.InvalidArgument, "duplicate group_by label %q", name)
+		}
+		seen[name] = struct{}{}
+
+		column, label := parcacol.ColumnLabels, name
+		if strings.HasPrefix(name, pprofGroupByPrefix) {
+			column, label = parcacol.ColumnPprofLabels, strings.TrimPrefix(name, pprofGroupByPrefix)
+		}
+		if !model.LabelName(label).IsValid() {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid group_by label %q", name)
+		}
+		columns = append(columns, column+"."+label)
+	}
+
+	return columns, nil
 }
 
 func (q *ColumnQueryAPI) selectMerge(ctx context.Context, m *pb.MergeProfile) (*profile.StacktraceSamples, error) {
+	groups, err := q.selectMergeGroups(ctx, m, nil)
+	if err != nil {
+		return nil, err
+	}
+	if len(groups) == 0 {
+		return &profile.StacktraceSamples{
+			Samples: []*profile.Sample{},
+		}, nil
+	}
+
+	return groups[0].Samples, nil
+}
+
+// selectMergeGroups merges the profiles partitioned by the values of the
+// group columns, in a single scan of the table.
+func (q *ColumnQueryAPI) selectMergeGroups(ctx context.Context, m *pb.MergeProfile, groupColumns []string) ([]parcacol.StacktraceSamplesGroup, error) {
 	ctx, span := q.tracer.Start(ctx, "selectMerge")
+	span.SetAttributes(attribute.StringSlice("group_by", groupColumns))
 	defer span.End()
 
+	_, table, err := q.table(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	selectorExprs, err := queryToFilterExprs(m.Query)
 	if err != nil {
 		return nil, err
 	}
 
+	sampleExprs, err := sampleSelectorToFilterExprs(m.SampleSelector)
+	if err != nil {
+		return nil, err
+	}
+
 	start := timestamp.FromTime(m.Start.AsTime())
 	end := timestamp.FromTime(m.End.AsTime())
 
 	filterExpr := logicalplan.And(
 		append(
-			selectorExprs,
+			append(selectorExprs, sampleExprs...),
 			logicalplan.Col("timestamp").GT(logicalplan.Literal(start)),
 			logicalplan.Col("timestamp").LT(logicalplan.Literal(end)),
 		)...,
 	)
 
+	groupExprs := []logicalplan.Expr{logicalplan.Col("stacktrace")}
+	for _, column := range groupColumns {
+		groupExprs = append(groupExprs, logicalplan.Col(column))
+	}
+
 	var ar arrow.Record
-	err = q.engine.ScanTable(q.tableName).
+	err = q.engine.ScanTable(table).
 		Filter(filterExpr).
 		Aggregate(
 			logicalplan.Sum(logicalplan.Col("value")),
-			logicalplan.Col("stacktrace"),
+			groupExprs...,
 		).
 		Execute(ctx, func(r arrow.Record) error {
 			r.Retain()
@@ -650,10 +1474,10 @@ func (q *ColumnQueryAPI) selectMerge(ctx context.Context, m *pb.MergeProfile) (*
 	}
 	defer ar.Release()
 
-	return parcacol.ArrowRecordToStacktraceSamples(ctx, q.metaStore, ar, "sum(value)")
+	return parcacol.ArrowRecordToStacktraceSamplesGroups(ctx, q.metaStore, ar, "sum(value)", groupColumns)
 }
 
-func (q *ColumnQueryAPI) diffRequest(ctx context.Context, d *pb.DiffProfile, reportType pb.QueryRequest_ReportType) (*pb.QueryResponse, error) {
+func (q *ColumnQueryAPI) diffRequest(ctx context.Context, d *pb.DiffProfile, report reportOptions) (*pb.QueryResponse, error) {
 	ctx, span := q.tracer.Start(ctx, "diffRequest")
 	defer span.End()
 
@@ -696,7 +1520,7 @@ func (q *ColumnQueryAPI) diffRequest(ctx context.Context, d *pb.DiffProfile, rep
 		})
 	}
 
-	return q.renderReport(ctx, diff, reportType)
+	return q.renderReport(ctx, diff, report)
 }
 
 func (q *ColumnQueryAPI) selectProfileForDiff(ctx context.Context, s *pb.ProfileDiffSelection) (*profile.StacktraceSamples, error) {
@@ -715,3 +1539,33 @@ func (q *ColumnQueryAPI) selectProfileForDiff(ctx context.Context, s *pb.Profile
 
 	return p, err
 }
+
+// GetRawProfile returns a profile exactly as it was ingested.
+func (q *ColumnQueryAPI) GetRawProfile(ctx context.Context, req *pb.GetRawProfileRequest) (*pb.GetRawProfileResponse, error) {
+	if q.rawProfiles == nil {
+		return nil, status.Error(codes.FailedPrecondition, "raw profiles are not archived")
+	}
+
+	tenantID, err := tenant.FromContext(ctx)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	ls, err := parser.ParseMetric(req.Series)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to parse series: %v", err)
+	}
+	if req.Time == nil {
+		return nil, status.Error(codes.InvalidArgument, "time must be set")
+	}
+
+	b, err := q.rawProfiles.Get(ctx, tenantID, ls, timestamp.FromTime(req.Time.AsTime()))
+	if err != nil {
+		if errors.Is(err, rawprofile.ErrNotFound) {
+			return nil, status.Error(codes.NotFound, err.Error())
+		}
+		return nil, status.Errorf(codes.Internal, "failed to get raw profile: %v", err)
+	}
+
+	return &pb.GetRawProfileResponse{RawProfile: b}, nil
+}
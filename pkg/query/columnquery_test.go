@@ -17,6 +17,8 @@ import (
 	"bytes"
 	"context"
 	"io/ioutil"
+	"math"
+	"strings"
 	"testing"
 	"time"
 
@@ -30,14 +32,23 @@ import (
 	"github.com/prometheus/prometheus/model/labels"
 	"github.com/prometheus/prometheus/model/timestamp"
 	"github.com/stretchr/testify/require"
+	"github.com/thanos-io/objstore"
 	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/durationpb"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
 	metastorepb "github.com/parca-dev/parca/gen/proto/go/parca/metastore/v1alpha1"
 	pb "github.com/parca-dev/parca/gen/proto/go/parca/query/v1alpha1"
 	"github.com/parca-dev/parca/pkg/metastore"
 	"github.com/parca-dev/parca/pkg/parcacol"
+	pprofprofile "github.com/parca-dev/parca/pkg/profile"
+	"github.com/parca-dev/parca/pkg/rawprofile"
+	"github.com/parca-dev/parca/pkg/symbol/disasm"
+	"github.com/parca-dev/parca/pkg/tenant"
 )
 
 func TestColumnQueryAPIQueryRange(t *testing.T) {
@@ -100,7 +111,7 @@ func TestColumnQueryAPIQueryRange(t *testing.T) {
 			memory.DefaultAllocator,
 			colDB.TableProvider(),
 		),
-		"stacktraces",
+		parcacol.NewTables(logger, colDB, "stacktraces"),
 	)
 	res, err := api.QueryRange(ctx, &pb.QueryRangeRequest{
 		Query: `memory:alloc_objects:count:space:bytes{job="default"}`,
@@ -111,6 +122,318 @@ func TestColumnQueryAPIQueryRange(t *testing.T) {
 	require.Equal(t, 1, len(res.Series))
 	require.Equal(t, 1, len(res.Series[0].Labelset.Labels))
 	require.Equal(t, 10, len(res.Series[0].Samples))
+
+	samples := res.Series[0].Samples
+	var sum, max int64
+	for i, s := range samples {
+		if i > 0 {
+			require.True(t, samples[i-1].Timestamp.AsTime().Before(s.Timestamp.AsTime()))
+		}
+		sum += s.Value
+		if s.Value > max {
+			max = s.Value
+		}
+	}
+
+	// A single step covers all profiles.
+	first, last := samples[0].Timestamp.AsTime(), samples[len(samples)-1].Timestamp.AsTime()
+	for f, want := range map[pb.QueryRangeRequest_StepFunction]int64{
+		pb.QueryRangeRequest_STEP_FUNCTION_SUM_UNSPECIFIED: sum,
+		pb.QueryRangeRequest_STEP_FUNCTION_AVG:             int64(math.Round(float64(sum) / float64(len(samples)))),
+		pb.QueryRangeRequest_STEP_FUNCTION_MAX:             max,
+	} {
+		res, err = api.QueryRange(ctx, &pb.QueryRangeRequest{
+			Query:        `memory:alloc_objects:count:space:bytes{job="default"}`,
+			Start:        timestamppb.New(first.Add(-time.Millisecond)),
+			End:          timestamppb.New(last.Add(time.Millisecond)),
+			Step:         durationpb.New(last.Sub(first) + 2*time.Millisecond),
+			StepFunction: f,
+		})
+		require.NoError(t, err)
+		require.Equal(t, 1, len(res.Series))
+		require.Equal(t, 1, len(res.Series[0].Samples))
+		require.Equal(t, first.Add(-time.Millisecond), res.Series[0].Samples[0].Timestamp.AsTime())
+		require.Equal(t, want, res.Series[0].Samples[0].Value)
+	}
+
+	// Averages are rounded to the nearest integer.
+	rs := &rangeSeries{values: map[int64]int64{1: 1, 2: 2}}
+	require.Equal(t, int64(2), rs.stepSamples(0, 10, pb.QueryRangeRequest_STEP_FUNCTION_AVG)[0].Value)
+
+	_, err = api.QueryRange(ctx, &pb.QueryRangeRequest{
+		Query: `memory:alloc_objects:count:space:bytes{job="default"}`,
+		Start: timestamppb.New(first),
+		End:   timestamppb.New(first.Add(time.Hour)),
+		Step:  durationpb.New(time.Millisecond),
+	})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	// The series with the highest totals are kept.
+	fileContent, err := ioutil.ReadFile(dir + files[0].Name())
+	require.NoError(t, err)
+	p, err := profile.Parse(bytes.NewBuffer(fileContent))
+	require.NoError(t, err)
+	err = ingester.Ingest(ctx, labels.Labels{{
+		Name:  "__name__",
+		Value: "memory",
+	}, {
+		Name:  "job",
+		Value: "other",
+	}}, p, false)
+	require.NoError(t, err)
+
+	res, err = api.QueryRange(ctx, &pb.QueryRangeRequest{
+		Query: `memory:alloc_objects:count:space:bytes`,
+		Start: timestamppb.New(timestamp.Time(0)),
+		End:   timestamppb.New(timestamp.Time(9223372036854775807)),
+		Limit: 1,
+	})
+	require.NoError(t, err)
+	require.Equal(t, 1, len(res.Series))
+	require.Equal(t, "default", res.Series[0].Labelset.Labels[0].Value)
+
+	queryRange := func(query string) *pb.QueryRangeResponse {
+		res, err := api.QueryRange(ctx, &pb.QueryRangeRequest{
+			Query: query,
+			Start: timestamppb.New(timestamp.Time(0)),
+			End:   timestamppb.New(timestamp.Time(9223372036854775807)),
+		})
+		require.NoError(t, err)
+		return res
+	}
+	total := func(s *pb.MetricsSeries) int64 {
+		var total int64
+		for _, sample := range s.Samples {
+			total += sample.Value
+		}
+		return total
+	}
+
+	res = queryRange(`memory:alloc_objects:count:space:bytes`)
+	require.Equal(t, 2, len(res.Series))
+	want := total(res.Series[0]) + total(res.Series[1])
+
+	res = queryRange(`sum(memory:alloc_objects:count:space:bytes)`)
+	require.Equal(t, 1, len(res.Series))
+	require.Empty(t, res.Series[0].Labelset.Labels)
+	require.Equal(t, 10, len(res.Series[0].Samples))
+	require.Equal(t, want, total(res.Series[0]))
+
+	res = queryRange(`sum by (job) (memory:alloc_objects:count:space:bytes)`)
+	require.Equal(t, 2, len(res.Series))
+	require.Equal(t, "default", res.Series[0].Labelset.Labels[0].Value)
+	require.Equal(t, "other", res.Series[1].Labelset.Labels[0].Value)
+
+	res = queryRange(`sum without (job) (memory:alloc_objects:count:space:bytes)`)
+	require.Equal(t, 1, len(res.Series))
+	require.Empty(t, res.Series[0].Labelset.Labels)
+	require.Equal(t, want, total(res.Series[0]))
+
+	res = queryRange(`topk(1, sum by (job) (memory:alloc_objects:count:space:bytes))`)
+	require.Equal(t, 1, len(res.Series))
+	require.Equal(t, "default", res.Series[0].Labelset.Labels[0].Value)
+
+	// The values of the delta profiles within the range are summed and
+	// divided by the range.
+	base := time.Unix(0, p.TimeNanos).Add(time.Hour).Truncate(time.Millisecond).UTC()
+	p.DurationNanos = (10 * time.Second).Nanoseconds()
+	for i := 0; i < 6; i++ {
+		p.TimeNanos = base.Add(time.Duration(i) * 10 * time.Second).UnixNano()
+		err = ingester.Ingest(ctx, labels.Labels{{
+			Name:  "__name__",
+			Value: "memory",
+		}, {
+			Name:  "job",
+			Value: "delta",
+		}}, p, false)
+		require.NoError(t, err)
+	}
+
+	res = queryRange(`memory:alloc_objects:count:space:bytes:delta`)
+	require.Equal(t, 1, len(res.Series))
+	require.Equal(t, 6, len(res.Series[0].Samples))
+	value := res.Series[0].Samples[0].Value
+
+	rateRange := func(query string, step time.Duration) *pb.QueryRangeResponse {
+		req := &pb.QueryRangeRequest{
+			Query: query,
+			Start: timestamppb.New(base.Add(-time.Millisecond)),
+			End:   timestamppb.New(base.Add(time.Hour)),
+		}
+		if step > 0 {
+			req.Step = durationpb.New(step)
+		}
+		res, err := api.QueryRange(ctx, req)
+		require.NoError(t, err)
+		return res
+	}
+	rate := func(n int, rng time.Duration) float64 {
+		return float64(int64(n)*value) / rng.Seconds()
+	}
+	requireRate := func(want float64, sample *pb.MetricsSample) {
+		require.InDelta(t, want, sample.ValuePerSecond, 1e-9)
+		require.Equal(t, int64(math.Round(want)), sample.Value)
+	}
+
+	res = rateRange(`sum(rate(memory:alloc_objects:count:space:bytes:delta[1m]))`, 0)
+	require.Equal(t, 1, len(res.Series))
+	require.Equal(t, 1, len(res.Series[0].Samples))
+	require.Equal(t, base.Add(-time.Millisecond), res.Series[0].Samples[0].Timestamp.AsTime())
+	requireRate(rate(6, time.Minute), res.Series[0].Samples[0])
+
+	// Windows overlap if the step is shorter than the range.
+	res = rateRange(`rate(memory:alloc_objects:count:space:bytes:delta[1m])`, 30*time.Second)
+	require.Equal(t, 1, len(res.Series))
+	require.Equal(t, 2, len(res.Series[0].Samples))
+	requireRate(rate(6, time.Minute), res.Series[0].Samples[0])
+	require.Equal(t, base.Add(30*time.Second-time.Millisecond), res.Series[0].Samples[1].Timestamp.AsTime())
+	requireRate(rate(3, time.Minute), res.Series[0].Samples[1])
+
+	// The default step is the range.
+	res = rateRange(`rate(memory:alloc_objects:count:space:bytes:delta[20s])`, 0)
+	require.Equal(t, 1, len(res.Series))
+	require.Equal(t, 3, len(res.Series[0].Samples))
+	for _, sample := range res.Series[0].Samples {
+		requireRate(rate(2, 20*time.Second), sample)
+	}
+
+	// Rates below one per second are not lost to rounding.
+	rs = &rangeSeries{values: map[int64]int64{0: 1}}
+	samples = rs.rateSamples(0, 10000, 10000)
+	require.Equal(t, 0.1, samples[0].ValuePerSecond)
+	require.Equal(t, int64(0), samples[0].Value)
+
+	_, err = api.QueryRange(ctx, &pb.QueryRangeRequest{
+		Query:        `rate(memory:alloc_objects:count:space:bytes:delta[1m])`,
+		Start:        timestamppb.New(base.Add(-time.Millisecond)),
+		End:          timestamppb.New(base.Add(time.Hour)),
+		StepFunction: pb.QueryRangeRequest_STEP_FUNCTION_MAX,
+	})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	for _, query := range []string{
+		`rate(memory:alloc_objects:count:space:bytes[10s])`,
+		`topk(0, memory:alloc_objects:count:space:bytes)`,
+		`max(memory:alloc_objects:count:space:bytes)`,
+		`memory:alloc_objects:count:space:bytes offset 1m`,
+	} {
+		_, err := api.QueryRange(ctx, &pb.QueryRangeRequest{
+			Query: query,
+			Start: timestamppb.New(timestamp.Time(0)),
+			End:   timestamppb.New(timestamp.Time(9223372036854775807)),
+		})
+		require.Equal(t, codes.InvalidArgument, status.Code(err), query)
+	}
+}
+
+func TestColumnQueryAPISeries(t *testing.T) {
+	ctx := context.Background()
+	logger := log.NewNopLogger()
+	reg := prometheus.NewRegistry()
+	tracer := trace.NewNoopTracerProvider().Tracer("")
+	col := columnstore.New(
+		reg,
+		8196,
+		64*1024*1024,
+	)
+	colDB, err := col.DB("parca")
+	require.NoError(t, err)
+	table, err := colDB.Table(
+		"stacktraces",
+		columnstore.NewTableConfig(
+			parcacol.Schema(),
+		),
+		logger,
+	)
+	require.NoError(t, err)
+	m := metastore.NewBadgerMetastore(
+		logger,
+		reg,
+		tracer,
+		metastore.NewRandomUUIDGenerator(),
+	)
+	t.Cleanup(func() {
+		m.Close()
+	})
+
+	fileContent, err := ioutil.ReadFile("testdata/alloc_objects.pb.gz")
+	require.NoError(t, err)
+	p, err := profile.Parse(bytes.NewBuffer(fileContent))
+	require.NoError(t, err)
+
+	ingester := parcacol.NewIngester(logger, m, table)
+	for _, job := range []string{"default", "other"} {
+		err = ingester.Ingest(ctx, labels.Labels{{
+			Name:  "__name__",
+			Value: "memory",
+		}, {
+			Name:  "job",
+			Value: job,
+		}}, p, false)
+		require.NoError(t, err)
+	}
+
+	api := NewColumnQueryAPI(
+		logger,
+		tracer,
+		m,
+		query.NewEngine(
+			memory.DefaultAllocator,
+			colDB.TableProvider(),
+		),
+		parcacol.NewTables(logger, colDB, "stacktraces"),
+	)
+	start := timestamppb.New(timestamp.Time(0))
+	end := timestamppb.New(timestamp.Time(9223372036854775807))
+
+	res, err := api.Series(ctx, &pb.SeriesRequest{
+		Match: []string{`{job="default"}`},
+		Start: start,
+		End:   end,
+	})
+	require.NoError(t, err)
+	require.Equal(t, len(p.SampleType), len(res.Series))
+	require.False(t, res.Truncated)
+	for _, s := range res.Series {
+		require.Equal(t, "memory", s.ProfileType.Name)
+		require.Equal(t, "job", s.Labelset.Labels[0].Name)
+		require.Equal(t, "default", s.Labelset.Labels[0].Value)
+	}
+
+	res, err = api.Series(ctx, &pb.SeriesRequest{
+		Match: []string{`memory:alloc_objects:count:space:bytes{job="default"}`, `memory:alloc_objects:count:space:bytes{job="other"}`},
+		Start: start,
+		End:   end,
+	})
+	require.NoError(t, err)
+	require.Equal(t, 2, len(res.Series))
+	require.Equal(t, "alloc_objects", res.Series[0].ProfileType.SampleType)
+	require.Equal(t, "default", res.Series[0].Labelset.Labels[0].Value)
+	require.Equal(t, "other", res.Series[1].Labelset.Labels[0].Value)
+
+	res, err = api.Series(ctx, &pb.SeriesRequest{
+		Start: start,
+		End:   end,
+		Limit: 3,
+	})
+	require.NoError(t, err)
+	require.Equal(t, 3, len(res.Series))
+	require.True(t, res.Truncated)
+
+	// Profiles outside of the time range don't match.
+	res, err = api.Series(ctx, &pb.SeriesRequest{
+		Start: timestamppb.New(timestamp.Time(p.TimeNanos/time.Millisecond.Nanoseconds() + 1)),
+		End:   end,
+	})
+	require.NoError(t, err)
+	require.Empty(t, res.Series)
+
+	_, err = api.Series(ctx, &pb.SeriesRequest{
+		Match: []string{`{job=`},
+		Start: start,
+		End:   end,
+	})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
 }
 
 func TestColumnQueryAPIQuery(t *testing.T) {
@@ -166,7 +489,7 @@ func TestColumnQueryAPIQuery(t *testing.T) {
 			memory.DefaultAllocator,
 			colDB.TableProvider(),
 		),
-		"stacktraces",
+		parcacol.NewTables(logger, colDB, "stacktraces"),
 	)
 	ts := timestamppb.New(timestamp.Time(p.TimeNanos / time.Millisecond.Nanoseconds()))
 	res, err := api.Query(ctx, &pb.QueryRequest{
@@ -249,7 +572,7 @@ func TestColumnQueryAPIQueryFgprof(t *testing.T) {
 			memory.DefaultAllocator,
 			colDB.TableProvider(),
 		),
-		"stacktraces",
+		parcacol.NewTables(logger, colDB, "stacktraces"),
 	)
 	res, err := api.QueryRange(ctx, &pb.QueryRangeRequest{
 		Query: `fgprof:samples:count::`,
@@ -262,6 +585,404 @@ func TestColumnQueryAPIQueryFgprof(t *testing.T) {
 	require.Equal(t, 1, len(res.Series[0].Samples))
 }
 
+// newTestColumnQueryAPI returns a query API with the given options, and an
+// ingester writing to the table of the default tenant it queries.
+func newTestColumnQueryAPI(t testing.TB, opts ...Option) (*ColumnQueryAPI, *parcacol.Ingester) {
+	t.Helper()
+
+	logger := log.NewNopLogger()
+	reg := prometheus.NewRegistry()
+	tracer := trace.NewNoopTracerProvider().Tracer("")
+	col := columnstore.New(
+		reg,
+		8196,
+		64*1024*1024,
+	)
+	colDB, err := col.DB("parca")
+	require.NoError(t, err)
+	tables := parcacol.NewTables(logger, colDB, "stacktraces")
+	table, err := tables.Table(tenant.Default)
+	require.NoError(t, err)
+	m := metastore.NewBadgerMetastore(
+		logger,
+		reg,
+		tracer,
+		metastore.NewRandomUUIDGenerator(),
+	)
+	t.Cleanup(func() {
+		m.Close()
+	})
+
+	api := NewColumnQueryAPI(
+		logger,
+		tracer,
+		m,
+		query.NewEngine(
+			memory.DefaultAllocator,
+			colDB.TableProvider(),
+		),
+		tables,
+		opts...,
+	)
+	return api, parcacol.NewIngester(logger, m, table)
+}
+
+// pprofLabelsProfile returns a memory profile of which the samples of the
+// main and worker threads are 1 and 10 bytes, allocated in chunks of 512
+// bytes and 2MiB respectively, and the unlabeled samples are 100 bytes.
+func pprofLabelsProfile(ts time.Time) *profile.Profile {
+	mappings := []*profile.Mapping{{ID: 1, File: "/bin/app", BuildID: "2d6912fd3dd64542f6f6294f4bf9cb6c265b3085"}}
+	fns := []*profile.Function{{ID: 1, Name: "main", Filename: "/src/main.go"}, {ID: 2, Name: "work", Filename: "/src/work.go"}}
+	locs := []*profile.Location{
+		{ID: 1, Mapping: mappings[0], Address: 0x1, Line: []profile.Line{{Function: fns[0], Line: 1}}},
+		{ID: 2, Mapping: mappings[0], Address: 0x2, Line: []profile.Line{{Function: fns[1], Line: 2}}},
+	}
+	return &profile.Profile{
+		SampleType: []*profile.ValueType{{Type: "alloc_space", Unit: "bytes"}},
+		PeriodType: &profile.ValueType{Type: "space", Unit: "bytes"},
+		Period:     1,
+		TimeNanos:  ts.UnixNano(),
+		Mapping:    mappings,
+		Function:   fns,
+		Location:   locs,
+		Sample: []*profile.Sample{{
+			Location: locs[:1],
+			Value:    []int64{1},
+			Label:    map[string][]string{"thread": {"main"}},
+			NumLabel: map[string][]int64{"bytes": {512}},
+		}, {
+			Location: locs[1:],
+			Value:    []int64{10},
+			Label:    map[string][]string{"thread": {"worker"}},
+			NumLabel: map[string][]int64{"bytes": {2 << 20}},
+		}, {
+			Location: locs,
+			Value:    []int64{100},
+		}},
+	}
+}
+
+func TestColumnQueryAPISampleSelector(t *testing.T) {
+	ctx := context.Background()
+	api, ingester := newTestColumnQueryAPI(t)
+
+	ts := time.Now().Truncate(time.Millisecond)
+	err := ingester.Ingest(ctx, labels.FromStrings("__name__", "memory", "job", "default"), pprofLabelsProfile(ts), false)
+	require.NoError(t, err)
+
+	total := func(p *pprofprofile.StacktraceSamples) int64 {
+		var total int64
+		for _, s := range p.Samples {
+			total += s.Value
+		}
+		return total
+	}
+
+	for selector, want := range map[string]int64{
+		``:                              111,
+		`{thread="main"}`:               1,
+		`thread=~"w.*"`:                 10,
+		`{bytes>1048576}`:               10,
+		`{bytes >= 512, thread="main"}`: 1,
+	} {
+		merged, err := api.selectMerge(ctx, &pb.MergeProfile{
+			Query:          `memory:alloc_space:bytes:space:bytes{job="default"}`,
+			Start:          timestamppb.New(ts.Add(-time.Minute)),
+			End:            timestamppb.New(ts.Add(time.Minute)),
+			SampleSelector: selector,
+		})
+		require.NoError(t, err, selector)
+		require.Equal(t, want, total(merged), selector)
+	}
+
+	single, err := api.selectSingle(ctx, &pb.SingleProfile{
+		Query:          `memory:alloc_space:bytes:space:bytes{job="default"}`,
+		Time:           timestamppb.New(ts),
+		SampleSelector: `{thread="worker"}`,
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(10), total(single))
+
+	for _, selector := range []string{
+		`{thread>"main"}`,
+		`{bytes=~1}`,
+		`{thread="main" bytes>1}`,
+		`{1thread="main"}`,
+		`{thread=~"("}`,
+	} {
+		_, err := api.selectMerge(ctx, &pb.MergeProfile{
+			Query:          `memory:alloc_space:bytes:space:bytes{job="default"}`,
+			Start:          timestamppb.New(ts.Add(-time.Minute)),
+			End:            timestamppb.New(ts.Add(time.Minute)),
+			SampleSelector: selector,
+		})
+		require.Equal(t, codes.InvalidArgument, status.Code(err), selector)
+	}
+}
+
+func TestColumnQueryAPIMergeGroupBy(t *testing.T) {
+	ctx := context.Background()
+	api, ingester := newTestColumnQueryAPI(t)
+
+	ts := time.Now().Truncate(time.Millisecond)
+	for _, pod := range []string{"a", "b"} {
+		err := ingester.Ingest(ctx, labels.FromStrings("__name__", "memory", "job", "default", "pod", pod), pprofLabelsProfile(ts), false)
+		require.NoError(t, err)
+	}
+
+	merge := func(groupBy ...string) (*pb.QueryResponse, error) {
+		return api.Query(ctx, &pb.QueryRequest{
+			Mode: pb.QueryRequest_MODE_MERGE,
+			Options: &pb.QueryRequest_Merge{
+				Merge: &pb.MergeProfile{
+					Query:   `memory:alloc_space:bytes:space:bytes{job="default"}`,
+					Start:   timestamppb.New(ts.Add(-time.Minute)),
+					End:     timestamppb.New(ts.Add(time.Minute)),
+					GroupBy: groupBy,
+				},
+			},
+			ReportType: pb.QueryRequest_REPORT_TYPE_TOP,
+		})
+	}
+
+	type group struct {
+		labels string
+		total  int64
+	}
+	groups := func(res *pb.QueryResponse) []group {
+		gs := []group{}
+		for _, r := range res.GetGroups().Reports {
+			ls := []string{}
+			for _, l := range r.Labels {
+				ls = append(ls, l.Name+"="+l.Value)
+			}
+			var total int64
+			for _, l := range r.GetTop().List {
+				total += l.Flat
+			}
+			gs = append(gs, group{labels: strings.Join(ls, ","), total: total})
+		}
+		return gs
+	}
+
+	res, err := merge("pod")
+	require.NoError(t, err)
+	require.Equal(t, []group{
+		{labels: "pod=a", total: 111},
+		{labels: "pod=b", total: 111},
+	}, groups(res))
+
+	res, err = merge("pod", "pprof.thread")
+	require.NoError(t, err)
+	require.Equal(t, []group{
+		{labels: "pod=a", total: 100},
+		{labels: "pod=a,pprof.thread=main", total: 1},
+		{labels: "pod=a,pprof.thread=worker", total: 10},
+		{labels: "pod=b", total: 100},
+		{labels: "pod=b,pprof.thread=main", total: 1},
+		{labels: "pod=b,pprof.thread=worker", total: 10},
+	}, groups(res))
+
+	res, err = merge("missing")
+	require.NoError(t, err)
+	require.Equal(t, []group{{labels: "", total: 222}}, groups(res))
+
+	// Filters apply to the report of each group.
+	res, err = api.Query(ctx, &pb.QueryRequest{
+		Mode: pb.QueryRequest_MODE_MERGE,
+		Options: &pb.QueryRequest_Merge{
+			Merge: &pb.MergeProfile{
+				Query:   `memory:alloc_space:bytes:space:bytes{job="default"}`,
+				Start:   timestamppb.New(ts.Add(-time.Minute)),
+				End:     timestamppb.New(ts.Add(time.Minute)),
+				GroupBy: []string{"pod"},
+			},
+		},
+		ReportType: pb.QueryRequest_REPORT_TYPE_TOP,
+		Filter:     &pb.Filter{Focus: "^work$"},
+	})
+	require.NoError(t, err)
+	require.Equal(t, []group{
+		{labels: "pod=a", total: 110},
+		{labels: "pod=b", total: 110},
+	}, groups(res))
+
+	neighborhood := func(function string) (*pb.QueryResponse, error) {
+		return api.Query(ctx, &pb.QueryRequest{
+			Mode: pb.QueryRequest_MODE_MERGE,
+			Options: &pb.QueryRequest_Merge{
+				Merge: &pb.MergeProfile{
+					Query:   `memory:alloc_space:bytes:space:bytes{job="default"}`,
+					Start:   timestamppb.New(ts.Add(-time.Minute)),
+					End:     timestamppb.New(ts.Add(time.Minute)),
+					GroupBy: []string{"pod"},
+				},
+			},
+			ReportType: pb.QueryRequest_REPORT_TYPE_CALLGRAPH_NEIGHBORHOOD,
+			Function:   function,
+		})
+	}
+	res, err = neighborhood("work")
+	require.NoError(t, err)
+	require.Len(t, res.GetGroups().Reports, 2)
+	for _, r := range res.GetGroups().Reports {
+		n := r.GetCallgraphNeighborhood()
+		require.Equal(t, "work", n.Function.GetName())
+		require.Equal(t, int64(110), n.Cumulative)
+		require.Equal(t, int64(10), n.Flat)
+		require.Len(t, n.Callers, 0)
+		require.Len(t, n.Callees, 1)
+		require.Equal(t, "main", n.Callees[0].Function.GetName())
+	}
+
+	_, err = neighborhood("")
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	graph := func(opts *pb.GraphOptions) (*pb.QueryResponse, error) {
+		return api.Query(ctx, &pb.QueryRequest{
+			Mode: pb.QueryRequest_MODE_MERGE,
+			Options: &pb.QueryRequest_Merge{
+				Merge: &pb.MergeProfile{
+					Query: `memory:alloc_space:bytes:space:bytes{job="default"}`,
+					Start: timestamppb.New(ts.Add(-time.Minute)),
+					End:   timestamppb.New(ts.Add(time.Minute)),
+				},
+			},
+			ReportType:   pb.QueryRequest_REPORT_TYPE_GRAPH,
+			GraphOptions: opts,
+		})
+	}
+	res, err = graph(&pb.GraphOptions{NodeFraction: 0.005, Dot: true})
+	require.NoError(t, err)
+	require.Equal(t, int64(222), res.GetGraph().Total)
+	require.Len(t, res.GetGraph().Nodes, 2)
+	require.NotEmpty(t, res.GetGraph().Dot)
+
+	_, err = graph(&pb.GraphOptions{NodeFraction: 2})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	for _, groupBy := range [][]string{{"pod", "pod"}, {"pprof."}, {"1pod"}} {
+		_, err = merge(groupBy...)
+		require.Equal(t, codes.InvalidArgument, status.Code(err), groupBy)
+	}
+}
+
+type fakeSourceFetcher map[string]string
+
+func (f fakeSourceFetcher) FetchSource(ctx context.Context, buildID, filename string) ([]byte, error) {
+	src, ok := f[buildID+filename]
+	if !ok {
+		return nil, status.Error(codes.NotFound, "source file not found")
+	}
+	return []byte(src), nil
+}
+
+func TestColumnQueryAPISource(t *testing.T) {
+	ctx := context.Background()
+	buildID := "2d6912fd3dd64542f6f6294f4bf9cb6c265b3085"
+	api, ingester := newTestColumnQueryAPI(t, WithSources(fakeSourceFetcher{
+		buildID + "/src/work.go": "package main\n\nfunc work() {}\n",
+	}))
+
+	ts := time.Now().Truncate(time.Millisecond)
+	err := ingester.Ingest(ctx, labels.FromStrings("__name__", "memory", "job", "default"), pprofLabelsProfile(ts), false)
+	require.NoError(t, err)
+
+	source := func(opts *pb.SourceOptions) (*pb.QueryResponse, error) {
+		return api.Query(ctx, &pb.QueryRequest{
+			Mode: pb.QueryRequest_MODE_MERGE,
+			Options: &pb.QueryRequest_Merge{
+				Merge: &pb.MergeProfile{
+					Query: `memory:alloc_space:bytes:space:bytes{job="default"}`,
+					Start: timestamppb.New(ts.Add(-time.Minute)),
+					End:   timestamppb.New(ts.Add(time.Minute)),
+				},
+			},
+			ReportType:    pb.QueryRequest_REPORT_TYPE_SOURCE,
+			SourceOptions: opts,
+		})
+	}
+
+	res, err := source(&pb.SourceOptions{Filename: "work.go"})
+	require.NoError(t, err)
+	src := res.GetSource()
+	require.Equal(t, int64(111), src.Total)
+	require.Len(t, src.Files, 1)
+	require.Equal(t, "/src/work.go", src.Files[0].Filename)
+	require.Equal(t, buildID, src.Files[0].BuildId)
+	require.Equal(t, "package main\n\nfunc work() {}\n", src.Files[0].Source)
+	require.Equal(t, []*pb.SourceLine{{Line: 2, Flat: 10, Cumulative: 110}}, src.Files[0].Lines)
+
+	// Files without sources are reported without content.
+	res, err = source(&pb.SourceOptions{Function: "main"})
+	require.NoError(t, err)
+	src = res.GetSource()
+	require.Len(t, src.Files, 1)
+	require.Equal(t, "/src/main.go", src.Files[0].Filename)
+	require.Empty(t, src.Files[0].Source)
+	require.Equal(t, []*pb.SourceLine{{Line: 1, Flat: 101, Cumulative: 101}}, src.Files[0].Lines)
+
+	for _, opts := range []*pb.SourceOptions{nil, {Filename: "work.go", Function: "work"}} {
+		_, err = source(opts)
+		require.Equal(t, codes.InvalidArgument, status.Code(err), opts.String())
+	}
+}
+
+type fakeDisassembler map[string][]disasm.Instruction
+
+func (d fakeDisassembler) Disassemble(ctx context.Context, buildID string, addrs []uint64) ([]disasm.Instruction, error) {
+	insts, ok := d[buildID]
+	if !ok {
+		return nil, status.Error(codes.NotFound, "debug info not found")
+	}
+	return insts, nil
+}
+
+func TestColumnQueryAPIDisassembly(t *testing.T) {
+	ctx := context.Background()
+	buildID := "2d6912fd3dd64542f6f6294f4bf9cb6c265b3085"
+	api, ingester := newTestColumnQueryAPI(t, WithDisassembler(fakeDisassembler{
+		buildID: {
+			{Address: 0x1, Size: 1, Function: "main", Text: "nop"},
+			{Address: 0x2, Size: 4, Function: "work", Text: "ret"},
+		},
+	}))
+
+	ts := time.Now().Truncate(time.Millisecond)
+	err := ingester.Ingest(ctx, labels.FromStrings("__name__", "memory", "job", "default"), pprofLabelsProfile(ts), false)
+	require.NoError(t, err)
+
+	disassembly := func(function string) (*pb.QueryResponse, error) {
+		return api.Query(ctx, &pb.QueryRequest{
+			Mode: pb.QueryRequest_MODE_MERGE,
+			Options: &pb.QueryRequest_Merge{
+				Merge: &pb.MergeProfile{
+					Query: `memory:alloc_space:bytes:space:bytes{job="default"}`,
+					Start: timestamppb.New(ts.Add(-time.Minute)),
+					End:   timestamppb.New(ts.Add(time.Minute)),
+				},
+			},
+			ReportType: pb.QueryRequest_REPORT_TYPE_DISASSEMBLY,
+			Function:   function,
+		})
+	}
+
+	res, err := disassembly("work")
+	require.NoError(t, err)
+	d := res.GetDisassembly()
+	require.Equal(t, int64(111), d.Total)
+	require.Len(t, d.Functions, 1)
+	require.Equal(t, "work", d.Functions[0].Function.GetName())
+	require.Equal(t, buildID, d.Functions[0].Mapping.GetBuildId())
+	require.Equal(t, []*pb.DisassemblyInstruction{
+		{Address: 0x1, Text: "nop"},
+		{Address: 0x2, Text: "ret", Flat: 10, Cumulative: 110},
+	}, d.Functions[0].Instructions)
+
+	_, err = disassembly("")
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
 func TestColumnQueryAPIQueryDiff(t *testing.T) {
 	ctx := context.Background()
 	logger := log.NewNopLogger()
@@ -388,7 +1109,7 @@ func TestColumnQueryAPIQueryDiff(t *testing.T) {
 			memory.DefaultAllocator,
 			colDB.TableProvider(),
 		),
-		"stacktraces",
+		parcacol.NewTables(logger, colDB, "stacktraces"),
 	)
 
 	res, err := api.Query(ctx, &pb.QueryRequest{
@@ -547,7 +1268,7 @@ func TestColumnQueryAPITypes(t *testing.T) {
 			memory.DefaultAllocator,
 			colDB.TableProvider(),
 		),
-		"stacktraces",
+		parcacol.NewTables(logger, colDB, "stacktraces"),
 	)
 	res, err := api.ProfileTypes(ctx, &pb.ProfileTypesRequest{})
 	require.NoError(t, err)
@@ -618,7 +1339,7 @@ func TestColumnQueryAPILabelNames(t *testing.T) {
 			memory.DefaultAllocator,
 			colDB.TableProvider(),
 		),
-		"stacktraces",
+		parcacol.NewTables(logger, colDB, "stacktraces"),
 	)
 	res, err := api.Labels(ctx, &pb.LabelsRequest{})
 	require.NoError(t, err)
@@ -628,6 +1349,71 @@ func TestColumnQueryAPILabelNames(t *testing.T) {
 	}, res.LabelNames)
 }
 
+func TestColumnQueryAPILabelNamesWarnings(t *testing.T) {
+	ctx := context.Background()
+	logger := log.NewNopLogger()
+	reg := prometheus.NewRegistry()
+	tracer := trace.NewNoopTracerProvider().Tracer("")
+	col := columnstore.New(
+		reg,
+		8196,
+		64*1024*1024,
+	)
+	colDB, err := col.DB("parca")
+	require.NoError(t, err)
+	table, err := colDB.Table(
+		"stacktraces",
+		columnstore.NewTableConfig(
+			parcacol.Schema(),
+		),
+		logger,
+	)
+	require.NoError(t, err)
+	m := metastore.NewBadgerMetastore(
+		logger,
+		reg,
+		tracer,
+		metastore.NewRandomUUIDGenerator(),
+	)
+	t.Cleanup(func() {
+		m.Close()
+	})
+
+	fileContent, err := ioutil.ReadFile("testdata/alloc_objects.pb.gz")
+	require.NoError(t, err)
+	p, err := profile.Parse(bytes.NewBuffer(fileContent))
+	require.NoError(t, err)
+
+	limiter := parcacol.NewCardinalityLimiter(reg, parcacol.CardinalityLimits{
+		MaxLabelNamesPerProfileType: 1,
+		Strip:                       true,
+	})
+	ingester := parcacol.NewIngester(logger, m, table, parcacol.WithCardinalityLimiter(limiter))
+	err = ingester.Ingest(ctx, labels.FromStrings("__name__", "memory", "instance", "localhost", "job", "default"), p, false)
+	require.NoError(t, err)
+
+	api := NewColumnQueryAPI(
+		logger,
+		tracer,
+		m,
+		query.NewEngine(
+			memory.DefaultAllocator,
+			colDB.TableProvider(),
+		),
+		parcacol.NewTables(logger, colDB, "stacktraces"),
+		WithCardinalityLimiter(limiter),
+	)
+	res, err := api.Labels(ctx, &pb.LabelsRequest{})
+	require.NoError(t, err)
+
+	require.Equal(t, []string{
+		"instance",
+	}, res.LabelNames)
+	require.Equal(t, []string{
+		"profile type memory exceeded the label_names_per_profile_type limit, labels were stripped",
+	}, res.Warnings)
+}
+
 func TestColumnQueryAPILabelValues(t *testing.T) {
 	ctx := context.Background()
 	logger := log.NewNopLogger()
@@ -681,7 +1467,7 @@ func TestColumnQueryAPILabelValues(t *testing.T) {
 			memory.DefaultAllocator,
 			colDB.TableProvider(),
 		),
-		"stacktraces",
+		parcacol.NewTables(logger, colDB, "stacktraces"),
 	)
 	res, err := api.Values(ctx, &pb.ValuesRequest{
 		LabelName: "job",
@@ -692,3 +1478,236 @@ func TestColumnQueryAPILabelValues(t *testing.T) {
 		"default",
 	}, res.LabelValues)
 }
+
+func TestColumnQueryAPILabelsMatchers(t *testing.T) {
+	ctx := context.Background()
+	logger := log.NewNopLogger()
+	reg := prometheus.NewRegistry()
+	tracer := trace.NewNoopTracerProvider().Tracer("")
+	col := columnstore.New(
+		reg,
+		8196,
+		64*1024*1024,
+	)
+	colDB, err := col.DB("parca")
+	require.NoError(t, err)
+	table, err := colDB.Table(
+		"stacktraces",
+		columnstore.NewTableConfig(
+			parcacol.Schema(),
+		),
+		logger,
+	)
+	require.NoError(t, err)
+	m := metastore.NewBadgerMetastore(
+		logger,
+		reg,
+		tracer,
+		metastore.NewRandomUUIDGenerator(),
+	)
+	t.Cleanup(func() {
+		m.Close()
+	})
+
+	fileContent, err := ioutil.ReadFile("testdata/alloc_objects.pb.gz")
+	require.NoError(t, err)
+	p, err := profile.Parse(bytes.NewBuffer(fileContent))
+	require.NoError(t, err)
+
+	ingester := parcacol.NewIngester(logger, m, table)
+	for _, ls := range []labels.Labels{
+		labels.FromStrings("__name__", "memory", "job", "default", "instance", "a"),
+		labels.FromStrings("__name__", "memory", "job", "other", "region", "eu"),
+	} {
+		require.NoError(t, ingester.Ingest(ctx, ls, p, false))
+	}
+
+	api := NewColumnQueryAPI(
+		logger,
+		tracer,
+		m,
+		query.NewEngine(
+			memory.DefaultAllocator,
+			colDB.TableProvider(),
+		),
+		parcacol.NewTables(logger, colDB, "stacktraces"),
+	)
+	after := timestamppb.New(timestamp.Time(p.TimeNanos/time.Millisecond.Nanoseconds() + 1))
+
+	for _, tc := range []struct {
+		req      *pb.LabelsRequest
+		names    []string
+		warnings int
+	}{
+		{req: &pb.LabelsRequest{}, names: []string{"instance", "job", "region"}},
+		{req: &pb.LabelsRequest{Match: []string{`{job="default"}`}}, names: []string{"instance", "job"}},
+		{req: &pb.LabelsRequest{Match: []string{`memory:alloc_objects:count:space:bytes{job="other"}`}}, names: []string{"job", "region"}},
+		{req: &pb.LabelsRequest{Start: after}, names: []string{}},
+		{req: &pb.LabelsRequest{Prefix: "j"}, names: []string{"job"}},
+		{req: &pb.LabelsRequest{Limit: 1}, names: []string{"instance"}, warnings: 1},
+	} {
+		res, err := api.Labels(ctx, tc.req)
+		require.NoError(t, err)
+		require.Equal(t, tc.names, res.LabelNames, tc.req.String())
+		require.Len(t, res.Warnings, tc.warnings)
+	}
+
+	for _, tc := range []struct {
+		req      *pb.ValuesRequest
+		values   []string
+		warnings int
+	}{
+		{req: &pb.ValuesRequest{LabelName: "job", Match: []string{`{region="eu"}`}}, values: []string{"other"}},
+		{req: &pb.ValuesRequest{LabelName: "job", Start: after}, values: []string{}},
+		{req: &pb.ValuesRequest{LabelName: "job", Prefix: "o"}, values: []string{"other"}},
+		{req: &pb.ValuesRequest{LabelName: "job", Limit: 1}, values: []string{"default"}, warnings: 1},
+	} {
+		res, err := api.Values(ctx, tc.req)
+		require.NoError(t, err)
+		require.Equal(t, tc.values, res.LabelValues, tc.req.String())
+		require.Len(t, res.Warnings, tc.warnings)
+	}
+
+	_, err = api.Labels(ctx, &pb.LabelsRequest{Match: []string{`{job=`}})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestColumnQueryAPIGetRawProfile(t *testing.T) {
+	ctx := context.Background()
+	logger := log.NewNopLogger()
+	tracer := trace.NewNoopTracerProvider().Tracer("")
+
+	archive := rawprofile.NewArchive(objstore.NewInMemBucket())
+	err := archive.Put(ctx, tenant.Default, labels.FromStrings("__name__", "memory", "job", "default"), 1, []byte("profile"))
+	require.NoError(t, err)
+
+	api := NewColumnQueryAPI(logger, tracer, nil, nil, nil, WithRawProfiles(archive))
+	res, err := api.GetRawProfile(ctx, &pb.GetRawProfileRequest{
+		Series: `memory{job="default"}`,
+		Time:   timestamppb.New(timestamp.Time(1)),
+	})
+	require.NoError(t, err)
+	require.Equal(t, []byte("profile"), res.RawProfile)
+
+	_, err = api.GetRawProfile(ctx, &pb.GetRawProfileRequest{
+		Series: `memory{job="default"}`,
+		Time:   timestamppb.New(timestamp.Time(2)),
+	})
+	require.Equal(t, codes.NotFound, status.Code(err))
+
+	_, err = api.GetRawProfile(ctx, &pb.GetRawProfileRequest{
+		Series: `memory{job=~"default"}`,
+		Time:   timestamppb.New(timestamp.Time(1)),
+	})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	api = NewColumnQueryAPI(logger, tracer, nil, nil, nil)
+	_, err = api.GetRawProfile(ctx, &pb.GetRawProfileRequest{
+		Series: `memory{job="default"}`,
+		Time:   timestamppb.New(timestamp.Time(1)),
+	})
+	require.Equal(t, codes.FailedPrecondition, status.Code(err))
+}
+
+func TestColumnQueryAPITenants(t *testing.T) {
+	ctx := context.Background()
+	teamA := metadata.NewIncomingContext(ctx, metadata.Pairs(tenant.Header, "team-a"))
+	logger := log.NewNopLogger()
+	reg := prometheus.NewRegistry()
+	tracer := trace.NewNoopTracerProvider().Tracer("")
+	col := columnstore.New(
+		reg,
+		8196,
+		64*1024*1024,
+	)
+	colDB, err := col.DB("parca")
+	require.NoError(t, err)
+	tables := parcacol.NewTables(logger, colDB, "stacktraces", parcacol.WithTenants("team-a"))
+	_, err = tables.Table(tenant.Default)
+	require.NoError(t, err)
+	table, err := tables.Table("team-a")
+	require.NoError(t, err)
+	m := metastore.NewBadgerMetastore(
+		logger,
+		reg,
+		tracer,
+		metastore.NewRandomUUIDGenerator(),
+	)
+	t.Cleanup(func() {
+		m.Close()
+	})
+
+	fileContent, err := ioutil.ReadFile("testdata/alloc_objects.pb.gz")
+	require.NoError(t, err)
+	p, err := profile.Parse(bytes.NewBuffer(fileContent))
+	require.NoError(t, err)
+
+	ingester := parcacol.NewIngester(logger, m, table, parcacol.WithTenant("team-a"))
+	err = ingester.Ingest(ctx, labels.Labels{{
+		Name:  "__name__",
+		Value: "memory",
+	}, {
+		Name:  "job",
+		Value: "default",
+	}}, p, false)
+	require.NoError(t, err)
+
+	table.Sync()
+
+	archive := rawprofile.NewArchive(objstore.NewInMemBucket())
+	err = archive.Put(ctx, "team-a", labels.FromStrings("__name__", "memory", "job", "default"), 1, []byte("profile"))
+	require.NoError(t, err)
+
+	api := NewColumnQueryAPI(
+		logger,
+		tracer,
+		m,
+		query.NewEngine(
+			memory.DefaultAllocator,
+			colDB.TableProvider(),
+		),
+		tables,
+		WithRawProfiles(archive),
+	)
+
+	labelsRes, err := api.Labels(teamA, &pb.LabelsRequest{})
+	require.NoError(t, err)
+	require.Equal(t, []string{"job"}, labelsRes.LabelNames)
+	typesRes, err := api.ProfileTypes(teamA, &pb.ProfileTypesRequest{})
+	require.NoError(t, err)
+	require.NotEmpty(t, typesRes.Types)
+	rawRes, err := api.GetRawProfile(teamA, &pb.GetRawProfileRequest{
+		Series: `memory{job="default"}`,
+		Time:   timestamppb.New(timestamp.Time(1)),
+	})
+	require.NoError(t, err)
+	require.Equal(t, []byte("profile"), rawRes.RawProfile)
+
+	// The profiles of the tenant aren't visible to the default tenant.
+	labelsRes, err = api.Labels(ctx, &pb.LabelsRequest{})
+	require.NoError(t, err)
+	require.Empty(t, labelsRes.LabelNames)
+	typesRes, err = api.ProfileTypes(ctx, &pb.ProfileTypesRequest{})
+	require.NoError(t, err)
+	require.Empty(t, typesRes.Types)
+	_, err = api.GetRawProfile(ctx, &pb.GetRawProfileRequest{
+		Series: `memory{job="default"}`,
+		Time:   timestamppb.New(timestamp.Time(1)),
+	})
+	require.Equal(t, codes.NotFound, status.Code(err))
+
+	// Queries of tenants that didn't write profiles don't create tables.
+	_, err = api.Labels(
+		metadata.NewIncomingContext(ctx, metadata.Pairs(tenant.Header, "team-b")),
+		&pb.LabelsRequest{},
+	)
+	require.Equal(t, codes.NotFound, status.Code(err))
+	_, ok := tables.Lookup("team-b")
+	require.False(t, ok)
+
+	_, err = api.Labels(
+		metadata.NewIncomingContext(ctx, metadata.Pairs(tenant.Header, "team/a")),
+		&pb.LabelsRequest{},
+	)
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+}
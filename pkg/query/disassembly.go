@@ -0,0 +1,156 @@
+// Copyright 2022 The Parca Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package query
+
+import (
+	"context"
+	"sort"
+
+	metastorev1alpha1 "github.com/parca-dev/parca/gen/proto/go/parca/metastore/v1alpha1"
+	pb "github.com/parca-dev/parca/gen/proto/go/parca/query/v1alpha1"
+	parcaprofile "github.com/parca-dev/parca/pkg/profile"
+	"github.com/parca-dev/parca/pkg/symbol/disasm"
+)
+
+// Disassembler disassembles the functions of the binaries of build IDs.
+type Disassembler interface {
+	// Disassemble returns the instructions of the functions of the binary of
+	// the build ID that contain the addresses, and an error with the NotFound
+	// code if the binary isn't available.
+	Disassemble(ctx context.Context, buildID string, addrs []uint64) ([]disasm.Instruction, error)
+}
+
+type disassemblyKey struct {
+	buildID, file string
+}
+
+type disassemblyAddressKey struct {
+	binary  disassemblyKey
+	address uint64
+}
+
+// GenerateDisassembly returns the flat and cumulative values of the sampled
+// addresses of a function, referred to by its ID or name, per binary. The
+// addresses of locations into which the function was inlined are included.
+// Addresses are added once to the cumulative values of recursive stacktraces.
+// The instructions have no text, see addInstructions.
+func GenerateDisassembly(ctx context.Context, p *parcaprofile.StacktraceSamples, function string) (*pb.Disassembly, error) {
+	matches := functionMatcher(function)
+
+	res := &pb.Disassembly{
+		Unit: p.Meta.SampleType.Unit,
+	}
+	functions := map[disassemblyKey]*pb.DisassemblyFunction{}
+	addresses := map[disassemblyAddressKey]*pb.DisassemblyInstruction{}
+
+	seen := map[disassemblyAddressKey]struct{}{}
+	for _, s := range p.Samples {
+		res.Total += abs64(s.Value)
+		for k := range seen {
+			delete(seen, k)
+		}
+
+		for i, l := range s.Location {
+			var fn *metastorev1alpha1.Function
+			for _, line := range l.Lines {
+				if matches(line.Function) {
+					fn = line.Function
+					break
+				}
+			}
+			if fn == nil {
+				continue
+			}
+
+			key := disassemblyAddressKey{
+				binary: disassemblyKey{
+					buildID: l.Mapping.GetBuildId(),
+					file:    l.Mapping.GetFile(),
+				},
+				address: l.Address,
+			}
+			f, ok := functions[key.binary]
+			if !ok {
+				f = &pb.DisassemblyFunction{
+					Function: fn,
+					Mapping:  l.Mapping,
+				}
+				functions[key.binary] = f
+			}
+			inst, ok := addresses[key]
+			if !ok {
+				inst = &pb.DisassemblyInstruction{Address: l.Address}
+				addresses[key] = inst
+				f.Instructions = append(f.Instructions, inst)
+			}
+
+			if i == 0 {
+				inst.Flat += s.Value
+			}
+			if _, ok := seen[key]; !ok {
+				seen[key] = struct{}{}
+				inst.Cumulative += s.Value
+				inst.Diff += s.DiffValue
+			}
+		}
+	}
+
+	res.Functions = make([]*pb.DisassemblyFunction, 0, len(functions))
+	for _, f := range functions {
+		sort.Slice(f.Instructions, func(i, j int) bool {
+			return f.Instructions[i].Address < f.Instructions[j].Address
+		})
+		res.Functions = append(res.Functions, f)
+	}
+	sort.Slice(res.Functions, func(i, j int) bool {
+		a, b := res.Functions[i].Mapping, res.Functions[j].Mapping
+		if a.GetBuildId() != b.GetBuildId() {
+			return a.GetBuildId() < b.GetBuildId()
+		}
+		return a.GetFile() < b.GetFile()
+	})
+
+	return res, nil
+}
+
+// addInstructions replaces the sampled addresses of the function with the
+// disassembled instructions, which get the values of the addresses they
+// contain. Addresses outside of the instructions are kept without text.
+func addInstructions(f *pb.DisassemblyFunction, insts []disasm.Instruction) {
+	res := make([]*pb.DisassemblyInstruction, 0, len(insts))
+	for _, inst := range insts {
+		res = append(res, &pb.DisassemblyInstruction{
+			Address: inst.Address,
+			Text:    inst.Text,
+		})
+	}
+
+	for _, a := range f.Instructions {
+		i := sort.Search(len(insts), func(i int) bool {
+			return insts[i].Address+insts[i].Size > a.Address
+		})
+		if i < len(insts) && insts[i].Address <= a.Address {
+			res[i].Flat += a.Flat
+			res[i].Cumulative += a.Cumulative
+			res[i].Diff += a.Diff
+			continue
+		}
+		res = append(res, a)
+	}
+	sort.SliceStable(res, func(i, j int) bool {
+		return res[i].Address < res[j].Address
+	})
+
+	f.Instructions = res
+}
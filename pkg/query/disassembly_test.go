@@ -0,0 +1,100 @@
+// Copyright 2022 The Parca Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package query
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	pb "github.com/parca-dev/parca/gen/proto/go/parca/query/v1alpha1"
+	parcaprofile "github.com/parca-dev/parca/pkg/profile"
+	"github.com/parca-dev/parca/pkg/symbol/disasm"
+)
+
+func formatDisassembly(d *pb.Disassembly) []string {
+	res := []string{}
+	for _, f := range d.Functions {
+		for _, inst := range f.Instructions {
+			res = append(res, fmt.Sprintf("%s %s@%s:%#x %q %d/%d", f.Function.GetName(), f.Mapping.GetFile(), f.Mapping.GetBuildId(), inst.Address, inst.Text, inst.Flat, inst.Cumulative))
+		}
+	}
+	return res
+}
+
+func TestGenerateDisassembly(t *testing.T) {
+	ctx := context.Background()
+	p := stacktraceSamplesFromPprof(filterTestProfile())
+	p.Meta.SampleType = parcaprofile.ValueType{Type: "samples", Unit: "count"}
+	for _, s := range p.Samples {
+		for _, l := range s.Location {
+			if l.Mapping.File == "/bin/app" {
+				l.Mapping.BuildId = "aa"
+			}
+		}
+	}
+
+	// The recursive stacktrace adds to the cumulative value of compute once.
+	d, err := GenerateDisassembly(ctx, p, "compute")
+	require.NoError(t, err)
+	require.Equal(t, int64(112), d.Total)
+	require.Equal(t, "count", d.Unit)
+	require.Equal(t, []string{
+		`compute /bin/app@aa:0x4 "" 10/37`,
+	}, formatDisassembly(d))
+
+	// The addresses of functions into which a function was inlined are the
+	// addresses of the function.
+	d, err = GenerateDisassembly(ctx, p, "handle")
+	require.NoError(t, err)
+	require.Equal(t, []string{
+		`handle /bin/app@aa:0x3 "" 0/70`,
+	}, formatDisassembly(d))
+
+	d, err = GenerateDisassembly(ctx, p, "malloc")
+	require.NoError(t, err)
+	require.Equal(t, []string{
+		`malloc /lib/libc.so@:0x5 "" 57/57`,
+	}, formatDisassembly(d))
+
+	d, err = GenerateDisassembly(ctx, p, "nomatch")
+	require.NoError(t, err)
+	require.Empty(t, d.Functions)
+}
+
+func TestAddInstructions(t *testing.T) {
+	f := &pb.DisassemblyFunction{
+		Instructions: []*pb.DisassemblyInstruction{
+			{Address: 0x3, Flat: 0, Cumulative: 70},
+			{Address: 0x4, Flat: 10, Cumulative: 37},
+			{Address: 0x9, Flat: 1, Cumulative: 1},
+		},
+	}
+	addInstructions(f, []disasm.Instruction{
+		{Address: 0x2, Size: 2, Text: "a"},
+		{Address: 0x4, Size: 1, Text: "b"},
+		{Address: 0x5, Size: 3, Text: "c"},
+	})
+
+	// Addresses get the instructions containing them, addresses outside of the
+	// instructions are kept.
+	require.Equal(t, []string{
+		` @:0x2 "a" 0/70`,
+		` @:0x4 "b" 10/37`,
+		` @:0x5 "c" 0/0`,
+		` @:0x9 "" 1/1`,
+	}, formatDisassembly(&pb.Disassembly{Functions: []*pb.DisassemblyFunction{f}}))
+}
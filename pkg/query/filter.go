@@ -0,0 +1,310 @@
+// Copyright 2022 The Parca Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package query
+
+import (
+	"regexp"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/parca-dev/parca/gen/proto/go/parca/query/v1alpha1"
+	"github.com/parca-dev/parca/pkg/metastore"
+	"github.com/parca-dev/parca/pkg/profile"
+)
+
+// stackFilter filters the stacktraces of a profile with the semantics of the
+// -focus, -ignore, -hide, -show_from and -prune_from options of pprof, which
+// are applied in this order.
+//
+// Locations are shared by the samples of a profile, and by the profiles of
+// grouped merges, so they are never modified. Locations of which frames are
+// dropped are replaced by copies instead.
+type stackFilter struct {
+	focus     *regexp.Regexp
+	ignore    *regexp.Regexp
+	hide      *regexp.Regexp
+	showFrom  *regexp.Regexp
+	pruneFrom *regexp.Regexp
+}
+
+// newStackFilter returns the filter of the request, which is nil if it
+// doesn't filter anything.
+func newStackFilter(f *pb.Filter) (*stackFilter, error) {
+	if f == nil || f.Focus == "" && f.Ignore == "" && f.Hide == "" && f.ShowFrom == "" && f.PruneFrom == "" {
+		return nil, nil
+	}
+
+	var err error
+	sf := &stackFilter{}
+	sf.focus, err = compileFilterRegex("focus", f.Focus, err)
+	sf.ignore, err = compileFilterRegex("ignore", f.Ignore, err)
+	sf.hide, err = compileFilterRegex("hide", f.Hide, err)
+	sf.showFrom, err = compileFilterRegex("show_from", f.ShowFrom, err)
+	sf.pruneFrom, err = compileFilterRegex("prune_from", f.PruneFrom, err)
+	if err != nil {
+		return nil, err
+	}
+
+	return sf, nil
+}
+
+func compileFilterRegex(name, value string, err error) (*regexp.Regexp, error) {
+	if value == "" || err != nil {
+		return nil, err
+	}
+	re, err := regexp.Compile(value)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid %s regex: %v", name, err)
+	}
+	return re, nil
+}
+
+// apply returns the filtered profile. The samples of the profile are not
+// modified.
+func (f *stackFilter) apply(p *profile.StacktraceSamples) *profile.StacktraceSamples {
+	if f == nil {
+		return p
+	}
+
+	samples := f.filterByName(p.Samples)
+	if f.showFrom != nil {
+		samples = f.filterShowFrom(samples)
+	}
+	if f.pruneFrom != nil {
+		samples = f.filterPruneFrom(samples)
+	}
+
+	return &profile.StacktraceSamples{
+		Meta:    p.Meta,
+		Samples: samples,
+	}
+}
+
+// filterByName keeps the samples with a location matching focus but none
+// matching ignore, and drops the frames matching hide. Samples left without
+// locations are dropped.
+func (f *stackFilter) filterByName(samples []*profile.Sample) []*profile.Sample {
+	// focused is true for the locations matching focus, false for the ones
+	// matching ignore and unset for the others.
+	focused := map[*metastore.Location]bool{}
+	// hidden maps the locations matching hide to the copies without the
+	// matching frames, or to nil if no frames are left.
+	hidden := map[*metastore.Location]*metastore.Location{}
+	for _, s := range samples {
+		for _, l := range s.Location {
+			if _, ok := focused[l]; ok {
+				continue
+			}
+			if _, ok := hidden[l]; ok {
+				continue
+			}
+
+			if f.ignore != nil && locationMatches(l, f.ignore) {
+				focused[l] = false
+			} else if f.focus == nil || locationMatches(l, f.focus) {
+				focused[l] = true
+			}
+
+			if f.hide != nil && locationMatches(l, f.hide) {
+				hidden[l] = withoutMatchingLines(l, f.hide)
+			}
+		}
+	}
+
+	res := make([]*profile.Sample, 0, len(samples))
+	for _, s := range samples {
+		if !focusedAndNotIgnored(s.Location, focused) {
+			continue
+		}
+		if len(hidden) == 0 {
+			res = append(res, s)
+			continue
+		}
+
+		locs := make([]*metastore.Location, 0, len(s.Location))
+		for _, l := range s.Location {
+			if h, ok := hidden[l]; ok {
+				l = h
+			}
+			if l != nil {
+				locs = append(locs, l)
+			}
+		}
+		if len(locs) == 0 {
+			continue
+		}
+		res = append(res, withLocations(s, locs))
+	}
+
+	return res
+}
+
+func focusedAndNotIgnored(locs []*metastore.Location, focused map[*metastore.Location]bool) bool {
+	res := false
+	for _, l := range locs {
+		if f, ok := focused[l]; ok {
+			if !f {
+				return false
+			}
+			res = true
+		}
+	}
+	return res
+}
+
+// filterShowFrom drops the locations above the highest location matching
+// show_from, as well as the frames inlined into it that are above its highest
+// matching frame. Samples without a matching location are dropped.
+func (f *stackFilter) filterShowFrom(samples []*profile.Sample) []*profile.Sample {
+	// shown maps the matching locations to themselves, or to copies without
+	// the frames above the highest matching one.
+	shown := map[*metastore.Location]*metastore.Location{}
+	for _, s := range samples {
+		for _, l := range s.Location {
+			if _, ok := shown[l]; ok {
+				continue
+			}
+			if l.Mapping != nil && f.showFrom.MatchString(l.Mapping.File) {
+				shown[l] = l
+				continue
+			}
+			for i := len(l.Lines) - 1; i >= 0; i-- {
+				if lineMatches(l.Lines[i], f.showFrom) {
+					shown[l] = withLines(l, l.Lines[:i+1])
+					break
+				}
+			}
+		}
+	}
+
+	res := make([]*profile.Sample, 0, len(samples))
+	for _, s := range samples {
+		// Locations are ordered from the leaf to the root.
+		for i := len(s.Location) - 1; i >= 0; i-- {
+			if _, ok := shown[s.Location[i]]; !ok {
+				continue
+			}
+
+			locs := make([]*metastore.Location, i+1)
+			for j, l := range s.Location[:i+1] {
+				if sl, ok := shown[l]; ok {
+					l = sl
+				}
+				locs[j] = l
+			}
+			res = append(res, withLocations(s, locs))
+			break
+		}
+	}
+
+	return res
+}
+
+// filterPruneFrom drops the locations below the lowest location of which a
+// function name matches prune_from, as well as the frames inlined into it
+// below its lowest matching frame.
+func (f *stackFilter) filterPruneFrom(samples []*profile.Sample) []*profile.Sample {
+	// pruned maps the matching locations to copies without the frames below
+	// the lowest matching one.
+	pruned := map[*metastore.Location]*metastore.Location{}
+	checked := map[*metastore.Location]struct{}{}
+	for _, s := range samples {
+		for _, l := range s.Location {
+			if _, ok := checked[l]; ok {
+				continue
+			}
+			checked[l] = struct{}{}
+
+			for i, line := range l.Lines {
+				if line.Function != nil && line.Function.Name != "" && f.pruneFrom.MatchString(profile.SimplifyFunctionName(line.Function.Name)) {
+					pruned[l] = withLines(l, l.Lines[i:])
+					break
+				}
+			}
+		}
+	}
+
+	res := make([]*profile.Sample, 0, len(samples))
+	for _, s := range samples {
+		keep := s
+		for i, l := range s.Location {
+			if _, ok := pruned[l]; !ok {
+				continue
+			}
+
+			locs := make([]*metastore.Location, 0, len(s.Location)-i)
+			for _, l := range s.Location[i:] {
+				if pl, ok := pruned[l]; ok {
+					l = pl
+				}
+				locs = append(locs, l)
+			}
+			keep = withLocations(s, locs)
+			break
+		}
+		res = append(res, keep)
+	}
+
+	return res
+}
+
+// locationMatches returns whether the function name or filename of a frame of
+// the location, or the file of its mapping, match the regex.
+func locationMatches(l *metastore.Location, re *regexp.Regexp) bool {
+	for _, line := range l.Lines {
+		if lineMatches(line, re) {
+			return true
+		}
+	}
+	return l.Mapping != nil && re.MatchString(l.Mapping.File)
+}
+
+func lineMatches(line metastore.LocationLine, re *regexp.Regexp) bool {
+	return line.Function != nil && (re.MatchString(line.Function.Name) || re.MatchString(line.Function.Filename))
+}
+
+// withoutMatchingLines returns a copy of the location without the frames
+// matching the regex, or nil if none are left.
+func withoutMatchingLines(l *metastore.Location, re *regexp.Regexp) *metastore.Location {
+	if l.Mapping != nil && re.MatchString(l.Mapping.File) {
+		return nil
+	}
+
+	lines := make([]metastore.LocationLine, 0, len(l.Lines))
+	for _, line := range l.Lines {
+		if !lineMatches(line, re) {
+			lines = append(lines, line)
+		}
+	}
+	if len(lines) == 0 {
+		return nil
+	}
+	return withLines(l, lines)
+}
+
+func withLines(l *metastore.Location, lines []metastore.LocationLine) *metastore.Location {
+	if len(lines) == len(l.Lines) {
+		return l
+	}
+	c := *l
+	c.Lines = lines
+	return &c
+}
+
+func withLocations(s *profile.Sample, locs []*metastore.Location) *profile.Sample {
+	c := *s
+	c.Location = locs
+	return &c
+}
@@ -0,0 +1,243 @@
+// Copyright 2022 The Parca Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package query
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/google/pprof/profile"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	metapb "github.com/parca-dev/parca/gen/proto/go/parca/metastore/v1alpha1"
+	pb "github.com/parca-dev/parca/gen/proto/go/parca/query/v1alpha1"
+	"github.com/parca-dev/parca/pkg/metastore"
+	parcaprofile "github.com/parca-dev/parca/pkg/profile"
+)
+
+// filterTestProfile returns a profile with inlined frames, recursion, several
+// mappings and a C++ function with an argument list.
+func filterTestProfile() *profile.Profile {
+	app := &profile.Mapping{ID: 1, File: "/bin/app"}
+	libc := &profile.Mapping{ID: 2, File: "/lib/libc.so"}
+
+	fn := func(id uint64, name, filename string) *profile.Function {
+		return &profile.Function{ID: id, Name: name, Filename: filename}
+	}
+	mainFn := fn(1, "main", "main.go")
+	run := fn(2, "run", "app.go")
+	handle := fn(3, "handle", "app.go")
+	helper := fn(4, "inlinedHelper", "helper.go")
+	compute := fn(5, "compute", "compute.go")
+	malloc := fn(6, "malloc", "malloc.c")
+	bar := fn(7, "ns::Foo::bar(int)", "foo.cc")
+	mallocgc := fn(8, "runtime.mallocgc", "malloc.go")
+
+	loc := func(id uint64, m *profile.Mapping, fns ...*profile.Function) *profile.Location {
+		l := &profile.Location{ID: id, Mapping: m, Address: id}
+		for i, f := range fns {
+			l.Line = append(l.Line, profile.Line{Function: f, Line: int64(i + 1)})
+		}
+		return l
+	}
+	l1 := loc(1, app, mainFn)
+	l2 := loc(2, app, run)
+	// handle calls inlinedHelper, which was inlined.
+	l3 := loc(3, app, helper, handle)
+	l4 := loc(4, app, compute)
+	l5 := loc(5, libc, malloc)
+	l6 := loc(6, app, bar)
+	l7 := loc(7, app, mallocgc)
+
+	sample := func(v int64, locs ...*profile.Location) *profile.Sample {
+		return &profile.Sample{Value: []int64{v}, Location: locs}
+	}
+	return &profile.Profile{
+		SampleType: []*profile.ValueType{{Type: "samples", Unit: "count"}},
+		Sample: []*profile.Sample{
+			sample(10, l4, l3, l2, l1),
+			sample(20, l5, l4, l3, l2, l1),
+			sample(30, l5, l6, l1),
+			sample(40, l7, l3, l2, l1),
+			sample(5, l2, l1),
+			sample(7, l5, l4, l7, l4, l2, l1),
+		},
+		Mapping:  []*profile.Mapping{app, libc},
+		Function: []*profile.Function{mainFn, run, handle, helper, compute, malloc, bar, mallocgc},
+		Location: []*profile.Location{l1, l2, l3, l4, l5, l6, l7},
+	}
+}
+
+// stacktraceSamplesFromPprof converts a pprof profile, sharing the locations
+// among samples as the metastore does.
+func stacktraceSamplesFromPprof(p *profile.Profile) *parcaprofile.StacktraceSamples {
+	locs := map[uint64]*metastore.Location{}
+	for _, l := range p.Location {
+		ml := &metastore.Location{
+			ID:      uuid.New(),
+			Address: l.Address,
+			Mapping: &metapb.Mapping{File: l.Mapping.File},
+		}
+		for _, line := range l.Line {
+			ml.Lines = append(ml.Lines, metastore.LocationLine{
+				Line:     line.Line,
+				Function: &metapb.Function{Name: line.Function.Name, Filename: line.Function.Filename},
+			})
+		}
+		locs[l.ID] = ml
+	}
+
+	res := &parcaprofile.StacktraceSamples{}
+	for _, s := range p.Sample {
+		sample := &parcaprofile.Sample{Value: s.Value[0]}
+		for _, l := range s.Location {
+			sample.Location = append(sample.Location, locs[l.ID])
+		}
+		res.Samples = append(res.Samples, sample)
+	}
+	return res
+}
+
+// The stacks are formatted from the leaf to the root, frames inlined into the
+// same location are joined by +.
+func formatPprofSamples(p *profile.Profile) []string {
+	res := []string{}
+	for _, s := range p.Sample {
+		frames := []string{}
+		for _, l := range s.Location {
+			names := []string{}
+			for _, line := range l.Line {
+				names = append(names, line.Function.Name)
+			}
+			frames = append(frames, strings.Join(names, "+"))
+		}
+		res = append(res, fmt.Sprintf("%d %s", s.Value[0], strings.Join(frames, ";")))
+	}
+	sort.Strings(res)
+	return res
+}
+
+func formatStacktraceSamples(p *parcaprofile.StacktraceSamples) []string {
+	res := []string{}
+	for _, s := range p.Samples {
+		frames := []string{}
+		for _, l := range s.Location {
+			names := []string{}
+			for _, line := range l.Lines {
+				names = append(names, line.Function.Name)
+			}
+			frames = append(frames, strings.Join(names, "+"))
+		}
+		res = append(res, fmt.Sprintf("%d %s", s.Value, strings.Join(frames, ";")))
+	}
+	sort.Strings(res)
+	return res
+}
+
+// pprofFilter applies the filter like pprof does.
+func pprofFilter(p *profile.Profile, f *pb.Filter) {
+	compile := func(s string) *regexp.Regexp {
+		if s == "" {
+			return nil
+		}
+		return regexp.MustCompile(s)
+	}
+	p.FilterSamplesByName(compile(f.Focus), compile(f.Ignore), compile(f.Hide), nil)
+	p.ShowFrom(compile(f.ShowFrom))
+	if re := compile(f.PruneFrom); re != nil {
+		p.PruneFrom(re)
+	}
+}
+
+func TestStackFilterMatchesPprof(t *testing.T) {
+	regexes := []string{
+		"",
+		"main",
+		"^run$",
+		"handle",
+		"inlinedHelper",
+		"helper|compute",
+		"compute",
+		"malloc",
+		"libc",
+		`app\.go`,
+		"Foo::bar$",
+		`bar\(int\)`,
+		"nomatch",
+	}
+	set := []func(f *pb.Filter, v string){
+		func(f *pb.Filter, v string) { f.Focus = v },
+		func(f *pb.Filter, v string) { f.Ignore = v },
+		func(f *pb.Filter, v string) { f.Hide = v },
+		func(f *pb.Filter, v string) { f.ShowFrom = v },
+		func(f *pb.Filter, v string) { f.PruneFrom = v },
+	}
+
+	// Every pair of options with every pair of regexes, which includes every
+	// single option.
+	for i := range set {
+		for j := i + 1; j < len(set); j++ {
+			for _, a := range regexes {
+				for _, b := range regexes {
+					f := &pb.Filter{}
+					set[i](f, a)
+					set[j](f, b)
+
+					expected := filterTestProfile()
+					pprofFilter(expected, f)
+
+					p := stacktraceSamplesFromPprof(filterTestProfile())
+					before := formatStacktraceSamples(p)
+					sf, err := newStackFilter(f)
+					require.NoError(t, err)
+					filtered := sf.apply(p)
+
+					require.Equal(t, formatPprofSamples(expected), formatStacktraceSamples(filtered), f.String())
+					require.Equal(t, before, formatStacktraceSamples(p), "input modified by %s", f.String())
+				}
+			}
+		}
+	}
+}
+
+func TestStackFilter(t *testing.T) {
+	p := stacktraceSamplesFromPprof(filterTestProfile())
+
+	sf, err := newStackFilter(&pb.Filter{})
+	require.NoError(t, err)
+	require.Nil(t, sf)
+	require.Equal(t, p, sf.apply(p))
+
+	sf, err = newStackFilter(&pb.Filter{
+		Focus:     "handle",
+		Ignore:    "libc",
+		Hide:      "inlinedHelper",
+		ShowFrom:  "^run$",
+		PruneFrom: "handle",
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{
+		"10 handle;run",
+		"40 handle;run",
+	}, formatStacktraceSamples(sf.apply(p)))
+
+	_, err = newStackFilter(&pb.Filter{Hide: "("})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+}
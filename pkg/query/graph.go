@@ -0,0 +1,316 @@
+// Copyright 2022 The Parca Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package query
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	pb "github.com/parca-dev/parca/gen/proto/go/parca/query/v1alpha1"
+	parcaprofile "github.com/parca-dev/parca/pkg/profile"
+)
+
+// GenerateGraph returns the call graph of the profile. Like pprof, the nodes
+// below the node fraction are dropped first, and the graph is built again
+// from the remaining nodes, so that calls through dropped nodes become
+// residual edges. The edges below the edge fraction are dropped afterwards.
+func GenerateGraph(ctx context.Context, p *parcaprofile.StacktraceSamples, opts *pb.GraphOptions) (*pb.Graph, error) {
+	var total int64
+	for _, s := range p.Samples {
+		total += abs64(s.Value)
+	}
+	nodeCutoff := int64(float64(total) * float64(opts.GetNodeFraction()))
+	edgeCutoff := int64(float64(total) * float64(opts.GetEdgeFraction()))
+
+	g := newCallGraph(p, nil)
+	var droppedNodes int
+	if nodeCutoff > 0 {
+		kept := map[string]bool{}
+		for key, n := range g.nodes {
+			if abs64(n.node.Cumulative) >= nodeCutoff {
+				kept[key] = true
+			}
+		}
+		if len(kept) < len(g.nodes) {
+			droppedNodes = len(g.nodes) - len(kept)
+			g = newCallGraph(p, kept)
+		}
+	}
+
+	var droppedEdges int
+	for pair, e := range g.edges {
+		if abs64(e.Weight) < edgeCutoff {
+			delete(g.edges, pair)
+			droppedEdges++
+		}
+	}
+
+	res := g.build()
+	res.Total = total
+	res.Unit = p.Meta.SampleType.Unit
+	res.DroppedNodes = int32(droppedNodes)
+	res.DroppedEdges = int32(droppedEdges)
+	if opts.GetDot() {
+		res.Dot = graphToDot(res)
+	}
+
+	return res, nil
+}
+
+type callGraphNode struct {
+	key  string
+	node *pb.GraphNode
+}
+
+type callGraphEdge struct {
+	source, target string
+}
+
+type callGraph struct {
+	nodes map[string]*callGraphNode
+	edges map[callGraphEdge]*pb.GraphEdge
+}
+
+// newCallGraph returns the call graph of the profile, which only has the kept
+// nodes unless kept is nil.
+func newCallGraph(p *parcaprofile.StacktraceSamples, kept map[string]bool) *callGraph {
+	g := &callGraph{
+		nodes: map[string]*callGraphNode{},
+		edges: map[callGraphEdge]*pb.GraphEdge{},
+	}
+
+	var frames []stackFrame
+	seenNodes := map[*callGraphNode]struct{}{}
+	seenEdges := map[callGraphEdge]struct{}{}
+	for _, s := range p.Samples {
+		if s.Value == 0 {
+			continue
+		}
+		frames = stackFrames(frames[:0], s.Location)
+		for n := range seenNodes {
+			delete(seenNodes, n)
+		}
+		for e := range seenEdges {
+			delete(seenEdges, e)
+		}
+
+		var parent *callGraphNode
+		// A residual edge goes through one or more nodes that were not kept.
+		residual := false
+		for i := len(frames) - 1; i >= 0; i-- {
+			f := frames[i]
+			if kept != nil && !kept[f.key()] {
+				residual = true
+				continue
+			}
+
+			n := g.node(f)
+			// Samples are added once to the nodes and edges of recursive
+			// stacktraces.
+			if _, ok := seenNodes[n]; !ok {
+				seenNodes[n] = struct{}{}
+				n.node.Cumulative += s.Value
+				n.node.Diff += s.DiffValue
+			}
+			if parent != nil && n != parent {
+				pair := callGraphEdge{source: parent.key, target: n.key}
+				if _, ok := seenEdges[pair]; !ok {
+					seenEdges[pair] = struct{}{}
+					g.addEdge(pair, s.Value, residual, f.inlined)
+				}
+			}
+			parent = n
+			residual = false
+		}
+		if parent != nil && !residual {
+			parent.node.Flat += s.Value
+		}
+	}
+
+	return g
+}
+
+func (g *callGraph) node(f stackFrame) *callGraphNode {
+	key := f.key()
+	n, ok := g.nodes[key]
+	if !ok {
+		n = &callGraphNode{
+			key: key,
+			node: &pb.GraphNode{
+				Function: f.function,
+				Mapping:  f.mapping,
+			},
+		}
+		if f.function.GetName() == "" {
+			n.node.Address = f.address
+		}
+		g.nodes[key] = n
+		return n
+	}
+
+	if n.node.Mapping != nil && !bytes.Equal(n.node.Mapping.GetId(), f.mapping.GetId()) {
+		n.node.Mapping = nil
+	}
+	return n
+}
+
+// addEdge adds the weight to the edge, which is residual if any call is, and
+// inlined only if all calls are.
+func (g *callGraph) addEdge(pair callGraphEdge, weight int64, residual, inline bool) {
+	e, ok := g.edges[pair]
+	if !ok {
+		g.edges[pair] = &pb.GraphEdge{
+			Weight:   weight,
+			Residual: residual,
+			Inline:   inline,
+		}
+		return
+	}
+
+	e.Weight += weight
+	if residual {
+		e.Residual = true
+	}
+	if !inline {
+		e.Inline = false
+	}
+}
+
+// build returns the graph with the nodes sorted by flat value, cumulative
+// value and key, and the edges sorted by weight.
+func (g *callGraph) build() *pb.Graph {
+	nodes := make([]*callGraphNode, 0, len(g.nodes))
+	for _, n := range g.nodes {
+		// Nodes of samples of which all values are zero.
+		if n.node.Cumulative == 0 && n.node.Flat == 0 {
+			continue
+		}
+		nodes = append(nodes, n)
+	}
+	sort.Slice(nodes, func(i, j int) bool {
+		a, b := nodes[i].node, nodes[j].node
+		if a.Flat != b.Flat {
+			return abs64(a.Flat) > abs64(b.Flat)
+		}
+		if a.Cumulative != b.Cumulative {
+			return abs64(a.Cumulative) > abs64(b.Cumulative)
+		}
+		return nodes[i].key < nodes[j].key
+	})
+
+	res := &pb.Graph{
+		Nodes: make([]*pb.GraphNode, 0, len(nodes)),
+		Edges: make([]*pb.GraphEdge, 0, len(g.edges)),
+	}
+	indices := make(map[string]uint32, len(nodes))
+	for i, n := range nodes {
+		indices[n.key] = uint32(i)
+		res.Nodes = append(res.Nodes, n.node)
+	}
+	for pair, e := range g.edges {
+		source, ok := indices[pair.source]
+		if !ok {
+			continue
+		}
+		target, ok := indices[pair.target]
+		if !ok {
+			continue
+		}
+		e.Source = source
+		e.Target = target
+		res.Edges = append(res.Edges, e)
+	}
+	sort.Slice(res.Edges, func(i, j int) bool {
+		a, b := res.Edges[i], res.Edges[j]
+		if a.Weight != b.Weight {
+			return abs64(a.Weight) > abs64(b.Weight)
+		}
+		if a.Source != b.Source {
+			return a.Source < b.Source
+		}
+		return a.Target < b.Target
+	})
+
+	return res
+}
+
+// graphToDot renders the graph in the DOT language, styled like the graphs of
+// pprof: the font size of the nodes grows with their flat value and the width
+// of the edges with their weight. Residual edges are dotted.
+func graphToDot(g *pb.Graph) string {
+	var maxFlat int64
+	for _, n := range g.Nodes {
+		if abs64(n.Flat) > maxFlat {
+			maxFlat = abs64(n.Flat)
+		}
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "digraph %q {\n", g.Unit)
+	b.WriteString("node [style=filled fillcolor=\"#f8f8f8\" shape=box]\n")
+	for i, n := range g.Nodes {
+		fontSize := 8.0
+		if maxFlat > 0 {
+			fontSize += math.Ceil(16 * math.Sqrt(float64(abs64(n.Flat))/float64(maxFlat)))
+		}
+		label := fmt.Sprintf("%s\n%s", graphNodeName(n), graphValue(n.Flat, g.Total))
+		if n.Cumulative != n.Flat {
+			label += fmt.Sprintf("\nof %s", graphValue(n.Cumulative, g.Total))
+		}
+		fmt.Fprintf(&b, "N%d [label=%q fontsize=%d]\n", i, label, int(fontSize))
+	}
+	for _, e := range g.Edges {
+		penWidth := 1.0
+		if g.Total > 0 {
+			penWidth += 5 * float64(abs64(e.Weight)) / float64(g.Total)
+		}
+		label := fmt.Sprintf(" %d", e.Weight)
+		if e.Inline {
+			label += " (inline)"
+		}
+		attrs := fmt.Sprintf("label=%q weight=%d penwidth=%.2f", label, e.Weight, penWidth)
+		if e.Residual {
+			attrs += " style=dotted"
+		}
+		fmt.Fprintf(&b, "N%d -> N%d [%s]\n", e.Source, e.Target, attrs)
+	}
+	b.WriteString("}\n")
+
+	return b.String()
+}
+
+func graphNodeName(n *pb.GraphNode) string {
+	if name := n.Function.GetName(); name != "" {
+		return name
+	}
+	return fmt.Sprintf("%#x", n.Address)
+}
+
+func graphValue(v, total int64) string {
+	if total == 0 {
+		return fmt.Sprintf("%d", v)
+	}
+	return fmt.Sprintf("%d (%.2f%%)", v, 100*float64(v)/float64(total))
+}
+
+func abs64(i int64) int64 {
+	if i < 0 {
+		return -i
+	}
+	return i
+}
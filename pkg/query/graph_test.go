@@ -0,0 +1,130 @@
+// Copyright 2022 The Parca Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package query
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	pb "github.com/parca-dev/parca/gen/proto/go/parca/query/v1alpha1"
+	parcaprofile "github.com/parca-dev/parca/pkg/profile"
+)
+
+func formatGraph(g *pb.Graph) ([]string, []string) {
+	nodes := []string{}
+	for _, n := range g.Nodes {
+		nodes = append(nodes, fmt.Sprintf("%s %d/%d", graphNodeName(n), n.Flat, n.Cumulative))
+	}
+	edges := []string{}
+	for _, e := range g.Edges {
+		edge := fmt.Sprintf("%s -> %s %d", graphNodeName(g.Nodes[e.Source]), graphNodeName(g.Nodes[e.Target]), e.Weight)
+		if e.Residual {
+			edge += " residual"
+		}
+		if e.Inline {
+			edge += " inline"
+		}
+		edges = append(edges, edge)
+	}
+	return nodes, edges
+}
+
+func TestGenerateGraph(t *testing.T) {
+	ctx := context.Background()
+	p := stacktraceSamplesFromPprof(filterTestProfile())
+	p.Meta.SampleType = parcaprofile.ValueType{Type: "samples", Unit: "count"}
+
+	g, err := GenerateGraph(ctx, p, nil)
+	require.NoError(t, err)
+	require.Equal(t, int64(112), g.Total)
+	require.Equal(t, "count", g.Unit)
+	require.Empty(t, g.Dot)
+
+	// The recursive stacktrace adds to compute and its call of malloc once.
+	nodes, edges := formatGraph(g)
+	require.Equal(t, []string{
+		"malloc 57/57",
+		"runtime.mallocgc 40/47",
+		"compute 10/37",
+		"run 5/82",
+		"main 0/112",
+		"handle 0/70",
+		"inlinedHelper 0/70",
+		"ns::Foo::bar(int) 0/30",
+	}, nodes)
+	require.Equal(t, []string{
+		"main -> run 82",
+		"run -> handle 70",
+		"handle -> inlinedHelper 70 inline",
+		"inlinedHelper -> runtime.mallocgc 40",
+		"main -> ns::Foo::bar(int) 30",
+		"inlinedHelper -> compute 30",
+		"ns::Foo::bar(int) -> malloc 30",
+		"compute -> malloc 27",
+		"runtime.mallocgc -> compute 7",
+		"compute -> runtime.mallocgc 7",
+		"run -> compute 7",
+	}, edges)
+
+	// Calls through dropped nodes become residual edges.
+	g, err = GenerateGraph(ctx, p, &pb.GraphOptions{
+		NodeFraction: 0.3,
+		EdgeFraction: 0.1,
+		Dot:          true,
+	})
+	require.NoError(t, err)
+	require.Equal(t, int32(1), g.DroppedNodes)
+	require.Equal(t, int32(3), g.DroppedEdges)
+	nodes, edges = formatGraph(g)
+	require.Equal(t, []string{
+		"malloc 57/57",
+		"runtime.mallocgc 40/47",
+		"compute 10/37",
+		"run 5/82",
+		"main 0/112",
+		"handle 0/70",
+		"inlinedHelper 0/70",
+	}, nodes)
+	require.Equal(t, []string{
+		"main -> run 82",
+		"run -> handle 70",
+		"handle -> inlinedHelper 70 inline",
+		"inlinedHelper -> runtime.mallocgc 40",
+		"main -> malloc 30 residual",
+		"inlinedHelper -> compute 30",
+		"compute -> malloc 27",
+	}, edges)
+
+	require.Equal(t, `digraph "count" {
+node [style=filled fillcolor="#f8f8f8" shape=box]
+N0 [label="malloc\n57 (50.89%)" fontsize=24]
+N1 [label="runtime.mallocgc\n40 (35.71%)\nof 47 (41.96%)" fontsize=22]
+N2 [label="compute\n10 (8.93%)\nof 37 (33.04%)" fontsize=15]
+N3 [label="run\n5 (4.46%)\nof 82 (73.21%)" fontsize=13]
+N4 [label="main\n0 (0.00%)\nof 112 (100.00%)" fontsize=8]
+N5 [label="handle\n0 (0.00%)\nof 70 (62.50%)" fontsize=8]
+N6 [label="inlinedHelper\n0 (0.00%)\nof 70 (62.50%)" fontsize=8]
+N4 -> N3 [label=" 82" weight=82 penwidth=4.66]
+N3 -> N5 [label=" 70" weight=70 penwidth=4.12]
+N5 -> N6 [label=" 70 (inline)" weight=70 penwidth=4.12]
+N6 -> N1 [label=" 40" weight=40 penwidth=2.79]
+N4 -> N0 [label=" 30" weight=30 penwidth=2.34 style=dotted]
+N6 -> N2 [label=" 30" weight=30 penwidth=2.34]
+N2 -> N0 [label=" 27" weight=27 penwidth=2.21]
+}
+`, g.Dot)
+}
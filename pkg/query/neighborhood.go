@@ -0,0 +1,205 @@
+// Copyright 2022 The Parca Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package query
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/google/uuid"
+
+	metastorev1alpha1 "github.com/parca-dev/parca/gen/proto/go/parca/metastore/v1alpha1"
+	pb "github.com/parca-dev/parca/gen/proto/go/parca/query/v1alpha1"
+	"github.com/parca-dev/parca/pkg/metastore"
+	parcaprofile "github.com/parca-dev/parca/pkg/profile"
+)
+
+// GenerateCallgraphNeighborhood returns the callers and callees of a function,
+// referred to by its ID or name, across all stacktraces of the profile.
+// Frames inlined into a location are separate frames.
+func GenerateCallgraphNeighborhood(ctx context.Context, p *parcaprofile.StacktraceSamples, function string) (*pb.CallgraphNeighborhood, error) {
+	matches := functionMatcher(function)
+
+	res := &pb.CallgraphNeighborhood{
+		Unit: p.Meta.SampleType.Unit,
+	}
+	callers := &neighborhoodNode{}
+	callees := &neighborhoodNode{}
+
+	var frames []stackFrame
+	for _, s := range p.Samples {
+		frames = stackFrames(frames[:0], s.Location)
+
+		lowest, highest := -1, -1
+		for i, f := range frames {
+			if matches(f.function) {
+				if lowest < 0 {
+					lowest = i
+				}
+				highest = i
+			}
+		}
+		if lowest < 0 {
+			continue
+		}
+
+		if res.Function == nil {
+			res.Function = frames[lowest].function
+		}
+		res.Cumulative += s.Value
+		res.Diff += s.DiffValue
+		var flat int64
+		if lowest == 0 {
+			flat = s.Value
+			res.Flat += s.Value
+		}
+
+		n := callers
+		for _, f := range frames[lowest+1:] {
+			n = n.child(f)
+			n.add(s.Value, s.DiffValue, flat)
+		}
+
+		n = callees
+		for i := highest - 1; i >= 0; i-- {
+			n = n.child(frames[i])
+			if i == 0 {
+				n.add(s.Value, s.DiffValue, s.Value)
+			} else {
+				n.add(s.Value, s.DiffValue, 0)
+			}
+		}
+	}
+
+	res.Callers = callers.build()
+	res.Callees = callees.build()
+
+	return res, nil
+}
+
+// functionMatcher returns whether functions are the function referred to by
+// its ID or name.
+func functionMatcher(function string) func(fn *metastorev1alpha1.Function) bool {
+	if id, err := uuid.Parse(function); err == nil {
+		return func(fn *metastorev1alpha1.Function) bool {
+			return fn != nil && bytes.Equal(fn.Id, id[:])
+		}
+	}
+	return func(fn *metastorev1alpha1.Function) bool {
+		return fn != nil && fn.Name == function
+	}
+}
+
+// stackFrame is a frame of a stacktrace.
+type stackFrame struct {
+	function *metastorev1alpha1.Function
+	mapping  *metastorev1alpha1.Mapping
+	address  uint64
+	line     int64
+	// inlined is true if the frame was inlined into its caller.
+	inlined bool
+}
+
+// stackFrames appends the frames of the locations to frames, from the leaf to
+// the root.
+func stackFrames(frames []stackFrame, locations []*metastore.Location) []stackFrame {
+	for _, l := range locations {
+		if len(l.Lines) == 0 {
+			frames = append(frames, stackFrame{mapping: l.Mapping, address: l.Address})
+			continue
+		}
+		for i, line := range l.Lines {
+			frames = append(frames, stackFrame{
+				function: line.Function,
+				mapping:  l.Mapping,
+				address:  l.Address,
+				line:     line.Line,
+				inlined:  i < len(l.Lines)-1,
+			})
+		}
+	}
+	return frames
+}
+
+// key aggregates frames by function name, and frames without function names
+// by address.
+func (f stackFrame) key() string {
+	if name := f.function.GetName(); name != "" {
+		return name
+	}
+	return fmt.Sprintf("%x:%x", f.mapping.GetId(), f.address)
+}
+
+type neighborhoodNode struct {
+	key      string
+	node     *pb.CallgraphNeighborhoodNode
+	children map[string]*neighborhoodNode
+}
+
+func (n *neighborhoodNode) child(f stackFrame) *neighborhoodNode {
+	key := f.key()
+	c, ok := n.children[key]
+	if !ok {
+		c = &neighborhoodNode{
+			key: key,
+			node: &pb.CallgraphNeighborhoodNode{
+				Function: f.function,
+				Mapping:  f.mapping,
+			},
+		}
+		if f.function.GetName() == "" {
+			c.node.Address = f.address
+		}
+		if n.children == nil {
+			n.children = map[string]*neighborhoodNode{}
+		}
+		n.children[key] = c
+		return c
+	}
+
+	if c.node.Mapping != nil && !bytes.Equal(c.node.Mapping.GetId(), f.mapping.GetId()) {
+		c.node.Mapping = nil
+	}
+	return c
+}
+
+func (n *neighborhoodNode) add(value, diff, flat int64) {
+	n.node.Cumulative += value
+	n.node.Diff += diff
+	n.node.Flat += flat
+}
+
+// build returns the children of the node, sorted by cumulative value and
+// then by key.
+func (n *neighborhoodNode) build() []*pb.CallgraphNeighborhoodNode {
+	children := make([]*neighborhoodNode, 0, len(n.children))
+	for _, c := range n.children {
+		children = append(children, c)
+	}
+	sort.Slice(children, func(i, j int) bool {
+		if children[i].node.Cumulative == children[j].node.Cumulative {
+			return children[i].key < children[j].key
+		}
+		return children[i].node.Cumulative > children[j].node.Cumulative
+	})
+
+	res := make([]*pb.CallgraphNeighborhoodNode, 0, len(children))
+	for _, c := range children {
+		c.node.Children = c.build()
+		res = append(res, c.node)
+	}
+	return res
+}
@@ -0,0 +1,105 @@
+// Copyright 2022 The Parca Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package query
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	pb "github.com/parca-dev/parca/gen/proto/go/parca/query/v1alpha1"
+	parcaprofile "github.com/parca-dev/parca/pkg/profile"
+)
+
+func formatNeighborhoodNodes(nodes []*pb.CallgraphNeighborhoodNode) string {
+	var b strings.Builder
+	var format func(nodes []*pb.CallgraphNeighborhoodNode, depth int)
+	format = func(nodes []*pb.CallgraphNeighborhoodNode, depth int) {
+		for _, n := range nodes {
+			fmt.Fprintf(&b, "%s%s %d/%d\n", strings.Repeat("  ", depth), n.Function.GetName(), n.Cumulative, n.Flat)
+			format(n.Children, depth+1)
+		}
+	}
+	format(nodes, 0)
+	return b.String()
+}
+
+func TestGenerateCallgraphNeighborhood(t *testing.T) {
+	ctx := context.Background()
+	p := stacktraceSamplesFromPprof(filterTestProfile())
+	p.Meta.SampleType = parcaprofile.ValueType{Type: "samples", Unit: "count"}
+
+	n, err := GenerateCallgraphNeighborhood(ctx, p, "compute")
+	require.NoError(t, err)
+	require.Equal(t, "compute", n.Function.GetName())
+	require.Equal(t, "count", n.Unit)
+	require.Equal(t, int64(37), n.Cumulative)
+	require.Equal(t, int64(10), n.Flat)
+
+	// The inlined frames are separate frames, the callers of the recursive
+	// stacktrace start at the lowest call of compute.
+	require.Equal(t, `inlinedHelper 30/10
+  handle 30/10
+    run 30/10
+      main 30/10
+runtime.mallocgc 7/0
+  compute 7/0
+    run 7/0
+      main 7/0
+`, formatNeighborhoodNodes(n.Callers))
+
+	// The callees of the recursive stacktrace start at the highest call of
+	// compute.
+	require.Equal(t, `malloc 20/20
+runtime.mallocgc 7/0
+  compute 7/0
+    malloc 7/7
+`, formatNeighborhoodNodes(n.Callees))
+
+	// Functions are referred to by name or ID.
+	id := uuid.New()
+	for _, s := range p.Samples {
+		for _, l := range s.Location {
+			for _, line := range l.Lines {
+				if line.Function.Name == "handle" {
+					line.Function.Id = id[:]
+				}
+			}
+		}
+	}
+	n, err = GenerateCallgraphNeighborhood(ctx, p, id.String())
+	require.NoError(t, err)
+	require.Equal(t, "handle", n.Function.GetName())
+	require.Equal(t, int64(70), n.Cumulative)
+	require.Equal(t, int64(0), n.Flat)
+	require.Equal(t, `run 70/0
+  main 70/0
+`, formatNeighborhoodNodes(n.Callers))
+	require.Equal(t, `inlinedHelper 70/0
+  runtime.mallocgc 40/40
+  compute 30/10
+    malloc 20/20
+`, formatNeighborhoodNodes(n.Callees))
+
+	n, err = GenerateCallgraphNeighborhood(ctx, p, "nomatch")
+	require.NoError(t, err)
+	require.Nil(t, n.Function)
+	require.Equal(t, int64(0), n.Cumulative)
+	require.Empty(t, n.Callers)
+	require.Empty(t, n.Callees)
+}
@@ -14,6 +14,7 @@
 package query
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"math"
@@ -32,9 +33,11 @@ import (
 	"go.opentelemetry.io/otel/trace"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
+	profilestorepb "github.com/parca-dev/parca/gen/proto/go/parca/profilestore/v1alpha1"
 	pb "github.com/parca-dev/parca/gen/proto/go/parca/query/v1alpha1"
 	"github.com/parca-dev/parca/pkg/metastore"
 	"github.com/parca-dev/parca/pkg/parcacol"
+	"github.com/parca-dev/parca/pkg/replay"
 )
 
 func Benchmark_Query_Merge(b *testing.B) {
@@ -106,7 +109,7 @@ func Benchmark_Query_Merge(b *testing.B) {
 					memory.NewGoAllocator(),
 					colDB.TableProvider(),
 				),
-				"stacktraces",
+				parcacol.NewTables(logger, colDB, "stacktraces"),
 			)
 			b.ResetTimer()
 
@@ -127,3 +130,92 @@ func Benchmark_Query_Merge(b *testing.B) {
 		})
 	}
 }
+
+// replayWriter returns a write function of a replay that ingests the profiles
+// with the ingester.
+func replayWriter(ingester *parcacol.Ingester) replay.WriteRawFunc {
+	return func(ctx context.Context, req *profilestorepb.WriteRawRequest) error {
+		for _, series := range req.Series {
+			ls := make(labels.Labels, 0, len(series.Labels.Labels))
+			for _, l := range series.Labels.Labels {
+				ls = append(ls, labels.Label{Name: l.Name, Value: l.Value})
+			}
+			for _, sample := range series.Samples {
+				p, err := profile.Parse(bytes.NewBuffer(sample.RawProfile))
+				if err != nil {
+					return err
+				}
+				if err := ingester.Ingest(ctx, ls, p, req.Normalized); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	}
+}
+
+// BenchmarkReplay replays the debug value log in testdata with the ingester,
+// and queries the replayed profiles.
+//
+// go test -bench=Replay -benchmem ./pkg/query
+func BenchmarkReplay(b *testing.B) {
+	ctx := context.Background()
+	logger := log.NewNopLogger()
+
+	profiles, err := replay.ReadDir("testdata/debug-value-log")
+	require.NoError(b, err)
+	require.NotEmpty(b, profiles)
+
+	b.Run("Ingest", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			b.StopTimer()
+			_, ingester := newTestColumnQueryAPI(b)
+			b.StartTimer()
+
+			stats, err := replay.Replay(ctx, logger, replayWriter(ingester), profiles)
+			require.NoError(b, err)
+			require.Zero(b, stats.Rejected)
+		}
+	})
+
+	api, ingester := newTestColumnQueryAPI(b)
+	_, err = replay.Replay(ctx, logger, replayWriter(ingester), profiles)
+	require.NoError(b, err)
+
+	start := timestamppb.New(profiles[0].Timestamp.Add(-time.Second))
+	end := timestamppb.New(profiles[len(profiles)-1].Timestamp.Add(time.Second))
+	const q = `memory:alloc_objects:count:space:bytes{job="parca"}`
+
+	b.Run("QueryRange", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			res, err := api.QueryRange(ctx, &pb.QueryRangeRequest{
+				Query: q,
+				Start: start,
+				End:   end,
+			})
+			require.NoError(b, err)
+			require.Len(b, res.Series, 1)
+			require.Len(b, res.Series[0].Samples, len(profiles))
+		}
+	})
+
+	b.Run("Merge", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_, err := api.Query(ctx, &pb.QueryRequest{
+				Mode: pb.QueryRequest_MODE_MERGE,
+				Options: &pb.QueryRequest_Merge{
+					Merge: &pb.MergeProfile{
+						Query: q,
+						Start: start,
+						End:   end,
+					},
+				},
+				ReportType: pb.QueryRequest_REPORT_TYPE_FLAMEGRAPH_UNSPECIFIED,
+			})
+			require.NoError(b, err)
+		}
+	})
+}
@@ -0,0 +1,188 @@
+// Copyright 2022 The Parca Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package query
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/apache/arrow/go/v8/arrow"
+	"github.com/apache/arrow/go/v8/arrow/array"
+	"github.com/polarsignals/arcticdb/query/logicalplan"
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/prometheus/prometheus/promql/parser"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/parca-dev/parca/pkg/parcacol"
+)
+
+// rangeExpr is the query of a range query. Besides a selector, it may
+// aggregate the series like PromQL does:
+//
+//	topk(k, sum by|without (labels) (rate(selector[range])))
+//
+// where each of topk, sum and rate is optional.
+type rangeExpr struct {
+	matchers []*labels.Matcher
+
+	// If rate is set, the values of the profiles within the range of every
+	// step are summed and divided by the range in seconds. The range is the
+	// default step.
+	rate      bool
+	rateRange time.Duration
+
+	// If sum is set, the series are summed by, or without, the grouping
+	// labels.
+	sum      bool
+	without  bool
+	grouping []string
+
+	// topk is zero unless only the k series with the highest totals are
+	// kept. Unlike in PromQL the totals are over the whole range of the
+	// query, not per step, so that the same series are kept at every step.
+	topk int
+}
+
+func parseRangeExpr(query string) (*rangeExpr, error) {
+	expr, err := parser.ParseExpr(query)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to parse query: %v", err)
+	}
+
+	e := &rangeExpr{}
+	expr = unparen(expr)
+	if agg, ok := expr.(*parser.AggregateExpr); ok && agg.Op == parser.TOPK {
+		if len(agg.Grouping) > 0 || agg.Without {
+			return nil, status.Error(codes.InvalidArgument, "topk does not support grouping")
+		}
+		k, ok := unparen(agg.Param).(*parser.NumberLiteral)
+		if !ok || k.Val < 1 || k.Val != float64(int(k.Val)) {
+			return nil, status.Error(codes.InvalidArgument, "the parameter of topk must be a positive integer")
+		}
+		e.topk = int(k.Val)
+		expr = unparen(agg.Expr)
+	}
+	if agg, ok := expr.(*parser.AggregateExpr); ok && agg.Op == parser.SUM {
+		e.sum = true
+		e.without = agg.Without
+		e.grouping = agg.Grouping
+		expr = unparen(agg.Expr)
+	}
+	if call, ok := expr.(*parser.Call); ok && call.Func.Name == "rate" {
+		ms, ok := call.Args[0].(*parser.MatrixSelector)
+		if !ok {
+			return nil, status.Error(codes.InvalidArgument, "the argument of rate must be a selector with a range")
+		}
+		e.rate = true
+		e.rateRange = ms.Range
+		expr = ms.VectorSelector
+	}
+
+	vs, ok := expr.(*parser.VectorSelector)
+	if !ok {
+		return nil, status.Errorf(codes.InvalidArgument, "unsupported query %q, only selectors optionally wrapped in rate, sum and topk are supported", query)
+	}
+	if vs.OriginalOffset != 0 || vs.Timestamp != nil || vs.StartOrEnd != 0 {
+		return nil, status.Error(codes.InvalidArgument, "offset and @ modifiers are not supported")
+	}
+	e.matchers = vs.LabelMatchers
+
+	if e.rate {
+		for _, m := range e.matchers {
+			if m.Name == labels.MetricName && !strings.HasSuffix(m.Value, ":delta") {
+				return nil, status.Error(codes.InvalidArgument, "rate is only supported for delta profiles")
+			}
+		}
+	}
+
+	return e, nil
+}
+
+func unparen(expr parser.Expr) parser.Expr {
+	for {
+		p, ok := expr.(*parser.ParenExpr)
+		if !ok {
+			return expr
+		}
+		expr = p.Expr
+	}
+}
+
+// groupExprs returns the expressions the values of the profiles are
+// aggregated by, the label columns of the series and the timestamp.
+func (q *ColumnQueryAPI) groupExprs(ctx context.Context, table string, e *rangeExpr) ([]logicalplan.Expr, error) {
+	exprs := []logicalplan.Expr{}
+	switch {
+	case !e.sum:
+		exprs = append(exprs, logicalplan.DynCol(parcacol.ColumnLabels))
+	case e.without:
+		names, err := q.labelNames(ctx, table)
+		if err != nil {
+			return nil, err
+		}
+		without := make(map[string]struct{}, len(e.grouping))
+		for _, name := range e.grouping {
+			without[name] = struct{}{}
+		}
+		for _, name := range names {
+			if _, ok := without[name]; !ok {
+				exprs = append(exprs, logicalplan.Col(parcacol.ColumnLabels+"."+name))
+			}
+		}
+	default:
+		for _, name := range e.grouping {
+			exprs = append(exprs, logicalplan.Col(parcacol.ColumnLabels+"."+name))
+		}
+	}
+
+	exprs = append(exprs, logicalplan.Col(parcacol.ColumnTimestamp))
+	return exprs, nil
+}
+
+// labelNames returns the names of the label columns of the table.
+func (q *ColumnQueryAPI) labelNames(ctx context.Context, table string) ([]string, error) {
+	seen := map[string]struct{}{}
+	err := q.engine.ScanSchema(table).
+		Distinct(logicalplan.Col("name")).
+		Filter(logicalplan.Col("name").RegexMatch("^labels\\..+$")).
+		Execute(ctx, func(ar arrow.Record) error {
+			if ar.NumCols() != 1 {
+				return fmt.Errorf("expected 1 column, got %d", ar.NumCols())
+			}
+
+			col := ar.Column(0)
+			stringCol, ok := col.(*array.String)
+			if !ok {
+				return fmt.Errorf("expected string column, got %T", col)
+			}
+
+			for i := 0; i < stringCol.Len(); i++ {
+				seen[strings.TrimPrefix(stringCol.Value(i), "labels.")] = struct{}{}
+			}
+
+			return nil
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(seen))
+	for name := range seen {
+		names = append(names, name)
+	}
+	return names, nil
+}
@@ -0,0 +1,177 @@
+// Copyright 2022 The Parca Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package query
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/polarsignals/arcticdb/query/logicalplan"
+	"github.com/prometheus/common/model"
+	"github.com/prometheus/prometheus/util/strutil"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/parca-dev/parca/pkg/parcacol"
+)
+
+// Operators of sample selectors, the ones that are prefixes of others come
+// last.
+var sampleSelectorOps = []string{"=~", "!~", "!=", "<=", ">=", "=", "<", ">"}
+
+// sampleSelectorToFilterExprs returns the filter expressions of a sample
+// selector, which selects samples by their pprof labels. It is a
+// comma-separated list of matchers, optionally enclosed in braces:
+//
+//	{thread="main", span_id=~"a.*", bytes>1048576}
+//
+// Quoted values are matched against string labels with =, !=, =~ and !~.
+// Integers are compared to numeric labels with =, !=, <, <=, > and >=.
+func sampleSelectorToFilterExprs(selector string) ([]logicalplan.Expr, error) {
+	s := strings.TrimSpace(selector)
+	if strings.HasPrefix(s, "{") && strings.HasSuffix(s, "}") {
+		s = strings.TrimSpace(s[1 : len(s)-1])
+	}
+
+	var exprs []logicalplan.Expr
+	for s != "" {
+		expr, rest, err := parseSampleMatcher(s)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid sample selector %q: %v", selector, err)
+		}
+		exprs = append(exprs, expr)
+
+		s = strings.TrimSpace(rest)
+		if s == "" {
+			break
+		}
+		if s[0] != ',' {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid sample selector %q: expected comma before %q", selector, s)
+		}
+		s = strings.TrimSpace(s[1:])
+	}
+
+	return exprs, nil
+}
+
+// parseSampleMatcher parses the matcher at the start of s and returns the
+// remainder of s.
+func parseSampleMatcher(s string) (logicalplan.Expr, string, error) {
+	i := 0
+	for i < len(s) && (s[i] == '_' || s[i] >= 'a' && s[i] <= 'z' || s[i] >= 'A' && s[i] <= 'Z' || i > 0 && s[i] >= '0' && s[i] <= '9') {
+		i++
+	}
+	name := s[:i]
+	if !model.LabelName(name).IsValid() {
+		return nil, "", fmt.Errorf("expected label name at %q", s)
+	}
+	s = strings.TrimSpace(s[i:])
+
+	var op string
+	for _, o := range sampleSelectorOps {
+		if strings.HasPrefix(s, o) {
+			op = o
+			break
+		}
+	}
+	if op == "" {
+		return nil, "", fmt.Errorf("expected operator after %q", name)
+	}
+	s = strings.TrimSpace(s[len(op):])
+
+	if s != "" && (s[0] == '"' || s[0] == '\'' || s[0] == '`') {
+		quoted, err := quotedPrefix(s)
+		if err != nil {
+			return nil, "", fmt.Errorf("invalid value of %q: %w", name, err)
+		}
+		value, err := strutil.Unquote(quoted)
+		if err != nil {
+			return nil, "", fmt.Errorf("invalid value of %q: %w", name, err)
+		}
+
+		col := logicalplan.Col(parcacol.ColumnPprofLabels + "." + name)
+		var expr logicalplan.Expr
+		switch op {
+		case "=":
+			expr = col.Eq(logicalplan.Literal(value))
+		case "!=":
+			expr = col.NotEq(logicalplan.Literal(value))
+		case "=~", "!~":
+			if _, err := regexp.Compile(value); err != nil {
+				return nil, "", fmt.Errorf("invalid regex of %q: %w", name, err)
+			}
+			if op == "=~" {
+				expr = col.RegexMatch(value)
+			} else {
+				expr = col.RegexNotMatch(value)
+			}
+		default:
+			return nil, "", fmt.Errorf("operator %s of %q requires an integer value", op, name)
+		}
+		return expr, s[len(quoted):], nil
+	}
+
+	j := strings.IndexAny(s, ", ")
+	if j < 0 {
+		j = len(s)
+	}
+	value, err := strconv.ParseInt(s[:j], 10, 64)
+	if err != nil {
+		return nil, "", fmt.Errorf("value of %q must be quoted or an integer, got %q", name, s[:j])
+	}
+
+	col := logicalplan.Col(parcacol.ColumnPprofNumLabels + "." + name)
+	var expr logicalplan.Expr
+	switch op {
+	case "=":
+		expr = col.Eq(logicalplan.Literal(value))
+	case "!=":
+		expr = col.NotEq(logicalplan.Literal(value))
+	case "<":
+		expr = col.LT(logicalplan.Literal(value))
+	case "<=":
+		expr = col.LTE(logicalplan.Literal(value))
+	case ">":
+		expr = col.GT(logicalplan.Literal(value))
+	case ">=":
+		expr = col.GTE(logicalplan.Literal(value))
+	default:
+		return nil, "", fmt.Errorf("operator %s of %q requires a quoted value", op, name)
+	}
+	return expr, s[j:], nil
+}
+
+// quotedPrefix returns the quoted string at the start of s. Like in PromQL,
+// values may be enclosed in double quotes, single quotes or backquotes, and
+// only backquoted values can't contain escape sequences.
+func quotedPrefix(s string) (string, error) {
+	q := s[0]
+	for i := 1; i < len(s); i++ {
+		switch s[i] {
+		case q:
+			return s[:i+1], nil
+		case '\\':
+			if q != '`' {
+				i++
+			}
+		case '\n':
+			if q != '`' {
+				return "", fmt.Errorf("unterminated quoted string %s", s)
+			}
+		}
+	}
+	return "", fmt.Errorf("unterminated quoted string %s", s)
+}
@@ -0,0 +1,70 @@
+// Copyright 2022 The Parca Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package query
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestSampleSelectorToFilterExprs(t *testing.T) {
+	testCases := []struct {
+		name     string
+		selector string
+		exprs    int
+		err      bool
+	}{
+		{name: "empty", selector: "", exprs: 0},
+		{name: "blank", selector: "  ", exprs: 0},
+		{name: "empty braces", selector: "{}", exprs: 0},
+		{name: "string", selector: `thread="main"`, exprs: 1},
+		{name: "braces", selector: `{thread!="main", span_id=~"a.*", bytes>=512}`, exprs: 3},
+		{name: "trailing comma", selector: `{thread="main",}`, exprs: 1},
+		{name: "single quotes", selector: `thread='main'`, exprs: 1},
+		{name: "backquotes", selector: "thread=~`ma.+`", exprs: 1},
+		{name: "escaped quotes", selector: `{thread="ma\"in", pool='it\'s'}`, exprs: 2},
+		{name: "unterminated double quote", selector: `thread="main`, err: true},
+		{name: "unterminated single quote", selector: `thread='main`, err: true},
+		{name: "unterminated backquote", selector: "thread=`main", err: true},
+		{name: "unknown operator", selector: `thread~"main"`, err: true},
+		{name: "doubled operator", selector: `thread=="main"`, err: true},
+		{name: "missing operator", selector: `thread`, err: true},
+		{name: "missing label name", selector: `="main"`, err: true},
+		{name: "missing value", selector: `thread=`, err: true},
+		{name: "comma only", selector: `,`, err: true},
+		{name: "empty matcher", selector: `thread="main",,bytes>1`, err: true},
+		{name: "missing comma", selector: `thread="main" bytes>1`, err: true},
+		{name: "ordering of string", selector: `thread<"main"`, err: true},
+		{name: "regex of integer", selector: `bytes=~512`, err: true},
+		{name: "invalid regex", selector: `thread=~"("`, err: true},
+		{name: "non-integer value", selector: `bytes>1.5`, err: true},
+		{name: "unbalanced brace", selector: `{thread="main"`, err: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			exprs, err := sampleSelectorToFilterExprs(tc.selector)
+			if tc.err {
+				require.Error(t, err)
+				require.Equal(t, codes.InvalidArgument, status.Code(err))
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, exprs, tc.exprs)
+		})
+	}
+}
@@ -0,0 +1,131 @@
+// Copyright 2022 The Parca Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package query
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	pb "github.com/parca-dev/parca/gen/proto/go/parca/query/v1alpha1"
+	parcaprofile "github.com/parca-dev/parca/pkg/profile"
+)
+
+// SourceFetcher fetches the source files of the binaries of build IDs.
+type SourceFetcher interface {
+	// FetchSource returns the content of the source file of the binary of the
+	// build ID, and an error with the NotFound code if it isn't available.
+	FetchSource(ctx context.Context, buildID, filename string) ([]byte, error)
+}
+
+type sourceFileKey struct {
+	buildID, filename string
+}
+
+type sourceLineKey struct {
+	file sourceFileKey
+	line int64
+}
+
+// GenerateSource returns the flat and cumulative values of the lines of the
+// selected source file or function, per build ID of the binary the file was
+// compiled into. Lines are added once to the cumulative values of recursive
+// stacktraces. The content of the files is not set.
+func GenerateSource(ctx context.Context, p *parcaprofile.StacktraceSamples, opts *pb.SourceOptions) (*pb.Source, error) {
+	var matches func(f stackFrame) bool
+	if opts.GetFunction() != "" {
+		matchesFunction := functionMatcher(opts.GetFunction())
+		matches = func(f stackFrame) bool {
+			return matchesFunction(f.function)
+		}
+	} else {
+		filename := opts.GetFilename()
+		matches = func(f stackFrame) bool {
+			name := f.function.GetFilename()
+			return name == filename || strings.HasSuffix(name, "/"+filename)
+		}
+	}
+
+	res := &pb.Source{
+		Unit: p.Meta.SampleType.Unit,
+	}
+	files := map[sourceFileKey]map[int64]*pb.SourceLine{}
+
+	var frames []stackFrame
+	seen := map[sourceLineKey]struct{}{}
+	for _, s := range p.Samples {
+		res.Total += abs64(s.Value)
+		frames = stackFrames(frames[:0], s.Location)
+		for k := range seen {
+			delete(seen, k)
+		}
+
+		for i, f := range frames {
+			if f.function == nil || !matches(f) {
+				continue
+			}
+
+			key := sourceLineKey{
+				file: sourceFileKey{
+					buildID:  f.mapping.GetBuildId(),
+					filename: f.function.GetFilename(),
+				},
+				line: f.line,
+			}
+			lines, ok := files[key.file]
+			if !ok {
+				lines = map[int64]*pb.SourceLine{}
+				files[key.file] = lines
+			}
+			line, ok := lines[key.line]
+			if !ok {
+				line = &pb.SourceLine{Line: key.line}
+				lines[key.line] = line
+			}
+
+			if i == 0 {
+				line.Flat += s.Value
+			}
+			if _, ok := seen[key]; !ok {
+				seen[key] = struct{}{}
+				line.Cumulative += s.Value
+				line.Diff += s.DiffValue
+			}
+		}
+	}
+
+	res.Files = make([]*pb.SourceFile, 0, len(files))
+	for key, lines := range files {
+		file := &pb.SourceFile{
+			Filename: key.filename,
+			BuildId:  key.buildID,
+			Lines:    make([]*pb.SourceLine, 0, len(lines)),
+		}
+		for _, l := range lines {
+			file.Lines = append(file.Lines, l)
+		}
+		sort.Slice(file.Lines, func(i, j int) bool {
+			return file.Lines[i].Line < file.Lines[j].Line
+		})
+		res.Files = append(res.Files, file)
+	}
+	sort.Slice(res.Files, func(i, j int) bool {
+		if res.Files[i].Filename != res.Files[j].Filename {
+			return res.Files[i].Filename < res.Files[j].Filename
+		}
+		return res.Files[i].BuildId < res.Files[j].BuildId
+	})
+
+	return res, nil
+}
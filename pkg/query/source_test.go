@@ -0,0 +1,79 @@
+// Copyright 2022 The Parca Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package query
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	pb "github.com/parca-dev/parca/gen/proto/go/parca/query/v1alpha1"
+	parcaprofile "github.com/parca-dev/parca/pkg/profile"
+)
+
+func formatSource(src *pb.Source) []string {
+	res := []string{}
+	for _, f := range src.Files {
+		for _, l := range f.Lines {
+			res = append(res, fmt.Sprintf("%s@%s:%d %d/%d", f.Filename, f.BuildId, l.Line, l.Flat, l.Cumulative))
+		}
+	}
+	return res
+}
+
+func TestGenerateSource(t *testing.T) {
+	ctx := context.Background()
+	p := stacktraceSamplesFromPprof(filterTestProfile())
+	p.Meta.SampleType = parcaprofile.ValueType{Type: "samples", Unit: "count"}
+	for _, s := range p.Samples {
+		for _, l := range s.Location {
+			if l.Mapping.File == "/bin/app" {
+				l.Mapping.BuildId = "aa"
+			}
+			for _, line := range l.Lines {
+				if line.Function.Name == "handle" {
+					line.Function.Filename = "/src/app.go"
+				}
+			}
+		}
+	}
+
+	src, err := GenerateSource(ctx, p, &pb.SourceOptions{Filename: "app.go"})
+	require.NoError(t, err)
+	require.Equal(t, int64(112), src.Total)
+	require.Equal(t, "count", src.Unit)
+	require.Equal(t, []string{
+		"/src/app.go@aa:2 0/70",
+		"app.go@aa:1 5/82",
+	}, formatSource(src))
+
+	// The recursive stacktrace adds to the cumulative value of compute once.
+	src, err = GenerateSource(ctx, p, &pb.SourceOptions{Function: "compute"})
+	require.NoError(t, err)
+	require.Equal(t, []string{
+		"compute.go@aa:1 10/37",
+	}, formatSource(src))
+
+	src, err = GenerateSource(ctx, p, &pb.SourceOptions{Filename: "malloc.c"})
+	require.NoError(t, err)
+	require.Equal(t, []string{
+		"malloc.c@:1 57/57",
+	}, formatSource(src))
+
+	src, err = GenerateSource(ctx, p, &pb.SourceOptions{Filename: "pp.go"})
+	require.NoError(t, err)
+	require.Empty(t, src.Files)
+}
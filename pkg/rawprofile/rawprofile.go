@@ -0,0 +1,81 @@
+// Copyright 2022 The Parca Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package rawprofile archives ingested profiles exactly as they were received,
+// as re-generated profiles lose information like comments and the original
+// sample type layout.
+package rawprofile
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"path"
+
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/thanos-io/objstore"
+
+	"github.com/parca-dev/parca/pkg/tenant"
+)
+
+// prefix of the objects of the archive in the bucket.
+const prefix = "raw-profiles"
+
+var ErrNotFound = errors.New("raw profile not found")
+
+// Archive stores raw profiles in a bucket, keyed by their tenant, series and
+// timestamp.
+type Archive struct {
+	bucket objstore.Bucket
+}
+
+func NewArchive(bucket objstore.Bucket) *Archive {
+	return &Archive{bucket: bucket}
+}
+
+// Put stores the raw profile of the series of the tenant at the timestamp in
+// milliseconds. A profile stored before for the same series and timestamp is
+// overwritten.
+func (a *Archive) Put(ctx context.Context, tenantID string, ls labels.Labels, timestamp int64, raw []byte) error {
+	return a.bucket.Upload(ctx, objectPath(tenantID, ls, timestamp), bytes.NewReader(raw))
+}
+
+// Get returns the raw profile of the series of the tenant at the timestamp in
+// milliseconds, or ErrNotFound if there is none.
+func (a *Archive) Get(ctx context.Context, tenantID string, ls labels.Labels, timestamp int64) ([]byte, error) {
+	r, err := a.bucket.Get(ctx, objectPath(tenantID, ls, timestamp))
+	if err != nil {
+		if a.bucket.IsObjNotFoundErr(err) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	defer r.Close()
+
+	return ioutil.ReadAll(r)
+}
+
+// objectPath mirrors the layout of the debug value log, the series are
+// identified by their base64 encoded label set. The series of tenants other
+// than the default tenant are below the tenants directory, which can't clash
+// with a series as padded base64 has a multiple of four characters.
+func objectPath(tenantID string, ls labels.Labels, timestamp int64) string {
+	series := base64.URLEncoding.EncodeToString([]byte(labels.New(ls...).String()))
+	if tenantID == tenant.Default {
+		return path.Join(prefix, series, fmt.Sprintf("%d.pb.gz", timestamp))
+	}
+	return path.Join(prefix, "tenants", tenantID, series, fmt.Sprintf("%d.pb.gz", timestamp))
+}
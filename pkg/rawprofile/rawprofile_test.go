@@ -0,0 +1,59 @@
+// Copyright 2022 The Parca Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rawprofile
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/stretchr/testify/require"
+	"github.com/thanos-io/objstore"
+
+	"github.com/parca-dev/parca/pkg/tenant"
+)
+
+func TestArchive(t *testing.T) {
+	ctx := context.Background()
+	a := NewArchive(objstore.NewInMemBucket())
+
+	ls := labels.FromStrings("__name__", "memory", "job", "parca")
+	require.NoError(t, a.Put(ctx, tenant.Default, ls, 1, []byte("profile")))
+
+	b, err := a.Get(ctx, tenant.Default, ls, 1)
+	require.NoError(t, err)
+	require.Equal(t, []byte("profile"), b)
+
+	// The order of the labels doesn't matter.
+	b, err = a.Get(ctx, tenant.Default, labels.Labels{{Name: "job", Value: "parca"}, {Name: "__name__", Value: "memory"}}, 1)
+	require.NoError(t, err)
+	require.Equal(t, []byte("profile"), b)
+
+	_, err = a.Get(ctx, tenant.Default, ls, 2)
+	require.ErrorIs(t, err, ErrNotFound)
+
+	_, err = a.Get(ctx, tenant.Default, labels.FromStrings("__name__", "memory", "job", "other"), 1)
+	require.ErrorIs(t, err, ErrNotFound)
+
+	// Tenants can't read each other's profiles.
+	_, err = a.Get(ctx, "team-a", ls, 1)
+	require.ErrorIs(t, err, ErrNotFound)
+	require.NoError(t, a.Put(ctx, "team-a", ls, 1, []byte("team-a profile")))
+	b, err = a.Get(ctx, "team-a", ls, 1)
+	require.NoError(t, err)
+	require.Equal(t, []byte("team-a profile"), b)
+	b, err = a.Get(ctx, tenant.Default, ls, 1)
+	require.NoError(t, err)
+	require.Equal(t, []byte("profile"), b)
+}
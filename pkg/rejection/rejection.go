@@ -0,0 +1,51 @@
+// Copyright 2022 The Parca Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package rejection holds the reasons the profile store attaches to the
+// errors of rejected profiles, so that clients can tell why a profile was
+// rejected without parsing the error message.
+package rejection
+
+import (
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/status"
+)
+
+// Domain is the domain of the error details carrying a reason.
+const Domain = "parca.dev"
+
+const (
+	DuplicateSample  = "DUPLICATE_SAMPLE"
+	OutOfOrderSample = "OUT_OF_ORDER_SAMPLE"
+	OutOfBounds      = "OUT_OF_BOUNDS"
+	CardinalityLimit = "CARDINALITY_LIMIT"
+	RateLimit        = "RATE_LIMIT"
+	SamplesLimit     = "SAMPLES_LIMIT"
+	SeriesLimit      = "SERIES_LIMIT"
+)
+
+// Reason returns the reason a profile was rejected for, or an empty string if
+// the error doesn't carry one. If several profiles were rejected, it is the
+// reason of the first one.
+func Reason(err error) string {
+	st, ok := status.FromError(err)
+	if !ok {
+		return ""
+	}
+	for _, d := range st.Details() {
+		if info, ok := d.(*errdetails.ErrorInfo); ok && info.Domain == Domain {
+			return info.Reason
+		}
+	}
+	return ""
+}
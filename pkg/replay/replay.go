@@ -0,0 +1,268 @@
+// Copyright 2022 The Parca Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package replay
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/fatih/semgroup"
+	"github.com/go-kit/log"
+	"github.com/go-kit/log/level"
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/prometheus/prometheus/model/timestamp"
+	"github.com/prometheus/prometheus/promql/parser"
+
+	profilestorepb "github.com/parca-dev/parca/gen/proto/go/parca/profilestore/v1alpha1"
+	"github.com/parca-dev/parca/pkg/rejection"
+)
+
+// Profile is a profile of the debug value log.
+type Profile struct {
+	Labels    labels.Labels
+	Timestamp time.Time
+	Path      string
+}
+
+// ReadDir returns the profiles of the debug value log written to dir by the
+// profile store, ordered by the time they were written at. The directories
+// of the log are named after the base64 encoded label set of their profiles,
+// the files after the time in milliseconds the profile was written at.
+func ReadDir(dir string) ([]Profile, error) {
+	seriesDirs, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	profiles := []Profile{}
+	for _, seriesDir := range seriesDirs {
+		if !seriesDir.IsDir() {
+			continue
+		}
+
+		ls, err := seriesLabels(seriesDir.Name())
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse label set of %s: %w", seriesDir.Name(), err)
+		}
+
+		files, err := ioutil.ReadDir(filepath.Join(dir, seriesDir.Name()))
+		if err != nil {
+			return nil, err
+		}
+
+		for _, f := range files {
+			if f.IsDir() || !strings.HasSuffix(f.Name(), ".pb.gz") {
+				continue
+			}
+
+			ms, err := strconv.ParseInt(strings.TrimSuffix(f.Name(), ".pb.gz"), 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse timestamp of %s: %w", f.Name(), err)
+			}
+
+			profiles = append(profiles, Profile{
+				Labels:    ls,
+				Timestamp: timestamp.Time(ms),
+				Path:      filepath.Join(dir, seriesDir.Name(), f.Name()),
+			})
+		}
+	}
+
+	sort.SliceStable(profiles, func(i, j int) bool {
+		return profiles[i].Timestamp.Before(profiles[j].Timestamp)
+	})
+
+	return profiles, nil
+}
+
+func seriesLabels(name string) (labels.Labels, error) {
+	s := name
+	if b, err := base64.URLEncoding.DecodeString(name); err == nil {
+		s = string(b)
+	}
+	return parser.ParseMetric(s)
+}
+
+// WriteRawFunc writes profiles, e.g. to a profile store or a gRPC client.
+type WriteRawFunc func(ctx context.Context, req *profilestorepb.WriteRawRequest) error
+
+type options struct {
+	realtime    bool
+	speed       float64
+	concurrency int
+}
+
+type Option func(*options)
+
+// WithRealtime reproduces the timing of the original writes, sped up by the
+// given factor. Otherwise profiles are replayed as fast as possible.
+func WithRealtime(speed float64) Option {
+	return func(o *options) {
+		o.realtime = true
+		o.speed = speed
+	}
+}
+
+// WithConcurrency sets the number of series replayed concurrently when not
+// replaying in realtime. The profiles of a series are always replayed in
+// order.
+func WithConcurrency(concurrency int) Option {
+	return func(o *options) {
+		o.concurrency = concurrency
+	}
+}
+
+// Stats are the statistics of a replay.
+type Stats struct {
+	Profiles int64
+	Rejected int64
+	Bytes    int64
+	Duration time.Duration
+}
+
+// Replay writes the profiles using the write function. Profiles rejected as
+// duplicate, out of order, out of bounds or exceeding a cardinality limit are
+// counted, but don't stop the replay.
+func Replay(ctx context.Context, logger log.Logger, write WriteRawFunc, profiles []Profile, opts ...Option) (Stats, error) {
+	o := &options{
+		speed:       1,
+		concurrency: 8,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	r := &replayer{
+		logger: logger,
+		write:  write,
+	}
+
+	start := time.Now()
+	var err error
+	if o.realtime {
+		err = r.realtime(ctx, profiles, o.speed)
+	} else {
+		err = r.fast(ctx, profiles, o.concurrency)
+	}
+
+	return Stats{
+		Profiles: atomic.LoadInt64(&r.profiles),
+		Rejected: atomic.LoadInt64(&r.rejected),
+		Bytes:    atomic.LoadInt64(&r.bytes),
+		Duration: time.Since(start),
+	}, err
+}
+
+type replayer struct {
+	logger log.Logger
+	write  WriteRawFunc
+
+	profiles int64
+	rejected int64
+	bytes    int64
+}
+
+func (r *replayer) realtime(ctx context.Context, profiles []Profile, speed float64) error {
+	if len(profiles) == 0 {
+		return nil
+	}
+
+	start := time.Now()
+	first := profiles[0].Timestamp
+	for _, p := range profiles {
+		due := start.Add(time.Duration(float64(p.Timestamp.Sub(first)) / speed))
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(time.Until(due)):
+		}
+
+		if err := r.replay(ctx, p); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *replayer) fast(ctx context.Context, profiles []Profile, concurrency int) error {
+	series := map[uint64][]Profile{}
+	order := []uint64{}
+	for _, p := range profiles {
+		h := p.Labels.Hash()
+		if _, ok := series[h]; !ok {
+			order = append(order, h)
+		}
+		series[h] = append(series[h], p)
+	}
+
+	g := semgroup.NewGroup(ctx, int64(concurrency))
+	for _, h := range order {
+		profiles := series[h]
+		g.Go(func() error {
+			for _, p := range profiles {
+				if err := r.replay(ctx, p); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+	}
+
+	return g.Wait()
+}
+
+func (r *replayer) replay(ctx context.Context, p Profile) error {
+	b, err := ioutil.ReadFile(p.Path)
+	if err != nil {
+		return err
+	}
+
+	lset := &profilestorepb.LabelSet{
+		Labels: make([]*profilestorepb.Label, 0, len(p.Labels)),
+	}
+	for _, l := range p.Labels {
+		lset.Labels = append(lset.Labels, &profilestorepb.Label{
+			Name:  l.Name,
+			Value: l.Value,
+		})
+	}
+
+	err = r.write(ctx, &profilestorepb.WriteRawRequest{
+		Series: []*profilestorepb.RawProfileSeries{{
+			Labels:  lset,
+			Samples: []*profilestorepb.RawSample{{RawProfile: b}},
+		}},
+	})
+	if err != nil {
+		if rejection.Reason(err) == "" {
+			return fmt.Errorf("failed to replay %s: %w", p.Path, err)
+		}
+		level.Debug(r.logger).Log("msg", "profile rejected", "path", p.Path, "err", err)
+		atomic.AddInt64(&r.rejected, 1)
+	}
+
+	atomic.AddInt64(&r.profiles, 1)
+	atomic.AddInt64(&r.bytes, int64(len(b)))
+	return nil
+}
@@ -0,0 +1,116 @@
+// Copyright 2022 The Parca Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package replay
+
+import (
+	"context"
+	"encoding/base64"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/go-kit/log"
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	profilestorepb "github.com/parca-dev/parca/gen/proto/go/parca/profilestore/v1alpha1"
+	"github.com/parca-dev/parca/pkg/rejection"
+)
+
+// writeDebugValueLog writes files in the layout of the debug value log of
+// the profile store.
+func writeDebugValueLog(t *testing.T, dir string, ls labels.Labels, timestamps ...string) {
+	t.Helper()
+
+	seriesDir := filepath.Join(dir, base64.URLEncoding.EncodeToString([]byte(ls.String())))
+	require.NoError(t, os.MkdirAll(seriesDir, os.ModePerm))
+	for _, ts := range timestamps {
+		require.NoError(t, ioutil.WriteFile(filepath.Join(seriesDir, ts+".pb.gz"), []byte(ts), 0o644))
+	}
+}
+
+func TestReplay(t *testing.T) {
+	dir := t.TempDir()
+	a := labels.FromStrings("__name__", "memory", "job", "a")
+	b := labels.FromStrings("__name__", "memory", "job", "b")
+	writeDebugValueLog(t, dir, a, "3000", "1000")
+	writeDebugValueLog(t, dir, b, "2000", "4000")
+
+	profiles, err := ReadDir(dir)
+	require.NoError(t, err)
+	require.Len(t, profiles, 4)
+	for i, ls := range []labels.Labels{a, b, a, b} {
+		require.Equal(t, ls, profiles[i].Labels)
+	}
+
+	var (
+		mtx     sync.Mutex
+		written = map[string][]string{}
+		order   []string
+	)
+	write := func(_ context.Context, req *profilestorepb.WriteRawRequest) error {
+		mtx.Lock()
+		defer mtx.Unlock()
+
+		series := req.Series[0]
+		ts := string(series.Samples[0].RawProfile)
+		job := series.Labels.Labels[1].Value
+		written[job] = append(written[job], ts)
+		order = append(order, ts)
+		if ts == "4000" {
+			st, err := status.New(codes.AlreadyExists, "duplicate").WithDetails(&errdetails.ErrorInfo{
+				Reason: rejection.DuplicateSample,
+				Domain: rejection.Domain,
+			})
+			require.NoError(t, err)
+			return st.Err()
+		}
+		return nil
+	}
+
+	stats, err := Replay(context.Background(), log.NewNopLogger(), write, profiles)
+	require.NoError(t, err)
+	require.Equal(t, int64(4), stats.Profiles)
+	require.Equal(t, int64(16), stats.Bytes)
+	require.Equal(t, int64(1), stats.Rejected)
+	require.Equal(t, map[string][]string{
+		"a": {"1000", "3000"},
+		"b": {"2000", "4000"},
+	}, written)
+
+	// Realtime replays preserve the global order of the profiles.
+	order = nil
+	_, err = Replay(context.Background(), log.NewNopLogger(), write, profiles, WithRealtime(1000))
+	require.NoError(t, err)
+	require.Equal(t, []string{"1000", "2000", "3000", "4000"}, order)
+}
+
+func TestReplayError(t *testing.T) {
+	dir := t.TempDir()
+	writeDebugValueLog(t, dir, labels.FromStrings("__name__", "memory"), "1000")
+
+	profiles, err := ReadDir(dir)
+	require.NoError(t, err)
+
+	// Errors other than rejections stop the replay.
+	_, err = Replay(context.Background(), log.NewNopLogger(), func(context.Context, *profilestorepb.WriteRawRequest) error {
+		return status.Error(codes.Internal, "failed")
+	}, profiles)
+	require.Error(t, err)
+}
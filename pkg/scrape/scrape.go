@@ -36,6 +36,7 @@ import (
 
 	profilepb "github.com/parca-dev/parca/gen/proto/go/parca/profilestore/v1alpha1"
 	"github.com/parca-dev/parca/pkg/config"
+	"github.com/parca-dev/parca/pkg/rejection"
 )
 
 // scrapePool manages scrapes for sets of targets.
@@ -106,7 +107,7 @@ func newScrapePool(
 			s,
 			log.With(logger, "target", t),
 			externalLabels,
-			sp.metrics.targetIntervalLength,
+			sp.metrics,
 			buffers,
 			store,
 		)
@@ -357,7 +358,7 @@ type scrapeLoop struct {
 	target         *Target
 	scraper        scraper
 	l              log.Logger
-	intervalLength *prometheus.SummaryVec
+	metrics        *scrapePoolMetrics
 	lastScrapeSize int
 	externalLabels labels.Labels
 
@@ -375,7 +376,7 @@ func newScrapeLoop(ctx context.Context,
 	sc scraper,
 	l log.Logger,
 	externalLabels labels.Labels,
-	targetIntervalLength *prometheus.SummaryVec,
+	metrics *scrapePoolMetrics,
 	buffers *pool.Pool,
 	store profilepb.ProfileStoreServiceServer,
 ) *scrapeLoop {
@@ -393,7 +394,7 @@ func newScrapeLoop(ctx context.Context,
 		stopped:        make(chan struct{}),
 		l:              l,
 		externalLabels: externalLabels,
-		intervalLength: targetIntervalLength,
+		metrics:        metrics,
 		ctx:            ctx,
 	}
 	sl.scrapeCtx, sl.cancel = context.WithCancel(ctx)
@@ -430,7 +431,7 @@ mainLoop:
 
 		// Only record after the first scrape.
 		if !last.IsZero() {
-			sl.intervalLength.WithLabelValues(interval.String()).Observe(
+			sl.metrics.targetIntervalLength.WithLabelValues(interval.String()).Observe(
 				time.Since(last).Seconds(),
 			)
 		}
@@ -495,6 +496,8 @@ mainLoop:
 				},
 			})
 			if err != nil {
+				sl.countRejected(err)
+
 				switch errc {
 				case nil:
 					level.Error(sl.l).Log("msg", "WriteRaw failed for scraped profile", "err", err)
@@ -535,6 +538,19 @@ mainLoop:
 	close(sl.stopped)
 }
 
+// countRejected increments the metric matching the reason the store rejected
+// the scraped profile for.
+func (sl *scrapeLoop) countRejected(err error) {
+	switch rejection.Reason(err) {
+	case rejection.DuplicateSample:
+		sl.metrics.targetScrapeSampleDuplicate.Inc()
+	case rejection.OutOfOrderSample:
+		sl.metrics.targetScrapeSampleOutOfOrder.Inc()
+	case rejection.OutOfBounds:
+		sl.metrics.targetScrapeSampleOutOfBounds.Inc()
+	}
+}
+
 // Stop the scraping. May still write data and stale markers after it has
 // returned. Cancel the context to stop all writes.
 func (sl *scrapeLoop) stop() {
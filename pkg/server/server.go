@@ -49,6 +49,7 @@ import (
 	"google.golang.org/grpc/status"
 
 	"github.com/parca-dev/parca/pkg/prober"
+	"github.com/parca-dev/parca/pkg/tenant"
 	"github.com/parca-dev/parca/ui"
 )
 
@@ -129,7 +130,15 @@ func (s *Server) ListenAndServe(ctx context.Context, logger log.Logger, port str
 
 	opts := []grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())}
 
-	grpcWebMux := runtime.NewServeMux()
+	grpcWebMux := runtime.NewServeMux(
+		runtime.WithIncomingHeaderMatcher(func(key string) (string, bool) {
+			// The tenant of HTTP requests is passed as a plain header.
+			if strings.EqualFold(key, tenant.Header) {
+				return tenant.Header, true
+			}
+			return runtime.DefaultHeaderMatcher(key)
+		}),
+	)
 	for _, r := range registerables {
 		if err := r.Register(ctx, srv, grpcWebMux, port, opts); err != nil {
 			return err
@@ -0,0 +1,87 @@
+// Copyright 2022 The Parca Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package sourcepath rewrites the source file paths of functions, e.g. to
+// make the paths that binaries built in CI embed relative to the repository.
+package sourcepath
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Rule rewrites a source path. It either trims a prefix, or replaces the
+// matches of a regex.
+type Rule struct {
+	trimPrefix  string
+	regex       *regexp.Regexp
+	replacement string
+}
+
+// NewTrimPrefixRule returns a rule that removes the prefix from paths.
+func NewTrimPrefixRule(prefix string) (*Rule, error) {
+	if prefix == "" {
+		return nil, errors.New("prefix must not be empty")
+	}
+	return &Rule{trimPrefix: prefix}, nil
+}
+
+// NewRegexRule returns a rule that replaces the matches of the regex with the
+// replacement, which may reference capture groups like
+// regexp.Regexp.ReplaceAllString.
+func NewRegexRule(regex, replacement string) (*Rule, error) {
+	rx, err := regexp.Compile(regex)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compile source path regexp %s: %w", regex, err)
+	}
+	return &Rule{regex: rx, replacement: replacement}, nil
+}
+
+// apply returns the rewritten path and whether the rule matched.
+func (r *Rule) apply(path string) (string, bool) {
+	if r.regex != nil {
+		if !r.regex.MatchString(path) {
+			return path, false
+		}
+		return r.regex.ReplaceAllString(path, r.replacement), true
+	}
+	if !strings.HasPrefix(path, r.trimPrefix) {
+		return path, false
+	}
+	return strings.TrimPrefix(path, r.trimPrefix), true
+}
+
+// Mapper rewrites source paths with the first of its rules that matches. A
+// nil Mapper leaves paths unchanged.
+type Mapper struct {
+	rules []*Rule
+}
+
+func NewMapper(rules ...*Rule) *Mapper {
+	return &Mapper{rules: rules}
+}
+
+// Map returns the rewritten path.
+func (m *Mapper) Map(path string) string {
+	if m == nil || path == "" {
+		return path
+	}
+	for _, r := range m.rules {
+		if p, ok := r.apply(path); ok {
+			return p
+		}
+	}
+	return path
+}
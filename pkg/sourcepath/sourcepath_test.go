@@ -0,0 +1,48 @@
+// Copyright 2022 The Parca Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sourcepath
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMapper(t *testing.T) {
+	trim, err := NewTrimPrefixRule("/home/runner/work/repo/repo/")
+	require.NoError(t, err)
+	build, err := NewRegexRule(`^/build/[^/]+/`, "")
+	require.NoError(t, err)
+	gopath, err := NewRegexRule(`^.*/pkg/mod/([^@]+)@[^/]+/`, "$1/")
+	require.NoError(t, err)
+
+	m := NewMapper(trim, build, gopath)
+	require.Equal(t, "pkg/parca/parca.go", m.Map("/home/runner/work/repo/repo/pkg/parca/parca.go"))
+	require.Equal(t, "src/main.cc", m.Map("/build/xyz/src/main.cc"))
+	require.Equal(t, "github.com/go-kit/log/value.go", m.Map("/root/go/pkg/mod/github.com/go-kit/log@v0.2.0/value.go"))
+	require.Equal(t, "/usr/include/stdio.h", m.Map("/usr/include/stdio.h"))
+
+	// Only the first matching rule is applied.
+	other, err := NewRegexRule(`^/build/`, "other/")
+	require.NoError(t, err)
+	require.Equal(t, "src/main.cc", NewMapper(build, other).Map("/build/xyz/src/main.cc"))
+
+	var nilMapper *Mapper
+	require.Equal(t, "/build/xyz/src/main.cc", nilMapper.Map("/build/xyz/src/main.cc"))
+
+	_, err = NewTrimPrefixRule("")
+	require.Error(t, err)
+	_, err = NewRegexRule("(", "")
+	require.Error(t, err)
+}
@@ -21,6 +21,7 @@ import (
 	"github.com/go-kit/log/level"
 
 	"github.com/parca-dev/parca/pkg/metastore"
+	"github.com/parca-dev/parca/pkg/sourcepath"
 	"github.com/parca-dev/parca/pkg/symbol/demangle"
 	"github.com/parca-dev/parca/pkg/symbol/elfutils"
 )
@@ -28,19 +29,23 @@ import (
 type DwarfLiner struct {
 	logger log.Logger
 
-	dbgFile elfutils.DebugInfoFile
+	dbgFile     elfutils.DebugInfoFile
+	sourcePaths *sourcepath.Mapper
 }
 
 // DWARF is a symbolizer that uses DWARF debug info to symbolize addresses.
-func DWARF(logger log.Logger, path string, demangler *demangle.Demangler) (*DwarfLiner, error) {
+// The filenames of the symbolized functions are rewritten by the source path
+// mapper, which may be nil.
+func DWARF(logger log.Logger, path string, demangler *demangle.Demangler, sourcePaths *sourcepath.Mapper) (*DwarfLiner, error) {
 	dbgFile, err := elfutils.NewDebugInfoFile(path, demangler)
 	if err != nil {
 		return nil, err
 	}
 
 	return &DwarfLiner{
-		logger:  log.With(logger, "liner", "dwarf", "file", path),
-		dbgFile: dbgFile,
+		logger:      log.With(logger, "liner", "dwarf", "file", path),
+		dbgFile:     dbgFile,
+		sourcePaths: sourcePaths,
 	}, nil
 }
 
@@ -57,5 +62,8 @@ func (dl *DwarfLiner) PCToLines(addr uint64) (lines []metastore.LocationLine, er
 		level.Debug(dl.logger).Log("msg", "failed to symbolize location", "addr", addr, "err", err)
 		return nil, err
 	}
+	for _, l := range lines {
+		l.Function.Filename = dl.sourcePaths.Map(l.Function.Filename)
+	}
 	return lines, nil
 }
@@ -24,23 +24,29 @@ import (
 
 	pb "github.com/parca-dev/parca/gen/proto/go/parca/metastore/v1alpha1"
 	"github.com/parca-dev/parca/pkg/metastore"
+	"github.com/parca-dev/parca/pkg/sourcepath"
 )
 
 type GoLiner struct {
 	logger log.Logger
 
-	symtab *gosym.Table
+	symtab      *gosym.Table
+	sourcePaths *sourcepath.Mapper
 }
 
-func Go(logger log.Logger, path string) (*GoLiner, error) {
+// Go is a symbolizer that uses the .gopclntab section of Go binaries to
+// symbolize addresses. The filenames of the symbolized functions are
+// rewritten by the source path mapper, which may be nil.
+func Go(logger log.Logger, path string, sourcePaths *sourcepath.Mapper) (*GoLiner, error) {
 	tab, err := gosymtab(path)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create go symbtab: %w", err)
 	}
 
 	return &GoLiner{
-		logger: log.With(logger, "liner", "go"),
-		symtab: tab,
+		logger:      log.With(logger, "liner", "go"),
+		symtab:      tab,
+		sourcePaths: sourcePaths,
 	}, nil
 }
 
@@ -67,7 +73,7 @@ func (gl *GoLiner) PCToLines(addr uint64) (lines []metastore.LocationLine, err e
 		Line: int64(line),
 		Function: &pb.Function{
 			Name:     name,
-			Filename: file,
+			Filename: gl.sourcePaths.Map(file),
 		},
 	})
 	return lines, nil
@@ -0,0 +1,184 @@
+// Copyright 2022 The Parca Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package disasm
+
+import (
+	"debug/elf"
+	"errors"
+	"fmt"
+	"sort"
+
+	"golang.org/x/arch/arm64/arm64asm"
+	"golang.org/x/arch/x86/x86asm"
+)
+
+// ErrUnsupportedMachine is returned for ELF files of machines other than
+// x86-64 and arm64.
+var ErrUnsupportedMachine = errors.New("unsupported machine")
+
+// Instruction is a machine instruction of a function.
+type Instruction struct {
+	Address uint64
+	Size    uint64
+	// Function is the name of the symbol of the function.
+	Function string
+	// Text is the instruction in GNU syntax.
+	Text string
+}
+
+// Functions returns the instructions of the function symbols of the ELF file
+// containing the addresses, sorted by address. Addresses outside of function
+// symbols, and functions of which the file has no code, like in separate debug
+// information files, are ignored.
+func Functions(path string, addrs []uint64) ([]Instruction, error) {
+	f, err := elf.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open elf: %w", err)
+	}
+	defer f.Close()
+
+	if f.Machine != elf.EM_X86_64 && f.Machine != elf.EM_AARCH64 {
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedMachine, f.Machine)
+	}
+
+	symbols, err := functionSymbols(f)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := map[uint64]struct{}{}
+	var res []Instruction
+	for _, addr := range addrs {
+		sym, ok := symbolAt(symbols, addr)
+		if !ok {
+			continue
+		}
+		if _, ok := seen[sym.Value]; ok {
+			continue
+		}
+		seen[sym.Value] = struct{}{}
+
+		code, err := functionCode(f, sym)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read code of %s: %w", sym.Name, err)
+		}
+		if code == nil {
+			continue
+		}
+		res = append(res, decode(f.Machine, symbols, sym, code)...)
+	}
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].Address < res[j].Address
+	})
+
+	return res, nil
+}
+
+// functionSymbols returns the function symbols of the file with a size,
+// sorted by address.
+func functionSymbols(f *elf.File) ([]elf.Symbol, error) {
+	syms, sErr := f.Symbols()
+	dynSyms, dErr := f.DynamicSymbols()
+	if sErr != nil && dErr != nil {
+		return nil, fmt.Errorf("failed to read symbol sections: %w", sErr)
+	}
+
+	res := make([]elf.Symbol, 0, len(syms)+len(dynSyms))
+	for _, sym := range append(syms, dynSyms...) {
+		if elf.ST_TYPE(sym.Info) == elf.STT_FUNC && sym.Size > 0 {
+			res = append(res, sym)
+		}
+	}
+	sort.SliceStable(res, func(i, j int) bool {
+		return res[i].Value < res[j].Value
+	})
+	return res, nil
+}
+
+// symbolAt returns the function symbol containing the address.
+func symbolAt(symbols []elf.Symbol, addr uint64) (elf.Symbol, bool) {
+	i := sort.Search(len(symbols), func(i int) bool {
+		return symbols[i].Value > addr
+	})
+	// Symbols may overlap, so the closest symbol doesn't necessarily contain
+	// the address.
+	for i--; i >= 0; i-- {
+		if sym := symbols[i]; addr < sym.Value+sym.Size {
+			return sym, true
+		}
+	}
+	return elf.Symbol{}, false
+}
+
+// functionCode returns the code of the function, or nil if the file doesn't
+// contain it.
+func functionCode(f *elf.File, sym elf.Symbol) ([]byte, error) {
+	for _, s := range f.Sections {
+		if s.Type == elf.SHT_NOBITS || s.Flags&elf.SHF_EXECINSTR == 0 {
+			continue
+		}
+		if sym.Value < s.Addr || sym.Value+sym.Size > s.Addr+s.Size {
+			continue
+		}
+
+		code := make([]byte, sym.Size)
+		if _, err := s.ReadAt(code, int64(sym.Value-s.Addr)); err != nil {
+			return nil, err
+		}
+		return code, nil
+	}
+	return nil, nil
+}
+
+func decode(machine elf.Machine, symbols []elf.Symbol, sym elf.Symbol, code []byte) []Instruction {
+	// lookup resolves the targets of calls and jumps to function names.
+	lookup := func(addr uint64) (string, uint64) {
+		if s, ok := symbolAt(symbols, addr); ok {
+			return s.Name, s.Value
+		}
+		return "", 0
+	}
+
+	var res []Instruction
+	for i := uint64(0); i < uint64(len(code)); {
+		inst := Instruction{
+			Address:  sym.Value + i,
+			Function: sym.Name,
+		}
+		switch machine {
+		case elf.EM_X86_64:
+			x86inst, err := x86asm.Decode(code[i:], 64)
+			if err != nil || x86inst.Len == 0 {
+				inst.Size, inst.Text = 1, "(bad)"
+				break
+			}
+			inst.Size = uint64(x86inst.Len)
+			inst.Text = x86asm.GNUSyntax(x86inst, inst.Address, lookup)
+		case elf.EM_AARCH64:
+			inst.Size = 4
+			if uint64(len(code))-i < inst.Size {
+				return res
+			}
+			arm64inst, err := arm64asm.Decode(code[i:])
+			if err != nil {
+				inst.Text = "(bad)"
+				break
+			}
+			inst.Text = arm64asm.GNUSyntax(arm64inst)
+		}
+		res = append(res, inst)
+		i += inst.Size
+	}
+	return res
+}
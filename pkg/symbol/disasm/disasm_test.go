@@ -0,0 +1,74 @@
+// Copyright 2022 The Parca Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package disasm
+
+import (
+	"debug/elf"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const testBinary = "../../symbolizer/testdata/2d6912fd3dd64542f6f6294f4bf9cb6c265b3085/debuginfo"
+
+func TestFunctions(t *testing.T) {
+	// main.main is at 0x463720 and 197 bytes long, main.init follows at
+	// 0x463800.
+	insts, err := Functions(testBinary, []uint64{0x463781, 0x463720, 0x1})
+	require.NoError(t, err)
+	require.NotEmpty(t, insts)
+
+	require.Equal(t, Instruction{
+		Address:  0x463720,
+		Size:     9,
+		Function: "main.main",
+		Text:     "mov %fs:0xfffffff8,%rcx",
+	}, insts[0])
+	require.Equal(t, uint64(0x46376d), insts[13].Address)
+	require.Equal(t, "callq runtime.mapaccess1_faststr", insts[13].Text)
+
+	next := insts[0].Address
+	for _, inst := range insts {
+		require.Equal(t, "main.main", inst.Function)
+		require.Equal(t, next, inst.Address)
+		next += inst.Size
+	}
+	require.Equal(t, uint64(0x463720+197), next)
+
+	insts, err = Functions(testBinary, []uint64{0x1})
+	require.NoError(t, err)
+	require.Empty(t, insts)
+}
+
+func TestSymbolAt(t *testing.T) {
+	symbols := []elf.Symbol{
+		{Name: "outer", Value: 0x10, Size: 0x20},
+		{Name: "inner", Value: 0x14, Size: 0x4},
+		{Name: "next", Value: 0x40, Size: 0x10},
+	}
+
+	for addr, want := range map[uint64]string{
+		0x0f: "",
+		0x10: "outer",
+		0x15: "inner",
+		0x18: "outer",
+		0x30: "",
+		0x4f: "next",
+		0x50: "",
+	} {
+		sym, ok := symbolAt(symbols, addr)
+		require.Equal(t, want != "", ok, "%#x", addr)
+		require.Equal(t, want, sym.Name, "%#x", addr)
+	}
+}
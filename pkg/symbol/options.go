@@ -18,6 +18,7 @@ import (
 
 	"github.com/goburrow/cache"
 
+	"github.com/parca-dev/parca/pkg/sourcepath"
 	"github.com/parca-dev/parca/pkg/symbol/demangle"
 )
 
@@ -35,6 +36,14 @@ func WithDemangleMode(mode string) Option {
 	}
 }
 
+// WithSourcePathMapper rewrites the filenames of the functions resolved from
+// DWARF and Go debug information.
+func WithSourcePathMapper(m *sourcepath.Mapper) Option {
+	return func(s *Symbolizer) {
+		s.sourcePaths = m
+	}
+}
+
 func WithCacheSize(size int) Option {
 	return func(s *Symbolizer) {
 		s.cacheOpts = append(s.cacheOpts, cache.WithMaximumSize(size))
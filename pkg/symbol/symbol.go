@@ -26,6 +26,7 @@ import (
 	pb "github.com/parca-dev/parca/gen/proto/go/parca/metastore/v1alpha1"
 	"github.com/parca-dev/parca/pkg/hash"
 	"github.com/parca-dev/parca/pkg/metastore"
+	"github.com/parca-dev/parca/pkg/sourcepath"
 	"github.com/parca-dev/parca/pkg/symbol/addr2line"
 	"github.com/parca-dev/parca/pkg/symbol/demangle"
 	"github.com/parca-dev/parca/pkg/symbol/elfutils"
@@ -36,6 +37,8 @@ var ErrLinerCreationFailedBefore = errors.New("failed to initialize liner")
 type Symbolizer struct {
 	logger    log.Logger
 	demangler *demangle.Demangler
+	// sourcePaths rewrites the filenames of symbolized functions.
+	sourcePaths *sourcepath.Mapper
 
 	cacheOpts  []cache.Option
 	linerCache cache.Cache
@@ -210,7 +213,7 @@ func (s *Symbolizer) newLiner(buildID, path string) (liner, error) {
 	}
 	if hasDWARF {
 		level.Debug(logger).Log("msg", "using DWARF liner to resolve symbols")
-		lnr, err := addr2line.DWARF(logger, path, s.demangler)
+		lnr, err := addr2line.DWARF(logger, path, s.demangler, s.sourcePaths)
 		if err != nil {
 			return nil, err
 		}
@@ -226,7 +229,7 @@ func (s *Symbolizer) newLiner(buildID, path string) (liner, error) {
 	if isGo {
 		// Right now, this uses "debug/gosym" package, and it won't work for inlined functions,
 		// so this is just a best-effort implementation, in case we don't have DWARF.
-		lnr, err := addr2line.Go(logger, path)
+		lnr, err := addr2line.Go(logger, path, s.sourcePaths)
 		if err == nil {
 			level.Debug(logger).Log("msg", "using go liner to resolve symbols")
 			return lnr, nil
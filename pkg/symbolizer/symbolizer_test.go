@@ -42,6 +42,7 @@ import (
 	"github.com/parca-dev/parca/pkg/metastore"
 	"github.com/parca-dev/parca/pkg/parcacol"
 	"github.com/parca-dev/parca/pkg/profilestore"
+	"github.com/parca-dev/parca/pkg/sourcepath"
 	"github.com/parca-dev/parca/pkg/symbol"
 )
 
@@ -323,6 +324,41 @@ func TestRealSymbolizerWithoutDWARF(t *testing.T) {
 	require.Equal(t, "main.busyCPU", lines[0].Function.Name)
 }
 
+func TestRealSymbolizerSourcePathMapping(t *testing.T) {
+	trim, err := sourcepath.NewTrimPrefixRule("/home/kakkoyun/Workspace/PolarSignals/pprof-example-app-go/")
+	require.NoError(t, err)
+
+	for _, profile := range []string{
+		"testdata/normal-cpu.stripped.pprof",        // DWARF liner
+		"testdata/without-dwarf-cpu.stripped.pprof", // Go liner
+	} {
+		t.Run(profile, func(t *testing.T) {
+			conn, dbgStr, mStr := setup(t, symbol.WithSourcePathMapper(sourcepath.NewMapper(trim)))
+
+			// Generated from https://github.com/polarsignals/pprof-example-app-go
+			require.NoError(t, ingest(t, conn, profile))
+
+			ctx := context.Background()
+			symLocs, err := metastore.GetSymbolizableLocations(ctx, mStr)
+			require.NoError(t, err)
+
+			sym := New(log.NewNopLogger(), mStr, dbgStr)
+			require.NoError(t, sym.symbolize(ctx, symLocs))
+
+			allLocs, err := metastore.GetLocations(ctx, mStr)
+			require.NoError(t, err)
+
+			lines := findLocWithAddress(allLocs, 0x6491de).Lines
+			require.Equal(t, 1, len(lines))
+			require.Equal(t, "fib/fib.go", lines[0].Function.Filename)
+
+			lines = findLocWithAddress(allLocs, 0x649e46).Lines
+			require.Equal(t, 1, len(lines))
+			require.Equal(t, "main.go", lines[0].Function.Filename)
+		})
+	}
+}
+
 func TestRealSymbolizerEverythingStrippedInliningEnabled(t *testing.T) {
 	// NOTICE: Uses custom Go symbolizer!
 
@@ -399,7 +435,7 @@ func ingest(t *testing.T, conn *grpc.ClientConn, path string) error {
 	return err
 }
 
-func setup(t *testing.T) (*grpc.ClientConn, *debuginfo.Store, metastore.ProfileMetaStore) {
+func setup(t *testing.T, symOpts ...symbol.Option) (*grpc.ClientConn, *debuginfo.Store, metastore.ProfileMetaStore) {
 	t.Helper()
 
 	logger := log.NewNopLogger()
@@ -412,22 +448,13 @@ func setup(t *testing.T) (*grpc.ClientConn, *debuginfo.Store, metastore.ProfileM
 	)
 	colDB, err := col.DB("parca")
 	require.NoError(t, err)
-	table, err := colDB.Table(
-		"stacktraces",
-		arcticdb.NewTableConfig(
-			parcacol.Schema(),
-		),
-		logger,
-	)
-	require.NoError(t, err)
-
 	cacheDir, err := ioutil.TempDir("", "parca-test-cache-*")
 	require.NoError(t, err)
 	t.Cleanup(func() {
 		os.RemoveAll(cacheDir)
 	})
 
-	sym, err := symbol.NewSymbolizer(logger)
+	sym, err := symbol.NewSymbolizer(logger, symOpts...)
 	require.NoError(t, err)
 
 	cfg := &debuginfo.Config{
@@ -464,9 +491,10 @@ func setup(t *testing.T) (*grpc.ClientConn, *debuginfo.Store, metastore.ProfileM
 
 	pStr := profilestore.NewProfileColumnStore(
 		logger,
+		reg,
 		tracer,
 		mStr,
-		table,
+		parcacol.NewTables(logger, colDB, "stacktraces"),
 		false,
 	)
 
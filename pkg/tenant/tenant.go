@@ -0,0 +1,83 @@
+// Copyright 2022 The Parca Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package tenant identifies the tenant of gRPC requests. The profiles of
+// every tenant are isolated from the ones of other tenants, requests without
+// a tenant belong to the default tenant.
+package tenant
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+
+	"google.golang.org/grpc/metadata"
+)
+
+// Header is the gRPC metadata key, and HTTP header, carrying the tenant ID.
+const Header = "parca-tenant"
+
+// Default is the tenant of requests without a tenant ID.
+const Default = ""
+
+var ErrInvalid = errors.New("invalid tenant ID")
+
+var validID = regexp.MustCompile(`^[a-zA-Z0-9_-]{1,64}$`)
+
+// Validate returns ErrInvalid unless the tenant ID consists of at most 64
+// letters, digits, underscores and dashes.
+func Validate(id string) error {
+	if !validID.MatchString(id) {
+		return fmt.Errorf("%w %q: must match %s", ErrInvalid, id, validID)
+	}
+	return nil
+}
+
+// FromContext returns the tenant of the incoming gRPC request, or Default if
+// the request doesn't carry a tenant ID.
+func FromContext(ctx context.Context) (string, error) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return Default, nil
+	}
+
+	ids := md.Get(Header)
+	switch len(ids) {
+	case 0:
+		return Default, nil
+	case 1:
+		if err := Validate(ids[0]); err != nil {
+			return "", err
+		}
+		return ids[0], nil
+	default:
+		return "", fmt.Errorf("%w: request has %d tenant IDs", ErrInvalid, len(ids))
+	}
+}
+
+// Credentials attach the tenant ID to every request of a gRPC client.
+type Credentials struct {
+	ID       string
+	Insecure bool
+}
+
+func (c *Credentials) GetRequestMetadata(ctx context.Context, uri ...string) (map[string]string, error) {
+	return map[string]string{
+		Header: c.ID,
+	}, nil
+}
+
+func (c *Credentials) RequireTransportSecurity() bool {
+	return !c.Insecure
+}
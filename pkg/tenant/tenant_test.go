@@ -0,0 +1,45 @@
+// Copyright 2022 The Parca Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tenant
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestFromContext(t *testing.T) {
+	id, err := FromContext(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, Default, id)
+
+	id, err = FromContext(metadata.NewIncomingContext(context.Background(), metadata.Pairs("other", "value")))
+	require.NoError(t, err)
+	require.Equal(t, Default, id)
+
+	id, err = FromContext(metadata.NewIncomingContext(context.Background(), metadata.Pairs(Header, "team-a")))
+	require.NoError(t, err)
+	require.Equal(t, "team-a", id)
+
+	for _, invalid := range []string{"", "team/a", "../a", strings.Repeat("a", 65)} {
+		_, err = FromContext(metadata.NewIncomingContext(context.Background(), metadata.Pairs(Header, invalid)))
+		require.ErrorIs(t, err, ErrInvalid, invalid)
+	}
+
+	_, err = FromContext(metadata.NewIncomingContext(context.Background(), metadata.Pairs(Header, "team-a", Header, "team-b")))
+	require.ErrorIs(t, err, ErrInvalid)
+}
@@ -1,9 +1,14 @@
 // @generated by protobuf-ts 2.2.2 with parameter long_type_string,generate_dependencies
 // @generated from protobuf file "parca/profilestore/v1alpha1/profilestore.proto" (package "parca.profilestore.v1alpha1", syntax proto3)
 // tslint:disable
+import { AdminService } from "./profilestore";
+import type { UsageResponse } from "./profilestore";
+import type { UsageRequest } from "./profilestore";
 import type { RpcTransport } from "@protobuf-ts/runtime-rpc";
 import type { ServiceInfo } from "@protobuf-ts/runtime-rpc";
 import { ProfileStoreService } from "./profilestore";
+import type { WriteArrowResponse } from "./profilestore";
+import type { WriteArrowRequest } from "./profilestore";
 import { stackIntercept } from "@protobuf-ts/runtime-rpc";
 import type { WriteRawResponse } from "./profilestore";
 import type { WriteRawRequest } from "./profilestore";
@@ -16,11 +21,19 @@ import type { RpcOptions } from "@protobuf-ts/runtime-rpc";
  */
 export interface IProfileStoreServiceClient {
     /**
-     * WriteRaw accepts a raw set of bytes of a pprof file
+     * WriteRaw accepts a raw set of bytes of a pprof file. Profiles that are rejected don't prevent the other profiles of
+     * the request from being ingested. The error then has the code of the first rejection and an ErrorInfo detail with
+     * the reason for every rejected series, with the labels of the series in the "series" metadata key.
      *
      * @generated from protobuf rpc: WriteRaw(parca.profilestore.v1alpha1.WriteRawRequest) returns (parca.profilestore.v1alpha1.WriteRawResponse);
      */
     writeRaw(input: WriteRawRequest, options?: RpcOptions): UnaryCall<WriteRawRequest, WriteRawResponse>;
+    /**
+     * WriteArrow accepts samples in columnar form as Arrow record batches
+     *
+     * @generated from protobuf rpc: WriteArrow(parca.profilestore.v1alpha1.WriteArrowRequest) returns (parca.profilestore.v1alpha1.WriteArrowResponse);
+     */
+    writeArrow(input: WriteArrowRequest, options?: RpcOptions): UnaryCall<WriteArrowRequest, WriteArrowResponse>;
 }
 /**
  * ProfileStoreService is the service the accepts pprof writes
@@ -34,7 +47,9 @@ export class ProfileStoreServiceClient implements IProfileStoreServiceClient, Se
     constructor(private readonly _transport: RpcTransport) {
     }
     /**
-     * WriteRaw accepts a raw set of bytes of a pprof file
+     * WriteRaw accepts a raw set of bytes of a pprof file. Profiles that are rejected don't prevent the other profiles of
+     * the request from being ingested. The error then has the code of the first rejection and an ErrorInfo detail with
+     * the reason for every rejected series, with the labels of the series in the "series" metadata key.
      *
      * @generated from protobuf rpc: WriteRaw(parca.profilestore.v1alpha1.WriteRawRequest) returns (parca.profilestore.v1alpha1.WriteRawResponse);
      */
@@ -42,4 +57,49 @@ export class ProfileStoreServiceClient implements IProfileStoreServiceClient, Se
         const method = this.methods[0], opt = this._transport.mergeOptions(options);
         return stackIntercept<WriteRawRequest, WriteRawResponse>("unary", this._transport, method, opt, input);
     }
+    /**
+     * WriteArrow accepts samples in columnar form as Arrow record batches
+     *
+     * @generated from protobuf rpc: WriteArrow(parca.profilestore.v1alpha1.WriteArrowRequest) returns (parca.profilestore.v1alpha1.WriteArrowResponse);
+     */
+    writeArrow(input: WriteArrowRequest, options?: RpcOptions): UnaryCall<WriteArrowRequest, WriteArrowResponse> {
+        const method = this.methods[1], opt = this._transport.mergeOptions(options);
+        return stackIntercept<WriteArrowRequest, WriteArrowResponse>("unary", this._transport, method, opt, input);
+    }
+}
+/**
+ * AdminService exposes the state of the profile store to operators
+ *
+ * @generated from protobuf service parca.profilestore.v1alpha1.AdminService
+ */
+export interface IAdminServiceClient {
+    /**
+     * Usage returns the usage of the ingestion limits of the tenants. Requests of the configured admin tenant get the
+     * usage of all tenants, requests of other tenants, including the default tenant, only the usage of their own limits.
+     *
+     * @generated from protobuf rpc: Usage(parca.profilestore.v1alpha1.UsageRequest) returns (parca.profilestore.v1alpha1.UsageResponse);
+     */
+    usage(input: UsageRequest, options?: RpcOptions): UnaryCall<UsageRequest, UsageResponse>;
+}
+/**
+ * AdminService exposes the state of the profile store to operators
+ *
+ * @generated from protobuf service parca.profilestore.v1alpha1.AdminService
+ */
+export class AdminServiceClient implements IAdminServiceClient, ServiceInfo {
+    typeName = AdminService.typeName;
+    methods = AdminService.methods;
+    options = AdminService.options;
+    constructor(private readonly _transport: RpcTransport) {
+    }
+    /**
+     * Usage returns the usage of the ingestion limits of the tenants. Requests of the configured admin tenant get the
+     * usage of all tenants, requests of other tenants, including the default tenant, only the usage of their own limits.
+     *
+     * @generated from protobuf rpc: Usage(parca.profilestore.v1alpha1.UsageRequest) returns (parca.profilestore.v1alpha1.UsageResponse);
+     */
+    usage(input: UsageRequest, options?: RpcOptions): UnaryCall<UsageRequest, UsageResponse> {
+        const method = this.methods[0], opt = this._transport.mergeOptions(options);
+        return stackIntercept<UsageRequest, UsageResponse>("unary", this._transport, method, opt, input);
+    }
 }
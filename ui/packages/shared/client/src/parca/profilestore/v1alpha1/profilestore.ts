@@ -109,6 +109,302 @@ export interface RawSample {
      */
     rawProfile: Uint8Array;
 }
+/**
+ * WriteArrowRequest writes samples that are already in columnar form
+ *
+ * @generated from protobuf message parca.profilestore.v1alpha1.WriteArrowRequest
+ */
+export interface WriteArrowRequest {
+    /**
+     * record is an Arrow IPC stream of record batches with the columns of the parca schema. Every row is a sample.
+     * The stacktrace column is a list of uint64 location ids referencing the locations of the request, leaf first.
+     * The labels, pprof_labels and pprof_num_labels columns are dynamic, e.g. labels.job.
+     *
+     * @generated from protobuf field: bytes record = 1;
+     */
+    record: Uint8Array;
+    /**
+     * locations are the locations referenced by the stacktrace column
+     *
+     * @generated from protobuf field: repeated parca.profilestore.v1alpha1.ArrowLocation locations = 2;
+     */
+    locations: ArrowLocation[];
+    /**
+     * functions are the functions referenced by the lines of the locations
+     *
+     * @generated from protobuf field: repeated parca.profilestore.v1alpha1.ArrowFunction functions = 3;
+     */
+    functions: ArrowFunction[];
+    /**
+     * mappings are the mappings referenced by the locations
+     *
+     * @generated from protobuf field: repeated parca.profilestore.v1alpha1.ArrowMapping mappings = 4;
+     */
+    mappings: ArrowMapping[];
+    /**
+     * normalized is a flag indicating if the addresses in the locations are normalized for position independent code
+     *
+     * @generated from protobuf field: bool normalized = 5;
+     */
+    normalized: boolean;
+}
+/**
+ * WriteArrowResponse is the empty response
+ *
+ * @generated from protobuf message parca.profilestore.v1alpha1.WriteArrowResponse
+ */
+export interface WriteArrowResponse {
+}
+/**
+ * ArrowLocation is a location of the dictionary of a WriteArrowRequest
+ *
+ * @generated from protobuf message parca.profilestore.v1alpha1.ArrowLocation
+ */
+export interface ArrowLocation {
+    /**
+     * id is the id of the location referenced by the stacktrace column
+     *
+     * @generated from protobuf field: uint64 id = 1;
+     */
+    id: string;
+    /**
+     * address is the instruction address of the location
+     *
+     * @generated from protobuf field: uint64 address = 2;
+     */
+    address: string;
+    /**
+     * mapping_id is the id of the mapping of the location, 0 if there is none
+     *
+     * @generated from protobuf field: uint64 mapping_id = 3;
+     */
+    mappingId: string;
+    /**
+     * lines are the lines of the location, the last one is the caller into which the preceding ones were inlined
+     *
+     * @generated from protobuf field: repeated parca.profilestore.v1alpha1.ArrowLine lines = 4;
+     */
+    lines: ArrowLine[];
+    /**
+     * is_folded indicates that multiple symbols map to the address of the location
+     *
+     * @generated from protobuf field: bool is_folded = 5;
+     */
+    isFolded: boolean;
+}
+/**
+ * ArrowLine is a line of an ArrowLocation
+ *
+ * @generated from protobuf message parca.profilestore.v1alpha1.ArrowLine
+ */
+export interface ArrowLine {
+    /**
+     * function_id is the id of the function of the line
+     *
+     * @generated from protobuf field: uint64 function_id = 1;
+     */
+    functionId: string;
+    /**
+     * line is the line number in the source code
+     *
+     * @generated from protobuf field: int64 line = 2;
+     */
+    line: string;
+}
+/**
+ * ArrowFunction is a function of the dictionary of a WriteArrowRequest
+ *
+ * @generated from protobuf message parca.profilestore.v1alpha1.ArrowFunction
+ */
+export interface ArrowFunction {
+    /**
+     * id is the id of the function referenced by the lines of the locations
+     *
+     * @generated from protobuf field: uint64 id = 1;
+     */
+    id: string;
+    /**
+     * name is the name of the function
+     *
+     * @generated from protobuf field: string name = 2;
+     */
+    name: string;
+    /**
+     * system_name is the name of the function as identified by the system
+     *
+     * @generated from protobuf field: string system_name = 3;
+     */
+    systemName: string;
+    /**
+     * filename is the source file containing the function
+     *
+     * @generated from protobuf field: string filename = 4;
+     */
+    filename: string;
+    /**
+     * start_line is the line number in the source file of the start of the function
+     *
+     * @generated from protobuf field: int64 start_line = 5;
+     */
+    startLine: string;
+}
+/**
+ * ArrowMapping is a mapping of the dictionary of a WriteArrowRequest
+ *
+ * @generated from protobuf message parca.profilestore.v1alpha1.ArrowMapping
+ */
+export interface ArrowMapping {
+    /**
+     * id is the id of the mapping referenced by the locations
+     *
+     * @generated from protobuf field: uint64 id = 1;
+     */
+    id: string;
+    /**
+     * start is the address at which the binary is loaded into memory
+     *
+     * @generated from protobuf field: uint64 start = 2;
+     */
+    start: string;
+    /**
+     * limit is the limit of the address range occupied by the mapping
+     *
+     * @generated from protobuf field: uint64 limit = 3;
+     */
+    limit: string;
+    /**
+     * offset is the offset in the binary that corresponds to the first mapped address
+     *
+     * @generated from protobuf field: uint64 offset = 4;
+     */
+    offset: string;
+    /**
+     * file is the name of the mapped binary
+     *
+     * @generated from protobuf field: string file = 5;
+     */
+    file: string;
+    /**
+     * build_id is the build id of the mapped binary
+     *
+     * @generated from protobuf field: string build_id = 6;
+     */
+    buildId: string;
+}
+/**
+ * UsageRequest is the empty request
+ *
+ * @generated from protobuf message parca.profilestore.v1alpha1.UsageRequest
+ */
+export interface UsageRequest {
+}
+/**
+ * UsageResponse contains the usage of the ingestion limits
+ *
+ * @generated from protobuf message parca.profilestore.v1alpha1.UsageResponse
+ */
+export interface UsageResponse {
+    /**
+     * usage is the usage of the limits of every tenant, and of every label selector of a tenant that overrides them
+     *
+     * @generated from protobuf field: repeated parca.profilestore.v1alpha1.LimitsUsage usage = 1;
+     */
+    usage: LimitsUsage[];
+}
+/**
+ * LimitsUsage is the usage of the ingestion limits of a tenant, or of the series of a tenant matching a label selector
+ *
+ * @generated from protobuf message parca.profilestore.v1alpha1.LimitsUsage
+ */
+export interface LimitsUsage {
+    /**
+     * tenant is the tenant the limits apply to, empty for the default tenant
+     *
+     * @generated from protobuf field: string tenant = 1;
+     */
+    tenant: string;
+    /**
+     * selector is the label selector of the series the limits apply to, empty for the series not matching a selector
+     *
+     * @generated from protobuf field: string selector = 2;
+     */
+    selector: string;
+    /**
+     * limits are the limits in effect, zero limits are disabled
+     *
+     * @generated from protobuf field: parca.profilestore.v1alpha1.IngestLimits limits = 3;
+     */
+    limits?: IngestLimits;
+    /**
+     * available_profiles is the number of profiles that can be written before the profiles per second limit is hit
+     *
+     * @generated from protobuf field: double available_profiles = 4;
+     */
+    availableProfiles: number;
+    /**
+     * available_bytes is the number of bytes that can be written before the bytes per second limit is hit
+     *
+     * @generated from protobuf field: double available_bytes = 5;
+     */
+    availableBytes: number;
+    /**
+     * active_series is the number of series that received profiles recently
+     *
+     * @generated from protobuf field: int64 active_series = 6;
+     */
+    activeSeries: string;
+    /**
+     * rejected_profiles is the number of profiles rejected for exceeding a limit, keyed by the rejection reason
+     *
+     * @generated from protobuf field: map<string, int64> rejected_profiles = 7;
+     */
+    rejectedProfiles: {
+        [key: string]: string;
+    };
+}
+/**
+ * IngestLimits bound the profiles written by a tenant
+ *
+ * @generated from protobuf message parca.profilestore.v1alpha1.IngestLimits
+ */
+export interface IngestLimits {
+    /**
+     * profiles_per_second is the rate at which profiles can be written
+     *
+     * @generated from protobuf field: double profiles_per_second = 1;
+     */
+    profilesPerSecond: number;
+    /**
+     * profiles_burst is the number of profiles that can be written at once
+     *
+     * @generated from protobuf field: int64 profiles_burst = 2;
+     */
+    profilesBurst: string;
+    /**
+     * bytes_per_second is the rate at which bytes of raw profiles can be written
+     *
+     * @generated from protobuf field: double bytes_per_second = 3;
+     */
+    bytesPerSecond: number;
+    /**
+     * bytes_burst is the number of bytes that can be written at once
+     *
+     * @generated from protobuf field: int64 bytes_burst = 4;
+     */
+    bytesBurst: string;
+    /**
+     * max_samples_per_profile is the maximum number of samples of a profile
+     *
+     * @generated from protobuf field: int64 max_samples_per_profile = 5;
+     */
+    maxSamplesPerProfile: string;
+    /**
+     * max_active_series is the maximum number of series that received profiles recently
+     *
+     * @generated from protobuf field: int64 max_active_series = 6;
+     */
+    maxActiveSeries: string;
+}
 // @generated message type with reflection information, may provide speed optimized methods
 class WriteRawRequest$Type extends MessageType<WriteRawRequest> {
     constructor() {
@@ -398,9 +694,663 @@ class RawSample$Type extends MessageType<RawSample> {
  * @generated MessageType for protobuf message parca.profilestore.v1alpha1.RawSample
  */
 export const RawSample = new RawSample$Type();
+// @generated message type with reflection information, may provide speed optimized methods
+class WriteArrowRequest$Type extends MessageType<WriteArrowRequest> {
+    constructor() {
+        super("parca.profilestore.v1alpha1.WriteArrowRequest", [
+            { no: 1, name: "record", kind: "scalar", T: 12 /*ScalarType.BYTES*/ },
+            { no: 2, name: "locations", kind: "message", repeat: 1 /*RepeatType.PACKED*/, T: () => ArrowLocation },
+            { no: 3, name: "functions", kind: "message", repeat: 1 /*RepeatType.PACKED*/, T: () => ArrowFunction },
+            { no: 4, name: "mappings", kind: "message", repeat: 1 /*RepeatType.PACKED*/, T: () => ArrowMapping },
+            { no: 5, name: "normalized", kind: "scalar", T: 8 /*ScalarType.BOOL*/ }
+        ]);
+    }
+    create(value?: PartialMessage<WriteArrowRequest>): WriteArrowRequest {
+        const message = { record: new Uint8Array(0), locations: [], functions: [], mappings: [], normalized: false };
+        globalThis.Object.defineProperty(message, MESSAGE_TYPE, { enumerable: false, value: this });
+        if (value !== undefined)
+            reflectionMergePartial<WriteArrowRequest>(this, message, value);
+        return message;
+    }
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: WriteArrowRequest): WriteArrowRequest {
+        let message = target ?? this.create(), end = reader.pos + length;
+        while (reader.pos < end) {
+            let [fieldNo, wireType] = reader.tag();
+            switch (fieldNo) {
+                case /* bytes record */ 1:
+                    message.record = reader.bytes();
+                    break;
+                case /* repeated parca.profilestore.v1alpha1.ArrowLocation locations */ 2:
+                    message.locations.push(ArrowLocation.internalBinaryRead(reader, reader.uint32(), options));
+                    break;
+                case /* repeated parca.profilestore.v1alpha1.ArrowFunction functions */ 3:
+                    message.functions.push(ArrowFunction.internalBinaryRead(reader, reader.uint32(), options));
+                    break;
+                case /* repeated parca.profilestore.v1alpha1.ArrowMapping mappings */ 4:
+                    message.mappings.push(ArrowMapping.internalBinaryRead(reader, reader.uint32(), options));
+                    break;
+                case /* bool normalized */ 5:
+                    message.normalized = reader.bool();
+                    break;
+                default:
+                    let u = options.readUnknownField;
+                    if (u === "throw")
+                        throw new globalThis.Error(`Unknown field ${fieldNo} (wire type ${wireType}) for ${this.typeName}`);
+                    let d = reader.skip(wireType);
+                    if (u !== false)
+                        (u === true ? UnknownFieldHandler.onRead : u)(this.typeName, message, fieldNo, wireType, d);
+            }
+        }
+        return message;
+    }
+    internalBinaryWrite(message: WriteArrowRequest, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* bytes record = 1; */
+        if (message.record.length)
+            writer.tag(1, WireType.LengthDelimited).bytes(message.record);
+        /* repeated parca.profilestore.v1alpha1.ArrowLocation locations = 2; */
+        for (let i = 0; i < message.locations.length; i++)
+            ArrowLocation.internalBinaryWrite(message.locations[i], writer.tag(2, WireType.LengthDelimited).fork(), options).join();
+        /* repeated parca.profilestore.v1alpha1.ArrowFunction functions = 3; */
+        for (let i = 0; i < message.functions.length; i++)
+            ArrowFunction.internalBinaryWrite(message.functions[i], writer.tag(3, WireType.LengthDelimited).fork(), options).join();
+        /* repeated parca.profilestore.v1alpha1.ArrowMapping mappings = 4; */
+        for (let i = 0; i < message.mappings.length; i++)
+            ArrowMapping.internalBinaryWrite(message.mappings[i], writer.tag(4, WireType.LengthDelimited).fork(), options).join();
+        /* bool normalized = 5; */
+        if (message.normalized !== false)
+            writer.tag(5, WireType.Varint).bool(message.normalized);
+        let u = options.writeUnknownFields;
+        if (u !== false)
+            (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
+        return writer;
+    }
+}
+/**
+ * @generated MessageType for protobuf message parca.profilestore.v1alpha1.WriteArrowRequest
+ */
+export const WriteArrowRequest = new WriteArrowRequest$Type();
+// @generated message type with reflection information, may provide speed optimized methods
+class WriteArrowResponse$Type extends MessageType<WriteArrowResponse> {
+    constructor() {
+        super("parca.profilestore.v1alpha1.WriteArrowResponse", []);
+    }
+    create(value?: PartialMessage<WriteArrowResponse>): WriteArrowResponse {
+        const message = {};
+        globalThis.Object.defineProperty(message, MESSAGE_TYPE, { enumerable: false, value: this });
+        if (value !== undefined)
+            reflectionMergePartial<WriteArrowResponse>(this, message, value);
+        return message;
+    }
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: WriteArrowResponse): WriteArrowResponse {
+        return target ?? this.create();
+    }
+    internalBinaryWrite(message: WriteArrowResponse, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        let u = options.writeUnknownFields;
+        if (u !== false)
+            (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
+        return writer;
+    }
+}
+/**
+ * @generated MessageType for protobuf message parca.profilestore.v1alpha1.WriteArrowResponse
+ */
+export const WriteArrowResponse = new WriteArrowResponse$Type();
+// @generated message type with reflection information, may provide speed optimized methods
+class ArrowLocation$Type extends MessageType<ArrowLocation> {
+    constructor() {
+        super("parca.profilestore.v1alpha1.ArrowLocation", [
+            { no: 1, name: "id", kind: "scalar", T: 4 /*ScalarType.UINT64*/ },
+            { no: 2, name: "address", kind: "scalar", T: 4 /*ScalarType.UINT64*/ },
+            { no: 3, name: "mapping_id", kind: "scalar", T: 4 /*ScalarType.UINT64*/ },
+            { no: 4, name: "lines", kind: "message", repeat: 1 /*RepeatType.PACKED*/, T: () => ArrowLine },
+            { no: 5, name: "is_folded", kind: "scalar", T: 8 /*ScalarType.BOOL*/ }
+        ]);
+    }
+    create(value?: PartialMessage<ArrowLocation>): ArrowLocation {
+        const message = { id: "0", address: "0", mappingId: "0", lines: [], isFolded: false };
+        globalThis.Object.defineProperty(message, MESSAGE_TYPE, { enumerable: false, value: this });
+        if (value !== undefined)
+            reflectionMergePartial<ArrowLocation>(this, message, value);
+        return message;
+    }
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: ArrowLocation): ArrowLocation {
+        let message = target ?? this.create(), end = reader.pos + length;
+        while (reader.pos < end) {
+            let [fieldNo, wireType] = reader.tag();
+            switch (fieldNo) {
+                case /* uint64 id */ 1:
+                    message.id = reader.uint64().toString();
+                    break;
+                case /* uint64 address */ 2:
+                    message.address = reader.uint64().toString();
+                    break;
+                case /* uint64 mapping_id */ 3:
+                    message.mappingId = reader.uint64().toString();
+                    break;
+                case /* repeated parca.profilestore.v1alpha1.ArrowLine lines */ 4:
+                    message.lines.push(ArrowLine.internalBinaryRead(reader, reader.uint32(), options));
+                    break;
+                case /* bool is_folded */ 5:
+                    message.isFolded = reader.bool();
+                    break;
+                default:
+                    let u = options.readUnknownField;
+                    if (u === "throw")
+                        throw new globalThis.Error(`Unknown field ${fieldNo} (wire type ${wireType}) for ${this.typeName}`);
+                    let d = reader.skip(wireType);
+                    if (u !== false)
+                        (u === true ? UnknownFieldHandler.onRead : u)(this.typeName, message, fieldNo, wireType, d);
+            }
+        }
+        return message;
+    }
+    internalBinaryWrite(message: ArrowLocation, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* uint64 id = 1; */
+        if (message.id !== "0")
+            writer.tag(1, WireType.Varint).uint64(message.id);
+        /* uint64 address = 2; */
+        if (message.address !== "0")
+            writer.tag(2, WireType.Varint).uint64(message.address);
+        /* uint64 mapping_id = 3; */
+        if (message.mappingId !== "0")
+            writer.tag(3, WireType.Varint).uint64(message.mappingId);
+        /* repeated parca.profilestore.v1alpha1.ArrowLine lines = 4; */
+        for (let i = 0; i < message.lines.length; i++)
+            ArrowLine.internalBinaryWrite(message.lines[i], writer.tag(4, WireType.LengthDelimited).fork(), options).join();
+        /* bool is_folded = 5; */
+        if (message.isFolded !== false)
+            writer.tag(5, WireType.Varint).bool(message.isFolded);
+        let u = options.writeUnknownFields;
+        if (u !== false)
+            (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
+        return writer;
+    }
+}
+/**
+ * @generated MessageType for protobuf message parca.profilestore.v1alpha1.ArrowLocation
+ */
+export const ArrowLocation = new ArrowLocation$Type();
+// @generated message type with reflection information, may provide speed optimized methods
+class ArrowLine$Type extends MessageType<ArrowLine> {
+    constructor() {
+        super("parca.profilestore.v1alpha1.ArrowLine", [
+            { no: 1, name: "function_id", kind: "scalar", T: 4 /*ScalarType.UINT64*/ },
+            { no: 2, name: "line", kind: "scalar", T: 3 /*ScalarType.INT64*/ }
+        ]);
+    }
+    create(value?: PartialMessage<ArrowLine>): ArrowLine {
+        const message = { functionId: "0", line: "0" };
+        globalThis.Object.defineProperty(message, MESSAGE_TYPE, { enumerable: false, value: this });
+        if (value !== undefined)
+            reflectionMergePartial<ArrowLine>(this, message, value);
+        return message;
+    }
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: ArrowLine): ArrowLine {
+        let message = target ?? this.create(), end = reader.pos + length;
+        while (reader.pos < end) {
+            let [fieldNo, wireType] = reader.tag();
+            switch (fieldNo) {
+                case /* uint64 function_id */ 1:
+                    message.functionId = reader.uint64().toString();
+                    break;
+                case /* int64 line */ 2:
+                    message.line = reader.int64().toString();
+                    break;
+                default:
+                    let u = options.readUnknownField;
+                    if (u === "throw")
+                        throw new globalThis.Error(`Unknown field ${fieldNo} (wire type ${wireType}) for ${this.typeName}`);
+                    let d = reader.skip(wireType);
+                    if (u !== false)
+                        (u === true ? UnknownFieldHandler.onRead : u)(this.typeName, message, fieldNo, wireType, d);
+            }
+        }
+        return message;
+    }
+    internalBinaryWrite(message: ArrowLine, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* uint64 function_id = 1; */
+        if (message.functionId !== "0")
+            writer.tag(1, WireType.Varint).uint64(message.functionId);
+        /* int64 line = 2; */
+        if (message.line !== "0")
+            writer.tag(2, WireType.Varint).int64(message.line);
+        let u = options.writeUnknownFields;
+        if (u !== false)
+            (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
+        return writer;
+    }
+}
+/**
+ * @generated MessageType for protobuf message parca.profilestore.v1alpha1.ArrowLine
+ */
+export const ArrowLine = new ArrowLine$Type();
+// @generated message type with reflection information, may provide speed optimized methods
+class ArrowFunction$Type extends MessageType<ArrowFunction> {
+    constructor() {
+        super("parca.profilestore.v1alpha1.ArrowFunction", [
+            { no: 1, name: "id", kind: "scalar", T: 4 /*ScalarType.UINT64*/ },
+            { no: 2, name: "name", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 3, name: "system_name", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 4, name: "filename", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 5, name: "start_line", kind: "scalar", T: 3 /*ScalarType.INT64*/ }
+        ]);
+    }
+    create(value?: PartialMessage<ArrowFunction>): ArrowFunction {
+        const message = { id: "0", name: "", systemName: "", filename: "", startLine: "0" };
+        globalThis.Object.defineProperty(message, MESSAGE_TYPE, { enumerable: false, value: this });
+        if (value !== undefined)
+            reflectionMergePartial<ArrowFunction>(this, message, value);
+        return message;
+    }
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: ArrowFunction): ArrowFunction {
+        let message = target ?? this.create(), end = reader.pos + length;
+        while (reader.pos < end) {
+            let [fieldNo, wireType] = reader.tag();
+            switch (fieldNo) {
+                case /* uint64 id */ 1:
+                    message.id = reader.uint64().toString();
+                    break;
+                case /* string name */ 2:
+                    message.name = reader.string();
+                    break;
+                case /* string system_name */ 3:
+                    message.systemName = reader.string();
+                    break;
+                case /* string filename */ 4:
+                    message.filename = reader.string();
+                    break;
+                case /* int64 start_line */ 5:
+                    message.startLine = reader.int64().toString();
+                    break;
+                default:
+                    let u = options.readUnknownField;
+                    if (u === "throw")
+                        throw new globalThis.Error(`Unknown field ${fieldNo} (wire type ${wireType}) for ${this.typeName}`);
+                    let d = reader.skip(wireType);
+                    if (u !== false)
+                        (u === true ? UnknownFieldHandler.onRead : u)(this.typeName, message, fieldNo, wireType, d);
+            }
+        }
+        return message;
+    }
+    internalBinaryWrite(message: ArrowFunction, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* uint64 id = 1; */
+        if (message.id !== "0")
+            writer.tag(1, WireType.Varint).uint64(message.id);
+        /* string name = 2; */
+        if (message.name !== "")
+            writer.tag(2, WireType.LengthDelimited).string(message.name);
+        /* string system_name = 3; */
+        if (message.systemName !== "")
+            writer.tag(3, WireType.LengthDelimited).string(message.systemName);
+        /* string filename = 4; */
+        if (message.filename !== "")
+            writer.tag(4, WireType.LengthDelimited).string(message.filename);
+        /* int64 start_line = 5; */
+        if (message.startLine !== "0")
+            writer.tag(5, WireType.Varint).int64(message.startLine);
+        let u = options.writeUnknownFields;
+        if (u !== false)
+            (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
+        return writer;
+    }
+}
+/**
+ * @generated MessageType for protobuf message parca.profilestore.v1alpha1.ArrowFunction
+ */
+export const ArrowFunction = new ArrowFunction$Type();
+// @generated message type with reflection information, may provide speed optimized methods
+class ArrowMapping$Type extends MessageType<ArrowMapping> {
+    constructor() {
+        super("parca.profilestore.v1alpha1.ArrowMapping", [
+            { no: 1, name: "id", kind: "scalar", T: 4 /*ScalarType.UINT64*/ },
+            { no: 2, name: "start", kind: "scalar", T: 4 /*ScalarType.UINT64*/ },
+            { no: 3, name: "limit", kind: "scalar", T: 4 /*ScalarType.UINT64*/ },
+            { no: 4, name: "offset", kind: "scalar", T: 4 /*ScalarType.UINT64*/ },
+            { no: 5, name: "file", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 6, name: "build_id", kind: "scalar", T: 9 /*ScalarType.STRING*/ }
+        ]);
+    }
+    create(value?: PartialMessage<ArrowMapping>): ArrowMapping {
+        const message = { id: "0", start: "0", limit: "0", offset: "0", file: "", buildId: "" };
+        globalThis.Object.defineProperty(message, MESSAGE_TYPE, { enumerable: false, value: this });
+        if (value !== undefined)
+            reflectionMergePartial<ArrowMapping>(this, message, value);
+        return message;
+    }
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: ArrowMapping): ArrowMapping {
+        let message = target ?? this.create(), end = reader.pos + length;
+        while (reader.pos < end) {
+            let [fieldNo, wireType] = reader.tag();
+            switch (fieldNo) {
+                case /* uint64 id */ 1:
+                    message.id = reader.uint64().toString();
+                    break;
+                case /* uint64 start */ 2:
+                    message.start = reader.uint64().toString();
+                    break;
+                case /* uint64 limit */ 3:
+                    message.limit = reader.uint64().toString();
+                    break;
+                case /* uint64 offset */ 4:
+                    message.offset = reader.uint64().toString();
+                    break;
+                case /* string file */ 5:
+                    message.file = reader.string();
+                    break;
+                case /* string build_id */ 6:
+                    message.buildId = reader.string();
+                    break;
+                default:
+                    let u = options.readUnknownField;
+                    if (u === "throw")
+                        throw new globalThis.Error(`Unknown field ${fieldNo} (wire type ${wireType}) for ${this.typeName}`);
+                    let d = reader.skip(wireType);
+                    if (u !== false)
+                        (u === true ? UnknownFieldHandler.onRead : u)(this.typeName, message, fieldNo, wireType, d);
+            }
+        }
+        return message;
+    }
+    internalBinaryWrite(message: ArrowMapping, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* uint64 id = 1; */
+        if (message.id !== "0")
+            writer.tag(1, WireType.Varint).uint64(message.id);
+        /* uint64 start = 2; */
+        if (message.start !== "0")
+            writer.tag(2, WireType.Varint).uint64(message.start);
+        /* uint64 limit = 3; */
+        if (message.limit !== "0")
+            writer.tag(3, WireType.Varint).uint64(message.limit);
+        /* uint64 offset = 4; */
+        if (message.offset !== "0")
+            writer.tag(4, WireType.Varint).uint64(message.offset);
+        /* string file = 5; */
+        if (message.file !== "")
+            writer.tag(5, WireType.LengthDelimited).string(message.file);
+        /* string build_id = 6; */
+        if (message.buildId !== "")
+            writer.tag(6, WireType.LengthDelimited).string(message.buildId);
+        let u = options.writeUnknownFields;
+        if (u !== false)
+            (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
+        return writer;
+    }
+}
+/**
+ * @generated MessageType for protobuf message parca.profilestore.v1alpha1.ArrowMapping
+ */
+export const ArrowMapping = new ArrowMapping$Type();
+// @generated message type with reflection information, may provide speed optimized methods
+class UsageRequest$Type extends MessageType<UsageRequest> {
+    constructor() {
+        super("parca.profilestore.v1alpha1.UsageRequest", []);
+    }
+    create(value?: PartialMessage<UsageRequest>): UsageRequest {
+        const message = {};
+        globalThis.Object.defineProperty(message, MESSAGE_TYPE, { enumerable: false, value: this });
+        if (value !== undefined)
+            reflectionMergePartial<UsageRequest>(this, message, value);
+        return message;
+    }
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: UsageRequest): UsageRequest {
+        return target ?? this.create();
+    }
+    internalBinaryWrite(message: UsageRequest, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        let u = options.writeUnknownFields;
+        if (u !== false)
+            (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
+        return writer;
+    }
+}
+/**
+ * @generated MessageType for protobuf message parca.profilestore.v1alpha1.UsageRequest
+ */
+export const UsageRequest = new UsageRequest$Type();
+// @generated message type with reflection information, may provide speed optimized methods
+class UsageResponse$Type extends MessageType<UsageResponse> {
+    constructor() {
+        super("parca.profilestore.v1alpha1.UsageResponse", [
+            { no: 1, name: "usage", kind: "message", repeat: 1 /*RepeatType.PACKED*/, T: () => LimitsUsage }
+        ]);
+    }
+    create(value?: PartialMessage<UsageResponse>): UsageResponse {
+        const message = { usage: [] };
+        globalThis.Object.defineProperty(message, MESSAGE_TYPE, { enumerable: false, value: this });
+        if (value !== undefined)
+            reflectionMergePartial<UsageResponse>(this, message, value);
+        return message;
+    }
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: UsageResponse): UsageResponse {
+        let message = target ?? this.create(), end = reader.pos + length;
+        while (reader.pos < end) {
+            let [fieldNo, wireType] = reader.tag();
+            switch (fieldNo) {
+                case /* repeated parca.profilestore.v1alpha1.LimitsUsage usage */ 1:
+                    message.usage.push(LimitsUsage.internalBinaryRead(reader, reader.uint32(), options));
+                    break;
+                default:
+                    let u = options.readUnknownField;
+                    if (u === "throw")
+                        throw new globalThis.Error(`Unknown field ${fieldNo} (wire type ${wireType}) for ${this.typeName}`);
+                    let d = reader.skip(wireType);
+                    if (u !== false)
+                        (u === true ? UnknownFieldHandler.onRead : u)(this.typeName, message, fieldNo, wireType, d);
+            }
+        }
+        return message;
+    }
+    internalBinaryWrite(message: UsageResponse, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* repeated parca.profilestore.v1alpha1.LimitsUsage usage = 1; */
+        for (let i = 0; i < message.usage.length; i++)
+            LimitsUsage.internalBinaryWrite(message.usage[i], writer.tag(1, WireType.LengthDelimited).fork(), options).join();
+        let u = options.writeUnknownFields;
+        if (u !== false)
+            (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
+        return writer;
+    }
+}
+/**
+ * @generated MessageType for protobuf message parca.profilestore.v1alpha1.UsageResponse
+ */
+export const UsageResponse = new UsageResponse$Type();
+// @generated message type with reflection information, may provide speed optimized methods
+class LimitsUsage$Type extends MessageType<LimitsUsage> {
+    constructor() {
+        super("parca.profilestore.v1alpha1.LimitsUsage", [
+            { no: 1, name: "tenant", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 2, name: "selector", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 3, name: "limits", kind: "message", T: () => IngestLimits },
+            { no: 4, name: "available_profiles", kind: "scalar", T: 1 /*ScalarType.DOUBLE*/ },
+            { no: 5, name: "available_bytes", kind: "scalar", T: 1 /*ScalarType.DOUBLE*/ },
+            { no: 6, name: "active_series", kind: "scalar", T: 3 /*ScalarType.INT64*/ },
+            { no: 7, name: "rejected_profiles", kind: "map", K: 9 /*ScalarType.STRING*/, V: { kind: "scalar", T: 3 /*ScalarType.INT64*/ } }
+        ]);
+    }
+    create(value?: PartialMessage<LimitsUsage>): LimitsUsage {
+        const message = { tenant: "", selector: "", availableProfiles: 0, availableBytes: 0, activeSeries: "0", rejectedProfiles: {} };
+        globalThis.Object.defineProperty(message, MESSAGE_TYPE, { enumerable: false, value: this });
+        if (value !== undefined)
+            reflectionMergePartial<LimitsUsage>(this, message, value);
+        return message;
+    }
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: LimitsUsage): LimitsUsage {
+        let message = target ?? this.create(), end = reader.pos + length;
+        while (reader.pos < end) {
+            let [fieldNo, wireType] = reader.tag();
+            switch (fieldNo) {
+                case /* string tenant */ 1:
+                    message.tenant = reader.string();
+                    break;
+                case /* string selector */ 2:
+                    message.selector = reader.string();
+                    break;
+                case /* parca.profilestore.v1alpha1.IngestLimits limits */ 3:
+                    message.limits = IngestLimits.internalBinaryRead(reader, reader.uint32(), options, message.limits);
+                    break;
+                case /* double available_profiles */ 4:
+                    message.availableProfiles = reader.double();
+                    break;
+                case /* double available_bytes */ 5:
+                    message.availableBytes = reader.double();
+                    break;
+                case /* int64 active_series */ 6:
+                    message.activeSeries = reader.int64().toString();
+                    break;
+                case /* map<string, int64> rejected_profiles */ 7:
+                    this.binaryReadMap7(message.rejectedProfiles, reader, options);
+                    break;
+                default:
+                    let u = options.readUnknownField;
+                    if (u === "throw")
+                        throw new globalThis.Error(`Unknown field ${fieldNo} (wire type ${wireType}) for ${this.typeName}`);
+                    let d = reader.skip(wireType);
+                    if (u !== false)
+                        (u === true ? UnknownFieldHandler.onRead : u)(this.typeName, message, fieldNo, wireType, d);
+            }
+        }
+        return message;
+    }
+    private binaryReadMap7(map: LimitsUsage["rejectedProfiles"], reader: IBinaryReader, options: BinaryReadOptions): void {
+        let len = reader.uint32(), end = reader.pos + len, key: keyof LimitsUsage["rejectedProfiles"] | undefined, val: LimitsUsage["rejectedProfiles"][any] | undefined;
+        while (reader.pos < end) {
+            let [fieldNo, wireType] = reader.tag();
+            switch (fieldNo) {
+                case 1:
+                    key = reader.string();
+                    break;
+                case 2:
+                    val = reader.int64().toString();
+                    break;
+                default: throw new globalThis.Error("unknown map entry field for field parca.profilestore.v1alpha1.LimitsUsage.rejected_profiles");
+            }
+        }
+        map[key ?? ""] = val ?? "0";
+    }
+    internalBinaryWrite(message: LimitsUsage, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* string tenant = 1; */
+        if (message.tenant !== "")
+            writer.tag(1, WireType.LengthDelimited).string(message.tenant);
+        /* string selector = 2; */
+        if (message.selector !== "")
+            writer.tag(2, WireType.LengthDelimited).string(message.selector);
+        /* parca.profilestore.v1alpha1.IngestLimits limits = 3; */
+        if (message.limits)
+            IngestLimits.internalBinaryWrite(message.limits, writer.tag(3, WireType.LengthDelimited).fork(), options).join();
+        /* double available_profiles = 4; */
+        if (message.availableProfiles !== 0)
+            writer.tag(4, WireType.Bit64).double(message.availableProfiles);
+        /* double available_bytes = 5; */
+        if (message.availableBytes !== 0)
+            writer.tag(5, WireType.Bit64).double(message.availableBytes);
+        /* int64 active_series = 6; */
+        if (message.activeSeries !== "0")
+            writer.tag(6, WireType.Varint).int64(message.activeSeries);
+        /* map<string, int64> rejected_profiles = 7; */
+        for (let k of Object.keys(message.rejectedProfiles))
+            writer.tag(7, WireType.LengthDelimited).fork().tag(1, WireType.LengthDelimited).string(k).tag(2, WireType.Varint).int64(message.rejectedProfiles[k]).join();
+        let u = options.writeUnknownFields;
+        if (u !== false)
+            (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
+        return writer;
+    }
+}
+/**
+ * @generated MessageType for protobuf message parca.profilestore.v1alpha1.LimitsUsage
+ */
+export const LimitsUsage = new LimitsUsage$Type();
+// @generated message type with reflection information, may provide speed optimized methods
+class IngestLimits$Type extends MessageType<IngestLimits> {
+    constructor() {
+        super("parca.profilestore.v1alpha1.IngestLimits", [
+            { no: 1, name: "profiles_per_second", kind: "scalar", T: 1 /*ScalarType.DOUBLE*/ },
+            { no: 2, name: "profiles_burst", kind: "scalar", T: 3 /*ScalarType.INT64*/ },
+            { no: 3, name: "bytes_per_second", kind: "scalar", T: 1 /*ScalarType.DOUBLE*/ },
+            { no: 4, name: "bytes_burst", kind: "scalar", T: 3 /*ScalarType.INT64*/ },
+            { no: 5, name: "max_samples_per_profile", kind: "scalar", T: 3 /*ScalarType.INT64*/ },
+            { no: 6, name: "max_active_series", kind: "scalar", T: 3 /*ScalarType.INT64*/ }
+        ]);
+    }
+    create(value?: PartialMessage<IngestLimits>): IngestLimits {
+        const message = { profilesPerSecond: 0, profilesBurst: "0", bytesPerSecond: 0, bytesBurst: "0", maxSamplesPerProfile: "0", maxActiveSeries: "0" };
+        globalThis.Object.defineProperty(message, MESSAGE_TYPE, { enumerable: false, value: this });
+        if (value !== undefined)
+            reflectionMergePartial<IngestLimits>(this, message, value);
+        return message;
+    }
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: IngestLimits): IngestLimits {
+        let message = target ?? this.create(), end = reader.pos + length;
+        while (reader.pos < end) {
+            let [fieldNo, wireType] = reader.tag();
+            switch (fieldNo) {
+                case /* double profiles_per_second */ 1:
+                    message.profilesPerSecond = reader.double();
+                    break;
+                case /* int64 profiles_burst */ 2:
+                    message.profilesBurst = reader.int64().toString();
+                    break;
+                case /* double bytes_per_second */ 3:
+                    message.bytesPerSecond = reader.double();
+                    break;
+                case /* int64 bytes_burst */ 4:
+                    message.bytesBurst = reader.int64().toString();
+                    break;
+                case /* int64 max_samples_per_profile */ 5:
+                    message.maxSamplesPerProfile = reader.int64().toString();
+                    break;
+                case /* int64 max_active_series */ 6:
+                    message.maxActiveSeries = reader.int64().toString();
+                    break;
+                default:
+                    let u = options.readUnknownField;
+                    if (u === "throw")
+                        throw new globalThis.Error(`Unknown field ${fieldNo} (wire type ${wireType}) for ${this.typeName}`);
+                    let d = reader.skip(wireType);
+                    if (u !== false)
+                        (u === true ? UnknownFieldHandler.onRead : u)(this.typeName, message, fieldNo, wireType, d);
+            }
+        }
+        return message;
+    }
+    internalBinaryWrite(message: IngestLimits, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* double profiles_per_second = 1; */
+        if (message.profilesPerSecond !== 0)
+            writer.tag(1, WireType.Bit64).double(message.profilesPerSecond);
+        /* int64 profiles_burst = 2; */
+        if (message.profilesBurst !== "0")
+            writer.tag(2, WireType.Varint).int64(message.profilesBurst);
+        /* double bytes_per_second = 3; */
+        if (message.bytesPerSecond !== 0)
+            writer.tag(3, WireType.Bit64).double(message.bytesPerSecond);
+        /* int64 bytes_burst = 4; */
+        if (message.bytesBurst !== "0")
+            writer.tag(4, WireType.Varint).int64(message.bytesBurst);
+        /* int64 max_samples_per_profile = 5; */
+        if (message.maxSamplesPerProfile !== "0")
+            writer.tag(5, WireType.Varint).int64(message.maxSamplesPerProfile);
+        /* int64 max_active_series = 6; */
+        if (message.maxActiveSeries !== "0")
+            writer.tag(6, WireType.Varint).int64(message.maxActiveSeries);
+        let u = options.writeUnknownFields;
+        if (u !== false)
+            (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
+        return writer;
+    }
+}
+/**
+ * @generated MessageType for protobuf message parca.profilestore.v1alpha1.IngestLimits
+ */
+export const IngestLimits = new IngestLimits$Type();
 /**
  * @generated ServiceType for protobuf service parca.profilestore.v1alpha1.ProfileStoreService
  */
 export const ProfileStoreService = new ServiceType("parca.profilestore.v1alpha1.ProfileStoreService", [
-    { name: "WriteRaw", options: { "google.api.http": { post: "/profiles/writeraw", body: "*" } }, I: WriteRawRequest, O: WriteRawResponse }
+    { name: "WriteRaw", options: { "google.api.http": { post: "/profiles/writeraw", body: "*" } }, I: WriteRawRequest, O: WriteRawResponse },
+    { name: "WriteArrow", options: { "google.api.http": { post: "/profiles/writearrow", body: "*" } }, I: WriteArrowRequest, O: WriteArrowResponse }
+]);
+/**
+ * @generated ServiceType for protobuf service parca.profilestore.v1alpha1.AdminService
+ */
+export const AdminService = new ServiceType("parca.profilestore.v1alpha1.AdminService", [
+    { name: "Usage", options: { "google.api.http": { get: "/profiles/usage" } }, I: UsageRequest, O: UsageResponse }
 ]);
@@ -4,6 +4,8 @@
 import type { RpcTransport } from "@protobuf-ts/runtime-rpc";
 import type { ServiceInfo } from "@protobuf-ts/runtime-rpc";
 import { QueryService } from "./query";
+import type { GetRawProfileResponse } from "./query";
+import type { GetRawProfileRequest } from "./query";
 import type { ValuesResponse } from "./query";
 import type { ValuesRequest } from "./query";
 import type { LabelsResponse } from "./query";
@@ -38,7 +40,7 @@ export interface IQueryServiceClient {
      */
     query(input: QueryRequest, options?: RpcOptions): UnaryCall<QueryRequest, QueryResponse>;
     /**
-     * Series is unimplemented
+     * Series returns the label sets and profile types of the series matching the selectors
      *
      * @generated from protobuf rpc: Series(parca.query.v1alpha1.SeriesRequest) returns (parca.query.v1alpha1.SeriesResponse);
      */
@@ -61,6 +63,12 @@ export interface IQueryServiceClient {
      * @generated from protobuf rpc: Values(parca.query.v1alpha1.ValuesRequest) returns (parca.query.v1alpha1.ValuesResponse);
      */
     values(input: ValuesRequest, options?: RpcOptions): UnaryCall<ValuesRequest, ValuesResponse>;
+    /**
+     * GetRawProfile returns a profile exactly as it was ingested, if raw profiles are archived
+     *
+     * @generated from protobuf rpc: GetRawProfile(parca.query.v1alpha1.GetRawProfileRequest) returns (parca.query.v1alpha1.GetRawProfileResponse);
+     */
+    getRawProfile(input: GetRawProfileRequest, options?: RpcOptions): UnaryCall<GetRawProfileRequest, GetRawProfileResponse>;
 }
 /**
  * QueryService is the service that provides APIs to retrieve and inspect profiles
@@ -92,7 +100,7 @@ export class QueryServiceClient implements IQueryServiceClient, ServiceInfo {
         return stackIntercept<QueryRequest, QueryResponse>("unary", this._transport, method, opt, input);
     }
     /**
-     * Series is unimplemented
+     * Series returns the label sets and profile types of the series matching the selectors
      *
      * @generated from protobuf rpc: Series(parca.query.v1alpha1.SeriesRequest) returns (parca.query.v1alpha1.SeriesResponse);
      */
@@ -127,4 +135,13 @@ export class QueryServiceClient implements IQueryServiceClient, ServiceInfo {
         const method = this.methods[5], opt = this._transport.mergeOptions(options);
         return stackIntercept<ValuesRequest, ValuesResponse>("unary", this._transport, method, opt, input);
     }
+    /**
+     * GetRawProfile returns a profile exactly as it was ingested, if raw profiles are archived
+     *
+     * @generated from protobuf rpc: GetRawProfile(parca.query.v1alpha1.GetRawProfileRequest) returns (parca.query.v1alpha1.GetRawProfileResponse);
+     */
+    getRawProfile(input: GetRawProfileRequest, options?: RpcOptions): UnaryCall<GetRawProfileRequest, GetRawProfileResponse> {
+        const method = this.methods[6], opt = this._transport.mergeOptions(options);
+        return stackIntercept<GetRawProfileRequest, GetRawProfileResponse>("unary", this._transport, method, opt, input);
+    }
 }
@@ -12,11 +12,13 @@ import type { PartialMessage } from "@protobuf-ts/runtime";
 import { reflectionMergePartial } from "@protobuf-ts/runtime";
 import { MESSAGE_TYPE } from "@protobuf-ts/runtime";
 import { MessageType } from "@protobuf-ts/runtime";
+import { Label } from "../../profilestore/v1alpha1/profilestore";
 import { Line } from "../../metastore/v1alpha1/metastore";
 import { Function } from "../../metastore/v1alpha1/metastore";
 import { Mapping } from "../../metastore/v1alpha1/metastore";
 import { Location } from "../../metastore/v1alpha1/metastore";
 import { LabelSet } from "../../profilestore/v1alpha1/profilestore";
+import { Duration } from "../../../google/protobuf/duration";
 import { Timestamp } from "../../../google/protobuf/timestamp";
 /**
  * ProfileTypesRequest is the request to retrieve the list of available profile types.
@@ -88,7 +90,9 @@ export interface ProfileType {
  */
 export interface QueryRangeRequest {
     /**
-     * query is the query string to match profiles against
+     * query is the query string to match profiles against. Like in PromQL, the selector may be wrapped in rate, then
+     * sum by or without labels, then topk. Unlike in PromQL, topk keeps the k series with the highest totals over the
+     * whole query time window, rather than the k highest series of every step.
      *
      * @generated from protobuf field: string query = 1;
      */
@@ -106,11 +110,52 @@ export interface QueryRangeRequest {
      */
     end?: Timestamp;
     /**
-     * limit is the max number of profiles to include in the response
+     * limit is the max number of series to include in the response, the series with the highest totals are kept
      *
      * @generated from protobuf field: uint32 limit = 4;
      */
     limit: number;
+    /**
+     * step is the duration of the buckets the values of the profiles are aggregated into, aligned to the start of the
+     * query time window. The value of each profile is returned if unset. The storage only sums the values per profile,
+     * the profiles are aggregated into the buckets by the query service, as the storage can't group by a bucketed
+     * timestamp.
+     *
+     * @generated from protobuf field: google.protobuf.Duration step = 5;
+     */
+    step?: Duration;
+    /**
+     * step_function is how the values of the profiles of a series within a step are aggregated. Rates always sum the
+     * values of the profiles within their range.
+     *
+     * @generated from protobuf field: parca.query.v1alpha1.QueryRangeRequest.StepFunction step_function = 6;
+     */
+    stepFunction: QueryRangeRequest_StepFunction;
+}
+/**
+ * StepFunction is how the values of the profiles of a series within a step are aggregated
+ *
+ * @generated from protobuf enum parca.query.v1alpha1.QueryRangeRequest.StepFunction
+ */
+export enum QueryRangeRequest_StepFunction {
+    /**
+     * STEP_FUNCTION_SUM_UNSPECIFIED sums the values
+     *
+     * @generated from protobuf enum value: STEP_FUNCTION_SUM_UNSPECIFIED = 0;
+     */
+    SUM_UNSPECIFIED = 0,
+    /**
+     * STEP_FUNCTION_AVG averages the values, rounded to the nearest integer
+     *
+     * @generated from protobuf enum value: STEP_FUNCTION_AVG = 1;
+     */
+    AVG = 1,
+    /**
+     * STEP_FUNCTION_MAX takes the maximum of the values
+     *
+     * @generated from protobuf enum value: STEP_FUNCTION_MAX = 2;
+     */
+    MAX = 2
 }
 /**
  * QueryRangeResponse is the set of matching profile values
@@ -119,7 +164,8 @@ export interface QueryRangeRequest {
  */
 export interface QueryRangeResponse {
     /**
-     * series is the set of metrics series that satisfy the query range request
+     * series is the set of metrics series that satisfy the query range request, sorted by labels with samples sorted
+     * by timestamp
      *
      * @generated from protobuf field: repeated parca.query.v1alpha1.MetricsSeries series = 1;
      */
@@ -169,11 +215,17 @@ export interface MetricsSample {
      */
     timestamp?: Timestamp;
     /**
-     * value is the cumulative value for the profile
+     * value is the cumulative value for the profile, or value_per_second rounded to the nearest integer for rates
      *
      * @generated from protobuf field: int64 value = 2;
      */
     value: string;
+    /**
+     * value_per_second is the value per second of the window of a rate, zero unless the query is a rate
+     *
+     * @generated from protobuf field: double value_per_second = 3;
+     */
+    valuePerSecond: number;
 }
 /**
  * MergeProfile contains parameters for a merge request
@@ -199,6 +251,20 @@ export interface MergeProfile {
      * @generated from protobuf field: google.protobuf.Timestamp end = 3;
      */
     end?: Timestamp;
+    /**
+     * sample_selector selects the samples to merge by their pprof labels, e.g. {thread="main", bytes>1048576}
+     *
+     * @generated from protobuf field: string sample_selector = 4;
+     */
+    sampleSelector: string;
+    /**
+     * group_by are the labels to partition the merge by, one report is returned per distinct combination of their values.
+     * Series labels are referred to by their name, e.g. pod, pprof labels by their name prefixed with pprof., e.g. pprof.thread.
+     * Not supported in diffs.
+     *
+     * @generated from protobuf field: repeated string group_by = 5;
+     */
+    groupBy: string[];
 }
 /**
  * SingleProfile contains parameters for a single profile query request
@@ -218,6 +284,12 @@ export interface SingleProfile {
      * @generated from protobuf field: string query = 2;
      */
     query: string;
+    /**
+     * sample_selector selects the samples of the profile by their pprof labels, e.g. {thread="main", bytes>1048576}
+     *
+     * @generated from protobuf field: string sample_selector = 3;
+     */
+    sampleSelector: string;
 }
 /**
  * DiffProfile contains parameters for a profile diff request
@@ -340,6 +412,31 @@ export interface QueryRequest {
      * @generated from protobuf field: parca.query.v1alpha1.QueryRequest.ReportType report_type = 5;
      */
     reportType: QueryRequest_ReportType;
+    /**
+     * filter selects the stacktraces and frames included in the report
+     *
+     * @generated from protobuf field: parca.query.v1alpha1.Filter filter = 6;
+     */
+    filter?: Filter;
+    /**
+     * function is the ID or name of the function of REPORT_TYPE_CALLGRAPH_NEIGHBORHOOD and REPORT_TYPE_DISASSEMBLY
+     * reports
+     *
+     * @generated from protobuf field: string function = 7;
+     */
+    function: string;
+    /**
+     * graph_options are the options of REPORT_TYPE_GRAPH reports
+     *
+     * @generated from protobuf field: parca.query.v1alpha1.GraphOptions graph_options = 8;
+     */
+    graphOptions?: GraphOptions;
+    /**
+     * source_options are the options of REPORT_TYPE_SOURCE reports
+     *
+     * @generated from protobuf field: parca.query.v1alpha1.SourceOptions source_options = 9;
+     */
+    sourceOptions?: SourceOptions;
 }
 /**
  * Mode is the type of query request
@@ -389,7 +486,115 @@ export enum QueryRequest_ReportType {
      *
      * @generated from protobuf enum value: REPORT_TYPE_TOP = 2;
      */
-    TOP = 2
+    TOP = 2,
+    /**
+     * REPORT_TYPE_CALLGRAPH_NEIGHBORHOOD is the callers and callees of a function
+     *
+     * @generated from protobuf enum value: REPORT_TYPE_CALLGRAPH_NEIGHBORHOOD = 3;
+     */
+    CALLGRAPH_NEIGHBORHOOD = 3,
+    /**
+     * REPORT_TYPE_GRAPH is the call graph of the functions
+     *
+     * @generated from protobuf enum value: REPORT_TYPE_GRAPH = 4;
+     */
+    GRAPH = 4,
+    /**
+     * REPORT_TYPE_SOURCE is the values of the lines of a source file or function
+     *
+     * @generated from protobuf enum value: REPORT_TYPE_SOURCE = 5;
+     */
+    SOURCE = 5,
+    /**
+     * REPORT_TYPE_DISASSEMBLY is the values of the instructions of a function
+     *
+     * @generated from protobuf enum value: REPORT_TYPE_DISASSEMBLY = 6;
+     */
+    DISASSEMBLY = 6
+}
+/**
+ * SourceOptions select the lines of source reports, either by filename or by function
+ *
+ * @generated from protobuf message parca.query.v1alpha1.SourceOptions
+ */
+export interface SourceOptions {
+    /**
+     * filename selects the lines of the files with this name, or with a path ending in /filename
+     *
+     * @generated from protobuf field: string filename = 1;
+     */
+    filename: string;
+    /**
+     * function selects the lines of the function with this ID or name
+     *
+     * @generated from protobuf field: string function = 2;
+     */
+    function: string;
+}
+/**
+ * GraphOptions are the options of call graph reports
+ *
+ * @generated from protobuf message parca.query.v1alpha1.GraphOptions
+ */
+export interface GraphOptions {
+    /**
+     * node_fraction drops the nodes with a cumulative value below this fraction of the total, like the -nodefraction
+     * option of pprof which defaults to 0.005, zero keeps all nodes
+     *
+     * @generated from protobuf field: float node_fraction = 1;
+     */
+    nodeFraction: number;
+    /**
+     * edge_fraction drops the edges with a weight below this fraction of the total, like the -edgefraction option of
+     * pprof which defaults to 0.001, zero keeps all edges
+     *
+     * @generated from protobuf field: float edge_fraction = 2;
+     */
+    edgeFraction: number;
+    /**
+     * dot requests the graph rendered in the DOT language of Graphviz
+     *
+     * @generated from protobuf field: bool dot = 3;
+     */
+    dot: boolean;
+}
+/**
+ * Filter selects stacktraces and frames like the options of pprof of the same names. The regexes match function names,
+ * filenames and mapping files, except prune_from which only matches function names.
+ *
+ * @generated from protobuf message parca.query.v1alpha1.Filter
+ */
+export interface Filter {
+    /**
+     * focus keeps only the stacktraces with a frame matching the regex
+     *
+     * @generated from protobuf field: string focus = 1;
+     */
+    focus: string;
+    /**
+     * ignore drops the stacktraces with a frame matching the regex
+     *
+     * @generated from protobuf field: string ignore = 2;
+     */
+    ignore: string;
+    /**
+     * hide drops the frames matching the regex
+     *
+     * @generated from protobuf field: string hide = 3;
+     */
+    hide: string;
+    /**
+     * show_from drops the frames above the highest frame matching the regex, and the stacktraces without a match
+     *
+     * @generated from protobuf field: string show_from = 4;
+     */
+    showFrom: string;
+    /**
+     * prune_from drops the frames below the lowest frame matching the regex
+     *
+     * @generated from protobuf field: string prune_from = 5;
+     */
+    pruneFrom: string;
 }
 /**
  * Top is the top report type
@@ -485,321 +690,1818 @@ export interface TopNodeMeta {
     line?: Line;
 }
 /**
- * Flamegraph is the flame graph report type
+ * CallgraphNeighborhood is the callers and callees of a function across all stacktraces containing it. In recursive
+ * stacktraces, the callers are the frames above the lowest call of the function and the callees the frames below the
+ * highest one.
  *
- * @generated from protobuf message parca.query.v1alpha1.Flamegraph
+ * @generated from protobuf message parca.query.v1alpha1.CallgraphNeighborhood
  */
-export interface Flamegraph {
+export interface CallgraphNeighborhood {
     /**
-     * root is the root of the flame graph
+     * function is the function of the report, unset if no stacktrace contains it
      *
-     * @generated from protobuf field: parca.query.v1alpha1.FlamegraphRootNode root = 1;
+     * @generated from protobuf field: parca.metastore.v1alpha1.Function function = 1;
      */
-    root?: FlamegraphRootNode;
+    function?: Function;
     /**
-     * total is the total weight of the flame graph
+     * cumulative is the value of the stacktraces containing the function
      *
-     * @generated from protobuf field: int64 total = 2;
+     * @generated from protobuf field: int64 cumulative = 2;
      */
-    total: string;
+    cumulative: string;
     /**
-     * unit is the unit represented by the flame graph
+     * flat is the value of the stacktraces of which the function is the leaf
      *
-     * @generated from protobuf field: string unit = 3;
+     * @generated from protobuf field: int64 flat = 3;
      */
-    unit: string;
+    flat: string;
     /**
-     * height is the max height of the graph
+     * diff is the diff value of the stacktraces containing the function
      *
-     * @generated from protobuf field: int32 height = 4;
+     * @generated from protobuf field: int64 diff = 4;
      */
-    height: number;
-}
-/**
- * FlamegraphRootNode is a root node of a flame graph
- *
- * @generated from protobuf message parca.query.v1alpha1.FlamegraphRootNode
- */
-export interface FlamegraphRootNode {
+    diff: string;
     /**
-     * cumulative is the cumulative value of the graph
+     * callers are the paths calling the function bottom-up, the children of a node are its callers
      *
-     * @generated from protobuf field: int64 cumulative = 1;
+     * @generated from protobuf field: repeated parca.query.v1alpha1.CallgraphNeighborhoodNode callers = 5;
      */
-    cumulative: string;
+    callers: CallgraphNeighborhoodNode[];
     /**
-     * diff is the diff
+     * callees are the paths called by the function top-down, the children of a node are its callees
      *
-     * @generated from protobuf field: int64 diff = 2;
+     * @generated from protobuf field: repeated parca.query.v1alpha1.CallgraphNeighborhoodNode callees = 6;
      */
-    diff: string;
+    callees: CallgraphNeighborhoodNode[];
     /**
-     * children are the list of the children of the root node
+     * unit is the unit represented by the report
      *
-     * @generated from protobuf field: repeated parca.query.v1alpha1.FlamegraphNode children = 3;
+     * @generated from protobuf field: string unit = 7;
      */
-    children: FlamegraphNode[];
+    unit: string;
 }
 /**
- * FlamegraphNode represents a node in the graph
+ * CallgraphNeighborhoodNode is a frame of the callers or callees of a function, frames are aggregated by function name
  *
- * @generated from protobuf message parca.query.v1alpha1.FlamegraphNode
+ * @generated from protobuf message parca.query.v1alpha1.CallgraphNeighborhoodNode
  */
-export interface FlamegraphNode {
-    /**
-     * meta is the metadata about the node
-     *
-     * @generated from protobuf field: parca.query.v1alpha1.FlamegraphNodeMeta meta = 1;
-     */
-    meta?: FlamegraphNodeMeta;
+export interface CallgraphNeighborhoodNode {
     /**
-     * cumulative is the cumulative value of the node
+     * function is the function of the frame, unset if the frame has no symbols
      *
-     * @generated from protobuf field: int64 cumulative = 2;
+     * @generated from protobuf field: parca.metastore.v1alpha1.Function function = 1;
      */
-    cumulative: string;
+    function?: Function;
     /**
-     * diff is the diff
+     * mapping is the mapping of the frame, unset if the aggregated frames are of different mappings
      *
-     * @generated from protobuf field: int64 diff = 3;
+     * @generated from protobuf field: parca.metastore.v1alpha1.Mapping mapping = 2;
      */
-    diff: string;
+    mapping?: Mapping;
     /**
-     * children are the child nodes
+     * address is the address of the frame if it has no function name
      *
-     * @generated from protobuf field: repeated parca.query.v1alpha1.FlamegraphNode children = 4;
+     * @generated from protobuf field: uint64 address = 3;
      */
-    children: FlamegraphNode[];
-}
-/**
- * FlamegraphNodeMeta is the metadata for a given node
- *
- * @generated from protobuf message parca.query.v1alpha1.FlamegraphNodeMeta
- */
-export interface FlamegraphNodeMeta {
+    address: string;
     /**
-     * location is the location for the code
+     * cumulative is the value of the stacktraces through the path
      *
-     * @generated from protobuf field: parca.metastore.v1alpha1.Location location = 1;
+     * @generated from protobuf field: int64 cumulative = 4;
      */
-    location?: Location;
+    cumulative: string;
     /**
-     * mapping is the mapping into code
+     * flat is the value of the stacktraces through the path of which the frame is the leaf for callees, and of which
+     * the function of the report is the leaf for callers
      *
-     * @generated from protobuf field: parca.metastore.v1alpha1.Mapping mapping = 2;
+     * @generated from protobuf field: int64 flat = 5;
      */
-    mapping?: Mapping;
+    flat: string;
     /**
-     * function is the function information
+     * diff is the diff value of the stacktraces through the path
      *
-     * @generated from protobuf field: parca.metastore.v1alpha1.Function function = 3;
+     * @generated from protobuf field: int64 diff = 6;
      */
-    function?: Function;
+    diff: string;
     /**
-     * line is the line location
+     * children are the next frames of the paths, sorted by cumulative value
      *
-     * @generated from protobuf field: parca.metastore.v1alpha1.Line line = 4;
+     * @generated from protobuf field: repeated parca.query.v1alpha1.CallgraphNeighborhoodNode children = 7;
      */
-    line?: Line;
+    children: CallgraphNeighborhoodNode[];
 }
 /**
- * QueryResponse is the returned report for the given query
+ * Graph is the call graph report type, frames are aggregated into nodes by function name
  *
- * @generated from protobuf message parca.query.v1alpha1.QueryResponse
+ * @generated from protobuf message parca.query.v1alpha1.Graph
  */
-export interface QueryResponse {
+export interface Graph {
     /**
-     * @generated from protobuf oneof: report
+     * nodes are the nodes of the graph, sorted by flat and then cumulative value
+     *
+     * @generated from protobuf field: repeated parca.query.v1alpha1.GraphNode nodes = 1;
      */
-    report: {
-        oneofKind: "flamegraph";
-        /**
-         * flamegraph is a flamegraph representation of the report
-         *
-         * @generated from protobuf field: parca.query.v1alpha1.Flamegraph flamegraph = 5;
-         */
-        flamegraph: Flamegraph;
-    } | {
-        oneofKind: "pprof";
-        /**
-         * pprof is a pprof profile as compressed bytes
-         *
-         * @generated from protobuf field: bytes pprof = 6;
-         */
-        pprof: Uint8Array;
-    } | {
-        oneofKind: "top";
-        /**
-         * top is a top list representation of the report
-         *
-         * @generated from protobuf field: parca.query.v1alpha1.Top top = 7;
-         */
-        top: Top;
-    } | {
-        oneofKind: undefined;
-    };
-}
-/**
- * SeriesRequest is unimplemented
- *
- * @generated from protobuf message parca.query.v1alpha1.SeriesRequest
- */
-export interface SeriesRequest {
+    nodes: GraphNode[];
     /**
-     * match ...
+     * edges are the calls between the nodes, sorted by weight
      *
-     * @generated from protobuf field: repeated string match = 1;
+     * @generated from protobuf field: repeated parca.query.v1alpha1.GraphEdge edges = 2;
      */
-    match: string[];
+    edges: GraphEdge[];
     /**
-     * start ...
+     * total is the total value of the profile
      *
-     * @generated from protobuf field: google.protobuf.Timestamp start = 2;
+     * @generated from protobuf field: int64 total = 3;
      */
-    start?: Timestamp;
+    total: string;
     /**
-     * end ...
+     * unit is the unit represented by the graph
      *
-     * @generated from protobuf field: google.protobuf.Timestamp end = 3;
+     * @generated from protobuf field: string unit = 4;
      */
-    end?: Timestamp;
-}
-/**
- * SeriesResponse is unimplemented
- *
- * @generated from protobuf message parca.query.v1alpha1.SeriesResponse
- */
-export interface SeriesResponse {
-}
-/**
- * LabelsRequest are the request values for labels
- *
- * @generated from protobuf message parca.query.v1alpha1.LabelsRequest
- */
-export interface LabelsRequest {
+    unit: string;
     /**
-     * match are the set of matching strings
+     * dropped_nodes is the number of nodes dropped by the node fraction
      *
-     * @generated from protobuf field: repeated string match = 1;
+     * @generated from protobuf field: int32 dropped_nodes = 5;
      */
-    match: string[];
+    droppedNodes: number;
     /**
-     * start is the start of the time window to perform the query
+     * dropped_edges is the number of edges dropped by the edge fraction
      *
-     * @generated from protobuf field: google.protobuf.Timestamp start = 2;
+     * @generated from protobuf field: int32 dropped_edges = 6;
      */
-    start?: Timestamp;
+    droppedEdges: number;
     /**
-     * end is the end of the time window to perform the query
+     * dot is the graph in the DOT language if requested
      *
-     * @generated from protobuf field: google.protobuf.Timestamp end = 3;
+     * @generated from protobuf field: string dot = 7;
      */
-    end?: Timestamp;
+    dot: string;
 }
 /**
- * LabelsResponse is the set of matching label names
+ * GraphNode is a node of a call graph
  *
- * @generated from protobuf message parca.query.v1alpha1.LabelsResponse
+ * @generated from protobuf message parca.query.v1alpha1.GraphNode
  */
-export interface LabelsResponse {
+export interface GraphNode {
     /**
-     * / label_names are the set of matching label names
+     * function is the function of the node, unset if the frames of the node have no symbols
      *
-     * @generated from protobuf field: repeated string label_names = 1;
+     * @generated from protobuf field: parca.metastore.v1alpha1.Function function = 1;
      */
-    labelNames: string[];
+    function?: Function;
     /**
-     * warnings is unimplemented
+     * mapping is the mapping of the node, unset if the frames of the node are of different mappings
      *
-     * @generated from protobuf field: repeated string warnings = 2;
+     * @generated from protobuf field: parca.metastore.v1alpha1.Mapping mapping = 2;
      */
-    warnings: string[];
+    mapping?: Mapping;
+    /**
+     * address is the address of the frames of the node if they have no function name
+     *
+     * @generated from protobuf field: uint64 address = 3;
+     */
+    address: string;
+    /**
+     * cumulative is the value of the stacktraces containing the node
+     *
+     * @generated from protobuf field: int64 cumulative = 4;
+     */
+    cumulative: string;
+    /**
+     * flat is the value of the stacktraces of which the node is the leaf
+     *
+     * @generated from protobuf field: int64 flat = 5;
+     */
+    flat: string;
+    /**
+     * diff is the diff value of the stacktraces containing the node
+     *
+     * @generated from protobuf field: int64 diff = 6;
+     */
+    diff: string;
 }
 /**
- * ValuesRequest are the request values for a values request
+ * GraphEdge is a call between two nodes of a call graph
  *
- * @generated from protobuf message parca.query.v1alpha1.ValuesRequest
+ * @generated from protobuf message parca.query.v1alpha1.GraphEdge
  */
-export interface ValuesRequest {
+export interface GraphEdge {
     /**
-     * label_name is the label name to match values against
+     * source is the index of the calling node
      *
-     * @generated from protobuf field: string label_name = 1;
+     * @generated from protobuf field: uint32 source = 1;
      */
-    labelName: string;
+    source: number;
     /**
-     * match are the set of matching strings to match values against
+     * target is the index of the called node
      *
-     * @generated from protobuf field: repeated string match = 2;
+     * @generated from protobuf field: uint32 target = 2;
      */
-    match: string[];
+    target: number;
     /**
-     * start is the start of the time window to perform the query
+     * weight is the value of the stacktraces with the call
      *
-     * @generated from protobuf field: google.protobuf.Timestamp start = 3;
+     * @generated from protobuf field: int64 weight = 3;
      */
-    start?: Timestamp;
+    weight: string;
     /**
-     * end is the end of the time window to perform the query
+     * residual is true if the call goes through dropped nodes
      *
-     * @generated from protobuf field: google.protobuf.Timestamp end = 4;
+     * @generated from protobuf field: bool residual = 4;
      */
-    end?: Timestamp;
+    residual: boolean;
+    /**
+     * inline is true if the call was inlined in all stacktraces
+     *
+     * @generated from protobuf field: bool inline = 5;
+     */
+    inline: boolean;
+}
+/**
+ * Disassembly is the disassembly report type
+ *
+ * @generated from protobuf message parca.query.v1alpha1.Disassembly
+ */
+export interface Disassembly {
+    /**
+     * functions are the functions of the report per binary, sorted by build ID
+     *
+     * @generated from protobuf field: repeated parca.query.v1alpha1.DisassemblyFunction functions = 1;
+     */
+    functions: DisassemblyFunction[];
+    /**
+     * total is the total value of the profile
+     *
+     * @generated from protobuf field: int64 total = 2;
+     */
+    total: string;
+    /**
+     * unit is the unit represented by the report
+     *
+     * @generated from protobuf field: string unit = 3;
+     */
+    unit: string;
+}
+/**
+ * DisassemblyFunction is a function of a binary
+ *
+ * @generated from protobuf message parca.query.v1alpha1.DisassemblyFunction
+ */
+export interface DisassemblyFunction {
+    /**
+     * function is the function
+     *
+     * @generated from protobuf field: parca.metastore.v1alpha1.Function function = 1;
+     */
+    function?: Function;
+    /**
+     * mapping is a mapping of the binary
+     *
+     * @generated from protobuf field: parca.metastore.v1alpha1.Mapping mapping = 2;
+     */
+    mapping?: Mapping;
+    /**
+     * instructions are the instructions of the function, sorted by address. If the binary couldn't be
+     * disassembled, only the sampled addresses are included, without text.
+     *
+     * @generated from protobuf field: repeated parca.query.v1alpha1.DisassemblyInstruction instructions = 3;
+     */
+    instructions: DisassemblyInstruction[];
+}
+/**
+ * DisassemblyInstruction is a machine instruction
+ *
+ * @generated from protobuf message parca.query.v1alpha1.DisassemblyInstruction
+ */
+export interface DisassemblyInstruction {
+    /**
+     * address is the address of the instruction
+     *
+     * @generated from protobuf field: uint64 address = 1;
+     */
+    address: string;
+    /**
+     * text is the instruction in GNU syntax, empty if the binary couldn't be disassembled
+     *
+     * @generated from protobuf field: string text = 2;
+     */
+    text: string;
+    /**
+     * flat is the value of the stacktraces of which the instruction is the leaf
+     *
+     * @generated from protobuf field: int64 flat = 3;
+     */
+    flat: string;
+    /**
+     * cumulative is the value of the stacktraces containing the instruction
+     *
+     * @generated from protobuf field: int64 cumulative = 4;
+     */
+    cumulative: string;
+    /**
+     * diff is the diff value of the stacktraces containing the instruction
+     *
+     * @generated from protobuf field: int64 diff = 5;
+     */
+    diff: string;
+}
+/**
+ * Source is the source report type
+ *
+ * @generated from protobuf message parca.query.v1alpha1.Source
+ */
+export interface Source {
+    /**
+     * files are the selected source files, sorted by filename and build ID
+     *
+     * @generated from protobuf field: repeated parca.query.v1alpha1.SourceFile files = 1;
+     */
+    files: SourceFile[];
+    /**
+     * total is the total value of the profile
+     *
+     * @generated from protobuf field: int64 total = 2;
+     */
+    total: string;
+    /**
+     * unit is the unit represented by the report
+     *
+     * @generated from protobuf field: string unit = 3;
+     */
+    unit: string;
+}
+/**
+ * SourceFile is a source file of the binary of a build ID
+ *
+ * @generated from protobuf message parca.query.v1alpha1.SourceFile
+ */
+export interface SourceFile {
+    /**
+     * filename is the name of the file
+     *
+     * @generated from protobuf field: string filename = 1;
+     */
+    filename: string;
+    /**
+     * build_id is the build ID of the binary the file was compiled into
+     *
+     * @generated from protobuf field: string build_id = 2;
+     */
+    buildId: string;
+    /**
+     * lines are the lines of the file with values, sorted by line number
+     *
+     * @generated from protobuf field: repeated parca.query.v1alpha1.SourceLine lines = 3;
+     */
+    lines: SourceLine[];
+    /**
+     * source is the content of the file, if the debuginfo store holds it
+     *
+     * @generated from protobuf field: string source = 4;
+     */
+    source: string;
+}
+/**
+ * SourceLine is a line of a source file
+ *
+ * @generated from protobuf message parca.query.v1alpha1.SourceLine
+ */
+export interface SourceLine {
+    /**
+     * line is the line number
+     *
+     * @generated from protobuf field: int64 line = 1;
+     */
+    line: string;
+    /**
+     * flat is the value of the stacktraces of which the line is the leaf
+     *
+     * @generated from protobuf field: int64 flat = 2;
+     */
+    flat: string;
+    /**
+     * cumulative is the value of the stacktraces containing the line
+     *
+     * @generated from protobuf field: int64 cumulative = 3;
+     */
+    cumulative: string;
+    /**
+     * diff is the diff value of the stacktraces containing the line
+     *
+     * @generated from protobuf field: int64 diff = 4;
+     */
+    diff: string;
+}
+/**
+ * Flamegraph is the flame graph report type
+ *
+ * @generated from protobuf message parca.query.v1alpha1.Flamegraph
+ */
+export interface Flamegraph {
+    /**
+     * root is the root of the flame graph
+     *
+     * @generated from protobuf field: parca.query.v1alpha1.FlamegraphRootNode root = 1;
+     */
+    root?: FlamegraphRootNode;
+    /**
+     * total is the total weight of the flame graph
+     *
+     * @generated from protobuf field: int64 total = 2;
+     */
+    total: string;
+    /**
+     * unit is the unit represented by the flame graph
+     *
+     * @generated from protobuf field: string unit = 3;
+     */
+    unit: string;
+    /**
+     * height is the max height of the graph
+     *
+     * @generated from protobuf field: int32 height = 4;
+     */
+    height: number;
+}
+/**
+ * FlamegraphRootNode is a root node of a flame graph
+ *
+ * @generated from protobuf message parca.query.v1alpha1.FlamegraphRootNode
+ */
+export interface FlamegraphRootNode {
+    /**
+     * cumulative is the cumulative value of the graph
+     *
+     * @generated from protobuf field: int64 cumulative = 1;
+     */
+    cumulative: string;
+    /**
+     * diff is the diff
+     *
+     * @generated from protobuf field: int64 diff = 2;
+     */
+    diff: string;
+    /**
+     * children are the list of the children of the root node
+     *
+     * @generated from protobuf field: repeated parca.query.v1alpha1.FlamegraphNode children = 3;
+     */
+    children: FlamegraphNode[];
+}
+/**
+ * FlamegraphNode represents a node in the graph
+ *
+ * @generated from protobuf message parca.query.v1alpha1.FlamegraphNode
+ */
+export interface FlamegraphNode {
+    /**
+     * meta is the metadata about the node
+     *
+     * @generated from protobuf field: parca.query.v1alpha1.FlamegraphNodeMeta meta = 1;
+     */
+    meta?: FlamegraphNodeMeta;
+    /**
+     * cumulative is the cumulative value of the node
+     *
+     * @generated from protobuf field: int64 cumulative = 2;
+     */
+    cumulative: string;
+    /**
+     * diff is the diff
+     *
+     * @generated from protobuf field: int64 diff = 3;
+     */
+    diff: string;
+    /**
+     * children are the child nodes
+     *
+     * @generated from protobuf field: repeated parca.query.v1alpha1.FlamegraphNode children = 4;
+     */
+    children: FlamegraphNode[];
+}
+/**
+ * FlamegraphNodeMeta is the metadata for a given node
+ *
+ * @generated from protobuf message parca.query.v1alpha1.FlamegraphNodeMeta
+ */
+export interface FlamegraphNodeMeta {
+    /**
+     * location is the location for the code
+     *
+     * @generated from protobuf field: parca.metastore.v1alpha1.Location location = 1;
+     */
+    location?: Location;
+    /**
+     * mapping is the mapping into code
+     *
+     * @generated from protobuf field: parca.metastore.v1alpha1.Mapping mapping = 2;
+     */
+    mapping?: Mapping;
+    /**
+     * function is the function information
+     *
+     * @generated from protobuf field: parca.metastore.v1alpha1.Function function = 3;
+     */
+    function?: Function;
+    /**
+     * line is the line location
+     *
+     * @generated from protobuf field: parca.metastore.v1alpha1.Line line = 4;
+     */
+    line?: Line;
+}
+/**
+ * QueryResponse is the returned report for the given query
+ *
+ * @generated from protobuf message parca.query.v1alpha1.QueryResponse
+ */
+export interface QueryResponse {
+    /**
+     * @generated from protobuf oneof: report
+     */
+    report: {
+        oneofKind: "flamegraph";
+        /**
+         * flamegraph is a flamegraph representation of the report
+         *
+         * @generated from protobuf field: parca.query.v1alpha1.Flamegraph flamegraph = 5;
+         */
+        flamegraph: Flamegraph;
+    } | {
+        oneofKind: "pprof";
+        /**
+         * pprof is a pprof profile as compressed bytes
+         *
+         * @generated from protobuf field: bytes pprof = 6;
+         */
+        pprof: Uint8Array;
+    } | {
+        oneofKind: "top";
+        /**
+         * top is a top list representation of the report
+         *
+         * @generated from protobuf field: parca.query.v1alpha1.Top top = 7;
+         */
+        top: Top;
+    } | {
+        oneofKind: "groups";
+        /**
+         * groups are the reports of the groups of a merge with group_by
+         *
+         * @generated from protobuf field: parca.query.v1alpha1.GroupedReports groups = 8;
+         */
+        groups: GroupedReports;
+    } | {
+        oneofKind: "callgraphNeighborhood";
+        /**
+         * callgraph_neighborhood is the callers and callees of a function
+         *
+         * @generated from protobuf field: parca.query.v1alpha1.CallgraphNeighborhood callgraph_neighborhood = 9;
+         */
+        callgraphNeighborhood: CallgraphNeighborhood;
+    } | {
+        oneofKind: "graph";
+        /**
+         * graph is the call graph of the functions
+         *
+         * @generated from protobuf field: parca.query.v1alpha1.Graph graph = 10;
+         */
+        graph: Graph;
+    } | {
+        oneofKind: "source";
+        /**
+         * source is the values of the lines of a source file or function
+         *
+         * @generated from protobuf field: parca.query.v1alpha1.Source source = 11;
+         */
+        source: Source;
+    } | {
+        oneofKind: "disassembly";
+        /**
+         * disassembly is the values of the instructions of a function
+         *
+         * @generated from protobuf field: parca.query.v1alpha1.Disassembly disassembly = 12;
+         */
+        disassembly: Disassembly;
+    } | {
+        oneofKind: undefined;
+    };
+}
+/**
+ * GroupedReports are the reports of the groups of a merge, sorted by the values of their labels
+ *
+ * @generated from protobuf message parca.query.v1alpha1.GroupedReports
+ */
+export interface GroupedReports {
+    /**
+     * reports are the reports of the groups
+     *
+     * @generated from protobuf field: repeated parca.query.v1alpha1.GroupedReport reports = 1;
+     */
+    reports: GroupedReport[];
+}
+/**
+ * GroupedReport is the report of the profiles with the same values of the group_by labels
+ *
+ * @generated from protobuf message parca.query.v1alpha1.GroupedReport
+ */
+export interface GroupedReport {
+    /**
+     * labels are the group_by labels and their values, labels without a value are omitted
+     *
+     * @generated from protobuf field: repeated parca.profilestore.v1alpha1.Label labels = 1;
+     */
+    labels: Label[];
+    /**
+     * @generated from protobuf oneof: report
+     */
+    report: {
+        oneofKind: "flamegraph";
+        /**
+         * flamegraph is a flamegraph representation of the report
+         *
+         * @generated from protobuf field: parca.query.v1alpha1.Flamegraph flamegraph = 2;
+         */
+        flamegraph: Flamegraph;
+    } | {
+        oneofKind: "pprof";
+        /**
+         * pprof is a pprof profile as compressed bytes
+         *
+         * @generated from protobuf field: bytes pprof = 3;
+         */
+        pprof: Uint8Array;
+    } | {
+        oneofKind: "top";
+        /**
+         * top is a top list representation of the report
+         *
+         * @generated from protobuf field: parca.query.v1alpha1.Top top = 4;
+         */
+        top: Top;
+    } | {
+        oneofKind: "callgraphNeighborhood";
+        /**
+         * callgraph_neighborhood is the callers and callees of a function
+         *
+         * @generated from protobuf field: parca.query.v1alpha1.CallgraphNeighborhood callgraph_neighborhood = 5;
+         */
+        callgraphNeighborhood: CallgraphNeighborhood;
+    } | {
+        oneofKind: "graph";
+        /**
+         * graph is the call graph of the functions
+         *
+         * @generated from protobuf field: parca.query.v1alpha1.Graph graph = 6;
+         */
+        graph: Graph;
+    } | {
+        oneofKind: "source";
+        /**
+         * source is the values of the lines of a source file or function
+         *
+         * @generated from protobuf field: parca.query.v1alpha1.Source source = 7;
+         */
+        source: Source;
+    } | {
+        oneofKind: "disassembly";
+        /**
+         * disassembly is the values of the instructions of a function
+         *
+         * @generated from protobuf field: parca.query.v1alpha1.Disassembly disassembly = 8;
+         */
+        disassembly: Disassembly;
+    } | {
+        oneofKind: undefined;
+    };
+}
+/**
+ * SeriesRequest is the request for the series matching a set of selectors over a time window
+ *
+ * @generated from protobuf message parca.query.v1alpha1.SeriesRequest
+ */
+export interface SeriesRequest {
+    /**
+     * match are the selectors of which series have to match at least one, all series match if empty
+     *
+     * @generated from protobuf field: repeated string match = 1;
+     */
+    match: string[];
+    /**
+     * start is the start of the query time window
+     *
+     * @generated from protobuf field: google.protobuf.Timestamp start = 2;
+     */
+    start?: Timestamp;
+    /**
+     * end is the end of the query time window
+     *
+     * @generated from protobuf field: google.protobuf.Timestamp end = 3;
+     */
+    end?: Timestamp;
+    /**
+     * limit is the max number of series to include in the response, zero means no limit
+     *
+     * @generated from protobuf field: uint32 limit = 4;
+     */
+    limit: number;
+}
+/**
+ * SeriesResponse is the set of series matching the selectors
+ *
+ * @generated from protobuf message parca.query.v1alpha1.SeriesResponse
+ */
+export interface SeriesResponse {
+    /**
+     * series are the matching series, sorted by profile type and labels
+     *
+     * @generated from protobuf field: repeated parca.query.v1alpha1.Series series = 1;
+     */
+    series: Series[];
+    /**
+     * truncated is true if more series than the limit matched
+     *
+     * @generated from protobuf field: bool truncated = 2;
+     */
+    truncated: boolean;
+}
+/**
+ * Series is the set of labels of profiles of a profile type
+ *
+ * @generated from protobuf message parca.query.v1alpha1.Series
+ */
+export interface Series {
+    /**
+     * labelset is the set of key value pairs
+     *
+     * @generated from protobuf field: parca.profilestore.v1alpha1.LabelSet labelset = 1;
+     */
+    labelset?: LabelSet;
+    /**
+     * profile_type is the type of the profiles of the series
+     *
+     * @generated from protobuf field: parca.query.v1alpha1.ProfileType profile_type = 2;
+     */
+    profileType?: ProfileType;
+}
+/**
+ * LabelsRequest are the request values for labels
+ *
+ * @generated from protobuf message parca.query.v1alpha1.LabelsRequest
+ */
+export interface LabelsRequest {
+    /**
+     * match are the selectors of which the series of the label names have to match at least one
+     *
+     * @generated from protobuf field: repeated string match = 1;
+     */
+    match: string[];
+    /**
+     * start is the start of the time window to perform the query
+     *
+     * @generated from protobuf field: google.protobuf.Timestamp start = 2;
+     */
+    start?: Timestamp;
+    /**
+     * end is the end of the time window to perform the query
+     *
+     * @generated from protobuf field: google.protobuf.Timestamp end = 3;
+     */
+    end?: Timestamp;
+    /**
+     * limit is the max number of label names to return, zero means no limit
+     *
+     * @generated from protobuf field: uint32 limit = 4;
+     */
+    limit: number;
+    /**
+     * prefix is the prefix the label names have to start with
+     *
+     * @generated from protobuf field: string prefix = 5;
+     */
+    prefix: string;
+}
+/**
+ * LabelsResponse is the set of matching label names
+ *
+ * @generated from protobuf message parca.query.v1alpha1.LabelsResponse
+ */
+export interface LabelsResponse {
+    /**
+     * / label_names are the set of matching label names
+     *
+     * @generated from protobuf field: repeated string label_names = 1;
+     */
+    labelNames: string[];
+    /**
+     * warnings are the reasons the label names may be incomplete, such as being truncated to the limit
+     *
+     * @generated from protobuf field: repeated string warnings = 2;
+     */
+    warnings: string[];
+}
+/**
+ * ValuesRequest are the request values for a values request
+ *
+ * @generated from protobuf message parca.query.v1alpha1.ValuesRequest
+ */
+export interface ValuesRequest {
+    /**
+     * label_name is the label name to match values against
+     *
+     * @generated from protobuf field: string label_name = 1;
+     */
+    labelName: string;
+    /**
+     * match are the selectors of which the series of the label values have to match at least one
+     *
+     * @generated from protobuf field: repeated string match = 2;
+     */
+    match: string[];
+    /**
+     * start is the start of the time window to perform the query
+     *
+     * @generated from protobuf field: google.protobuf.Timestamp start = 3;
+     */
+    start?: Timestamp;
+    /**
+     * end is the end of the time window to perform the query
+     *
+     * @generated from protobuf field: google.protobuf.Timestamp end = 4;
+     */
+    end?: Timestamp;
+    /**
+     * limit is the max number of label values to return, zero means no limit
+     *
+     * @generated from protobuf field: uint32 limit = 5;
+     */
+    limit: number;
+    /**
+     * prefix is the prefix the label values have to start with
+     *
+     * @generated from protobuf field: string prefix = 6;
+     */
+    prefix: string;
+}
+/**
+ * ValuesResponse are the set of matching values
+ *
+ * @generated from protobuf message parca.query.v1alpha1.ValuesResponse
+ */
+export interface ValuesResponse {
+    /**
+     * label_values are the set of matching label values
+     *
+     * @generated from protobuf field: repeated string label_values = 1;
+     */
+    labelValues: string[];
+    /**
+     * warnings are the reasons the label values may be incomplete, such as being truncated to the limit
+     *
+     * @generated from protobuf field: repeated string warnings = 2;
+     */
+    warnings: string[];
+}
+/**
+ * ValueType represents a value, including its type and unit
+ *
+ * @generated from protobuf message parca.query.v1alpha1.ValueType
+ */
+export interface ValueType {
+    /**
+     * type is the type of the value
+     *
+     * @generated from protobuf field: string type = 1;
+     */
+    type: string;
+    /**
+     * unit is the unit of the value
+     *
+     * @generated from protobuf field: string unit = 2;
+     */
+    unit: string;
+}
+/**
+ * GetRawProfileRequest is the request to retrieve a profile as it was ingested
+ *
+ * @generated from protobuf message parca.query.v1alpha1.GetRawProfileRequest
+ */
+export interface GetRawProfileRequest {
+    /**
+     * series is the label set of the series including the profile name, e.g. memory{job="parca"}
+     *
+     * @generated from protobuf field: string series = 1;
+     */
+    series: string;
+    /**
+     * time is the timestamp of the profile
+     *
+     * @generated from protobuf field: google.protobuf.Timestamp time = 2;
+     */
+    time?: Timestamp;
+}
+/**
+ * GetRawProfileResponse is the response to retrieve a profile as it was ingested
+ *
+ * @generated from protobuf message parca.query.v1alpha1.GetRawProfileResponse
+ */
+export interface GetRawProfileResponse {
+    /**
+     * raw_profile is the pprof profile as it was ingested
+     *
+     * @generated from protobuf field: bytes raw_profile = 1;
+     */
+    rawProfile: Uint8Array;
+}
+// @generated message type with reflection information, may provide speed optimized methods
+class ProfileTypesRequest$Type extends MessageType<ProfileTypesRequest> {
+    constructor() {
+        super("parca.query.v1alpha1.ProfileTypesRequest", []);
+    }
+    create(value?: PartialMessage<ProfileTypesRequest>): ProfileTypesRequest {
+        const message = {};
+        globalThis.Object.defineProperty(message, MESSAGE_TYPE, { enumerable: false, value: this });
+        if (value !== undefined)
+            reflectionMergePartial<ProfileTypesRequest>(this, message, value);
+        return message;
+    }
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: ProfileTypesRequest): ProfileTypesRequest {
+        return target ?? this.create();
+    }
+    internalBinaryWrite(message: ProfileTypesRequest, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        let u = options.writeUnknownFields;
+        if (u !== false)
+            (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
+        return writer;
+    }
+}
+/**
+ * @generated MessageType for protobuf message parca.query.v1alpha1.ProfileTypesRequest
+ */
+export const ProfileTypesRequest = new ProfileTypesRequest$Type();
+// @generated message type with reflection information, may provide speed optimized methods
+class ProfileTypesResponse$Type extends MessageType<ProfileTypesResponse> {
+    constructor() {
+        super("parca.query.v1alpha1.ProfileTypesResponse", [
+            { no: 1, name: "types", kind: "message", repeat: 1 /*RepeatType.PACKED*/, T: () => ProfileType }
+        ]);
+    }
+    create(value?: PartialMessage<ProfileTypesResponse>): ProfileTypesResponse {
+        const message = { types: [] };
+        globalThis.Object.defineProperty(message, MESSAGE_TYPE, { enumerable: false, value: this });
+        if (value !== undefined)
+            reflectionMergePartial<ProfileTypesResponse>(this, message, value);
+        return message;
+    }
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: ProfileTypesResponse): ProfileTypesResponse {
+        let message = target ?? this.create(), end = reader.pos + length;
+        while (reader.pos < end) {
+            let [fieldNo, wireType] = reader.tag();
+            switch (fieldNo) {
+                case /* repeated parca.query.v1alpha1.ProfileType types */ 1:
+                    message.types.push(ProfileType.internalBinaryRead(reader, reader.uint32(), options));
+                    break;
+                default:
+                    let u = options.readUnknownField;
+                    if (u === "throw")
+                        throw new globalThis.Error(`Unknown field ${fieldNo} (wire type ${wireType}) for ${this.typeName}`);
+                    let d = reader.skip(wireType);
+                    if (u !== false)
+                        (u === true ? UnknownFieldHandler.onRead : u)(this.typeName, message, fieldNo, wireType, d);
+            }
+        }
+        return message;
+    }
+    internalBinaryWrite(message: ProfileTypesResponse, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* repeated parca.query.v1alpha1.ProfileType types = 1; */
+        for (let i = 0; i < message.types.length; i++)
+            ProfileType.internalBinaryWrite(message.types[i], writer.tag(1, WireType.LengthDelimited).fork(), options).join();
+        let u = options.writeUnknownFields;
+        if (u !== false)
+            (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
+        return writer;
+    }
+}
+/**
+ * @generated MessageType for protobuf message parca.query.v1alpha1.ProfileTypesResponse
+ */
+export const ProfileTypesResponse = new ProfileTypesResponse$Type();
+// @generated message type with reflection information, may provide speed optimized methods
+class ProfileType$Type extends MessageType<ProfileType> {
+    constructor() {
+        super("parca.query.v1alpha1.ProfileType", [
+            { no: 1, name: "name", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 2, name: "sample_type", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 3, name: "sample_unit", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 4, name: "period_type", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 5, name: "period_unit", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 6, name: "delta", kind: "scalar", T: 8 /*ScalarType.BOOL*/ }
+        ]);
+    }
+    create(value?: PartialMessage<ProfileType>): ProfileType {
+        const message = { name: "", sampleType: "", sampleUnit: "", periodType: "", periodUnit: "", delta: false };
+        globalThis.Object.defineProperty(message, MESSAGE_TYPE, { enumerable: false, value: this });
+        if (value !== undefined)
+            reflectionMergePartial<ProfileType>(this, message, value);
+        return message;
+    }
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: ProfileType): ProfileType {
+        let message = target ?? this.create(), end = reader.pos + length;
+        while (reader.pos < end) {
+            let [fieldNo, wireType] = reader.tag();
+            switch (fieldNo) {
+                case /* string name */ 1:
+                    message.name = reader.string();
+                    break;
+                case /* string sample_type */ 2:
+                    message.sampleType = reader.string();
+                    break;
+                case /* string sample_unit */ 3:
+                    message.sampleUnit = reader.string();
+                    break;
+                case /* string period_type */ 4:
+                    message.periodType = reader.string();
+                    break;
+                case /* string period_unit */ 5:
+                    message.periodUnit = reader.string();
+                    break;
+                case /* bool delta */ 6:
+                    message.delta = reader.bool();
+                    break;
+                default:
+                    let u = options.readUnknownField;
+                    if (u === "throw")
+                        throw new globalThis.Error(`Unknown field ${fieldNo} (wire type ${wireType}) for ${this.typeName}`);
+                    let d = reader.skip(wireType);
+                    if (u !== false)
+                        (u === true ? UnknownFieldHandler.onRead : u)(this.typeName, message, fieldNo, wireType, d);
+            }
+        }
+        return message;
+    }
+    internalBinaryWrite(message: ProfileType, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* string name = 1; */
+        if (message.name !== "")
+            writer.tag(1, WireType.LengthDelimited).string(message.name);
+        /* string sample_type = 2; */
+        if (message.sampleType !== "")
+            writer.tag(2, WireType.LengthDelimited).string(message.sampleType);
+        /* string sample_unit = 3; */
+        if (message.sampleUnit !== "")
+            writer.tag(3, WireType.LengthDelimited).string(message.sampleUnit);
+        /* string period_type = 4; */
+        if (message.periodType !== "")
+            writer.tag(4, WireType.LengthDelimited).string(message.periodType);
+        /* string period_unit = 5; */
+        if (message.periodUnit !== "")
+            writer.tag(5, WireType.LengthDelimited).string(message.periodUnit);
+        /* bool delta = 6; */
+        if (message.delta !== false)
+            writer.tag(6, WireType.Varint).bool(message.delta);
+        let u = options.writeUnknownFields;
+        if (u !== false)
+            (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
+        return writer;
+    }
+}
+/**
+ * @generated MessageType for protobuf message parca.query.v1alpha1.ProfileType
+ */
+export const ProfileType = new ProfileType$Type();
+// @generated message type with reflection information, may provide speed optimized methods
+class QueryRangeRequest$Type extends MessageType<QueryRangeRequest> {
+    constructor() {
+        super("parca.query.v1alpha1.QueryRangeRequest", [
+            { no: 1, name: "query", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 2, name: "start", kind: "message", T: () => Timestamp },
+            { no: 3, name: "end", kind: "message", T: () => Timestamp },
+            { no: 4, name: "limit", kind: "scalar", T: 13 /*ScalarType.UINT32*/ },
+            { no: 5, name: "step", kind: "message", T: () => Duration },
+            { no: 6, name: "step_function", kind: "enum", T: () => ["parca.query.v1alpha1.QueryRangeRequest.StepFunction", QueryRangeRequest_StepFunction, "STEP_FUNCTION_"] }
+        ]);
+    }
+    create(value?: PartialMessage<QueryRangeRequest>): QueryRangeRequest {
+        const message = { query: "", limit: 0, stepFunction: 0 };
+        globalThis.Object.defineProperty(message, MESSAGE_TYPE, { enumerable: false, value: this });
+        if (value !== undefined)
+            reflectionMergePartial<QueryRangeRequest>(this, message, value);
+        return message;
+    }
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: QueryRangeRequest): QueryRangeRequest {
+        let message = target ?? this.create(), end = reader.pos + length;
+        while (reader.pos < end) {
+            let [fieldNo, wireType] = reader.tag();
+            switch (fieldNo) {
+                case /* string query */ 1:
+                    message.query = reader.string();
+                    break;
+                case /* google.protobuf.Timestamp start */ 2:
+                    message.start = Timestamp.internalBinaryRead(reader, reader.uint32(), options, message.start);
+                    break;
+                case /* google.protobuf.Timestamp end */ 3:
+                    message.end = Timestamp.internalBinaryRead(reader, reader.uint32(), options, message.end);
+                    break;
+                case /* uint32 limit */ 4:
+                    message.limit = reader.uint32();
+                    break;
+                case /* google.protobuf.Duration step */ 5:
+                    message.step = Duration.internalBinaryRead(reader, reader.uint32(), options, message.step);
+                    break;
+                case /* parca.query.v1alpha1.QueryRangeRequest.StepFunction step_function */ 6:
+                    message.stepFunction = reader.int32();
+                    break;
+                default:
+                    let u = options.readUnknownField;
+                    if (u === "throw")
+                        throw new globalThis.Error(`Unknown field ${fieldNo} (wire type ${wireType}) for ${this.typeName}`);
+                    let d = reader.skip(wireType);
+                    if (u !== false)
+                        (u === true ? UnknownFieldHandler.onRead : u)(this.typeName, message, fieldNo, wireType, d);
+            }
+        }
+        return message;
+    }
+    internalBinaryWrite(message: QueryRangeRequest, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* string query = 1; */
+        if (message.query !== "")
+            writer.tag(1, WireType.LengthDelimited).string(message.query);
+        /* google.protobuf.Timestamp start = 2; */
+        if (message.start)
+            Timestamp.internalBinaryWrite(message.start, writer.tag(2, WireType.LengthDelimited).fork(), options).join();
+        /* google.protobuf.Timestamp end = 3; */
+        if (message.end)
+            Timestamp.internalBinaryWrite(message.end, writer.tag(3, WireType.LengthDelimited).fork(), options).join();
+        /* uint32 limit = 4; */
+        if (message.limit !== 0)
+            writer.tag(4, WireType.Varint).uint32(message.limit);
+        /* google.protobuf.Duration step = 5; */
+        if (message.step)
+            Duration.internalBinaryWrite(message.step, writer.tag(5, WireType.LengthDelimited).fork(), options).join();
+        /* parca.query.v1alpha1.QueryRangeRequest.StepFunction step_function = 6; */
+        if (message.stepFunction !== 0)
+            writer.tag(6, WireType.Varint).int32(message.stepFunction);
+        let u = options.writeUnknownFields;
+        if (u !== false)
+            (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
+        return writer;
+    }
+}
+/**
+ * @generated MessageType for protobuf message parca.query.v1alpha1.QueryRangeRequest
+ */
+export const QueryRangeRequest = new QueryRangeRequest$Type();
+// @generated message type with reflection information, may provide speed optimized methods
+class QueryRangeResponse$Type extends MessageType<QueryRangeResponse> {
+    constructor() {
+        super("parca.query.v1alpha1.QueryRangeResponse", [
+            { no: 1, name: "series", kind: "message", repeat: 1 /*RepeatType.PACKED*/, T: () => MetricsSeries }
+        ]);
+    }
+    create(value?: PartialMessage<QueryRangeResponse>): QueryRangeResponse {
+        const message = { series: [] };
+        globalThis.Object.defineProperty(message, MESSAGE_TYPE, { enumerable: false, value: this });
+        if (value !== undefined)
+            reflectionMergePartial<QueryRangeResponse>(this, message, value);
+        return message;
+    }
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: QueryRangeResponse): QueryRangeResponse {
+        let message = target ?? this.create(), end = reader.pos + length;
+        while (reader.pos < end) {
+            let [fieldNo, wireType] = reader.tag();
+            switch (fieldNo) {
+                case /* repeated parca.query.v1alpha1.MetricsSeries series */ 1:
+                    message.series.push(MetricsSeries.internalBinaryRead(reader, reader.uint32(), options));
+                    break;
+                default:
+                    let u = options.readUnknownField;
+                    if (u === "throw")
+                        throw new globalThis.Error(`Unknown field ${fieldNo} (wire type ${wireType}) for ${this.typeName}`);
+                    let d = reader.skip(wireType);
+                    if (u !== false)
+                        (u === true ? UnknownFieldHandler.onRead : u)(this.typeName, message, fieldNo, wireType, d);
+            }
+        }
+        return message;
+    }
+    internalBinaryWrite(message: QueryRangeResponse, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* repeated parca.query.v1alpha1.MetricsSeries series = 1; */
+        for (let i = 0; i < message.series.length; i++)
+            MetricsSeries.internalBinaryWrite(message.series[i], writer.tag(1, WireType.LengthDelimited).fork(), options).join();
+        let u = options.writeUnknownFields;
+        if (u !== false)
+            (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
+        return writer;
+    }
+}
+/**
+ * @generated MessageType for protobuf message parca.query.v1alpha1.QueryRangeResponse
+ */
+export const QueryRangeResponse = new QueryRangeResponse$Type();
+// @generated message type with reflection information, may provide speed optimized methods
+class MetricsSeries$Type extends MessageType<MetricsSeries> {
+    constructor() {
+        super("parca.query.v1alpha1.MetricsSeries", [
+            { no: 1, name: "labelset", kind: "message", T: () => LabelSet },
+            { no: 2, name: "samples", kind: "message", repeat: 1 /*RepeatType.PACKED*/, T: () => MetricsSample },
+            { no: 3, name: "period_type", kind: "message", T: () => ValueType },
+            { no: 4, name: "sample_type", kind: "message", T: () => ValueType }
+        ]);
+    }
+    create(value?: PartialMessage<MetricsSeries>): MetricsSeries {
+        const message = { samples: [] };
+        globalThis.Object.defineProperty(message, MESSAGE_TYPE, { enumerable: false, value: this });
+        if (value !== undefined)
+            reflectionMergePartial<MetricsSeries>(this, message, value);
+        return message;
+    }
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: MetricsSeries): MetricsSeries {
+        let message = target ?? this.create(), end = reader.pos + length;
+        while (reader.pos < end) {
+            let [fieldNo, wireType] = reader.tag();
+            switch (fieldNo) {
+                case /* parca.profilestore.v1alpha1.LabelSet labelset */ 1:
+                    message.labelset = LabelSet.internalBinaryRead(reader, reader.uint32(), options, message.labelset);
+                    break;
+                case /* repeated parca.query.v1alpha1.MetricsSample samples */ 2:
+                    message.samples.push(MetricsSample.internalBinaryRead(reader, reader.uint32(), options));
+                    break;
+                case /* parca.query.v1alpha1.ValueType period_type */ 3:
+                    message.periodType = ValueType.internalBinaryRead(reader, reader.uint32(), options, message.periodType);
+                    break;
+                case /* parca.query.v1alpha1.ValueType sample_type */ 4:
+                    message.sampleType = ValueType.internalBinaryRead(reader, reader.uint32(), options, message.sampleType);
+                    break;
+                default:
+                    let u = options.readUnknownField;
+                    if (u === "throw")
+                        throw new globalThis.Error(`Unknown field ${fieldNo} (wire type ${wireType}) for ${this.typeName}`);
+                    let d = reader.skip(wireType);
+                    if (u !== false)
+                        (u === true ? UnknownFieldHandler.onRead : u)(this.typeName, message, fieldNo, wireType, d);
+            }
+        }
+        return message;
+    }
+    internalBinaryWrite(message: MetricsSeries, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* parca.profilestore.v1alpha1.LabelSet labelset = 1; */
+        if (message.labelset)
+            LabelSet.internalBinaryWrite(message.labelset, writer.tag(1, WireType.LengthDelimited).fork(), options).join();
+        /* repeated parca.query.v1alpha1.MetricsSample samples = 2; */
+        for (let i = 0; i < message.samples.length; i++)
+            MetricsSample.internalBinaryWrite(message.samples[i], writer.tag(2, WireType.LengthDelimited).fork(), options).join();
+        /* parca.query.v1alpha1.ValueType period_type = 3; */
+        if (message.periodType)
+            ValueType.internalBinaryWrite(message.periodType, writer.tag(3, WireType.LengthDelimited).fork(), options).join();
+        /* parca.query.v1alpha1.ValueType sample_type = 4; */
+        if (message.sampleType)
+            ValueType.internalBinaryWrite(message.sampleType, writer.tag(4, WireType.LengthDelimited).fork(), options).join();
+        let u = options.writeUnknownFields;
+        if (u !== false)
+            (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
+        return writer;
+    }
+}
+/**
+ * @generated MessageType for protobuf message parca.query.v1alpha1.MetricsSeries
+ */
+export const MetricsSeries = new MetricsSeries$Type();
+// @generated message type with reflection information, may provide speed optimized methods
+class MetricsSample$Type extends MessageType<MetricsSample> {
+    constructor() {
+        super("parca.query.v1alpha1.MetricsSample", [
+            { no: 1, name: "timestamp", kind: "message", T: () => Timestamp },
+            { no: 2, name: "value", kind: "scalar", T: 3 /*ScalarType.INT64*/ },
+            { no: 3, name: "value_per_second", kind: "scalar", T: 1 /*ScalarType.DOUBLE*/ }
+        ]);
+    }
+    create(value?: PartialMessage<MetricsSample>): MetricsSample {
+        const message = { value: "0", valuePerSecond: 0 };
+        globalThis.Object.defineProperty(message, MESSAGE_TYPE, { enumerable: false, value: this });
+        if (value !== undefined)
+            reflectionMergePartial<MetricsSample>(this, message, value);
+        return message;
+    }
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: MetricsSample): MetricsSample {
+        let message = target ?? this.create(), end = reader.pos + length;
+        while (reader.pos < end) {
+            let [fieldNo, wireType] = reader.tag();
+            switch (fieldNo) {
+                case /* google.protobuf.Timestamp timestamp */ 1:
+                    message.timestamp = Timestamp.internalBinaryRead(reader, reader.uint32(), options, message.timestamp);
+                    break;
+                case /* int64 value */ 2:
+                    message.value = reader.int64().toString();
+                    break;
+                case /* double value_per_second */ 3:
+                    message.valuePerSecond = reader.double();
+                    break;
+                default:
+                    let u = options.readUnknownField;
+                    if (u === "throw")
+                        throw new globalThis.Error(`Unknown field ${fieldNo} (wire type ${wireType}) for ${this.typeName}`);
+                    let d = reader.skip(wireType);
+                    if (u !== false)
+                        (u === true ? UnknownFieldHandler.onRead : u)(this.typeName, message, fieldNo, wireType, d);
+            }
+        }
+        return message;
+    }
+    internalBinaryWrite(message: MetricsSample, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* google.protobuf.Timestamp timestamp = 1; */
+        if (message.timestamp)
+            Timestamp.internalBinaryWrite(message.timestamp, writer.tag(1, WireType.LengthDelimited).fork(), options).join();
+        /* int64 value = 2; */
+        if (message.value !== "0")
+            writer.tag(2, WireType.Varint).int64(message.value);
+        /* double value_per_second = 3; */
+        if (message.valuePerSecond !== 0)
+            writer.tag(3, WireType.Bit64).double(message.valuePerSecond);
+        let u = options.writeUnknownFields;
+        if (u !== false)
+            (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
+        return writer;
+    }
+}
+/**
+ * @generated MessageType for protobuf message parca.query.v1alpha1.MetricsSample
+ */
+export const MetricsSample = new MetricsSample$Type();
+// @generated message type with reflection information, may provide speed optimized methods
+class MergeProfile$Type extends MessageType<MergeProfile> {
+    constructor() {
+        super("parca.query.v1alpha1.MergeProfile", [
+            { no: 1, name: "query", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 2, name: "start", kind: "message", T: () => Timestamp },
+            { no: 3, name: "end", kind: "message", T: () => Timestamp },
+            { no: 4, name: "sample_selector", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 5, name: "group_by", kind: "scalar", repeat: 2 /*RepeatType.UNPACKED*/, T: 9 /*ScalarType.STRING*/ }
+        ]);
+    }
+    create(value?: PartialMessage<MergeProfile>): MergeProfile {
+        const message = { query: "", sampleSelector: "", groupBy: [] };
+        globalThis.Object.defineProperty(message, MESSAGE_TYPE, { enumerable: false, value: this });
+        if (value !== undefined)
+            reflectionMergePartial<MergeProfile>(this, message, value);
+        return message;
+    }
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: MergeProfile): MergeProfile {
+        let message = target ?? this.create(), end = reader.pos + length;
+        while (reader.pos < end) {
+            let [fieldNo, wireType] = reader.tag();
+            switch (fieldNo) {
+                case /* string query */ 1:
+                    message.query = reader.string();
+                    break;
+                case /* google.protobuf.Timestamp start */ 2:
+                    message.start = Timestamp.internalBinaryRead(reader, reader.uint32(), options, message.start);
+                    break;
+                case /* google.protobuf.Timestamp end */ 3:
+                    message.end = Timestamp.internalBinaryRead(reader, reader.uint32(), options, message.end);
+                    break;
+                case /* string sample_selector */ 4:
+                    message.sampleSelector = reader.string();
+                    break;
+                case /* repeated string group_by */ 5:
+                    message.groupBy.push(reader.string());
+                    break;
+                default:
+                    let u = options.readUnknownField;
+                    if (u === "throw")
+                        throw new globalThis.Error(`Unknown field ${fieldNo} (wire type ${wireType}) for ${this.typeName}`);
+                    let d = reader.skip(wireType);
+                    if (u !== false)
+                        (u === true ? UnknownFieldHandler.onRead : u)(this.typeName, message, fieldNo, wireType, d);
+            }
+        }
+        return message;
+    }
+    internalBinaryWrite(message: MergeProfile, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* string query = 1; */
+        if (message.query !== "")
+            writer.tag(1, WireType.LengthDelimited).string(message.query);
+        /* google.protobuf.Timestamp start = 2; */
+        if (message.start)
+            Timestamp.internalBinaryWrite(message.start, writer.tag(2, WireType.LengthDelimited).fork(), options).join();
+        /* google.protobuf.Timestamp end = 3; */
+        if (message.end)
+            Timestamp.internalBinaryWrite(message.end, writer.tag(3, WireType.LengthDelimited).fork(), options).join();
+        /* string sample_selector = 4; */
+        if (message.sampleSelector !== "")
+            writer.tag(4, WireType.LengthDelimited).string(message.sampleSelector);
+        /* repeated string group_by = 5; */
+        for (let i = 0; i < message.groupBy.length; i++)
+            writer.tag(5, WireType.LengthDelimited).string(message.groupBy[i]);
+        let u = options.writeUnknownFields;
+        if (u !== false)
+            (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
+        return writer;
+    }
+}
+/**
+ * @generated MessageType for protobuf message parca.query.v1alpha1.MergeProfile
+ */
+export const MergeProfile = new MergeProfile$Type();
+// @generated message type with reflection information, may provide speed optimized methods
+class SingleProfile$Type extends MessageType<SingleProfile> {
+    constructor() {
+        super("parca.query.v1alpha1.SingleProfile", [
+            { no: 1, name: "time", kind: "message", T: () => Timestamp },
+            { no: 2, name: "query", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 3, name: "sample_selector", kind: "scalar", T: 9 /*ScalarType.STRING*/ }
+        ]);
+    }
+    create(value?: PartialMessage<SingleProfile>): SingleProfile {
+        const message = { query: "", sampleSelector: "" };
+        globalThis.Object.defineProperty(message, MESSAGE_TYPE, { enumerable: false, value: this });
+        if (value !== undefined)
+            reflectionMergePartial<SingleProfile>(this, message, value);
+        return message;
+    }
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: SingleProfile): SingleProfile {
+        let message = target ?? this.create(), end = reader.pos + length;
+        while (reader.pos < end) {
+            let [fieldNo, wireType] = reader.tag();
+            switch (fieldNo) {
+                case /* google.protobuf.Timestamp time */ 1:
+                    message.time = Timestamp.internalBinaryRead(reader, reader.uint32(), options, message.time);
+                    break;
+                case /* string query */ 2:
+                    message.query = reader.string();
+                    break;
+                case /* string sample_selector */ 3:
+                    message.sampleSelector = reader.string();
+                    break;
+                default:
+                    let u = options.readUnknownField;
+                    if (u === "throw")
+                        throw new globalThis.Error(`Unknown field ${fieldNo} (wire type ${wireType}) for ${this.typeName}`);
+                    let d = reader.skip(wireType);
+                    if (u !== false)
+                        (u === true ? UnknownFieldHandler.onRead : u)(this.typeName, message, fieldNo, wireType, d);
+            }
+        }
+        return message;
+    }
+    internalBinaryWrite(message: SingleProfile, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* google.protobuf.Timestamp time = 1; */
+        if (message.time)
+            Timestamp.internalBinaryWrite(message.time, writer.tag(1, WireType.LengthDelimited).fork(), options).join();
+        /* string query = 2; */
+        if (message.query !== "")
+            writer.tag(2, WireType.LengthDelimited).string(message.query);
+        /* string sample_selector = 3; */
+        if (message.sampleSelector !== "")
+            writer.tag(3, WireType.LengthDelimited).string(message.sampleSelector);
+        let u = options.writeUnknownFields;
+        if (u !== false)
+            (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
+        return writer;
+    }
+}
+/**
+ * @generated MessageType for protobuf message parca.query.v1alpha1.SingleProfile
+ */
+export const SingleProfile = new SingleProfile$Type();
+// @generated message type with reflection information, may provide speed optimized methods
+class DiffProfile$Type extends MessageType<DiffProfile> {
+    constructor() {
+        super("parca.query.v1alpha1.DiffProfile", [
+            { no: 1, name: "a", kind: "message", T: () => ProfileDiffSelection },
+            { no: 2, name: "b", kind: "message", T: () => ProfileDiffSelection }
+        ]);
+    }
+    create(value?: PartialMessage<DiffProfile>): DiffProfile {
+        const message = {};
+        globalThis.Object.defineProperty(message, MESSAGE_TYPE, { enumerable: false, value: this });
+        if (value !== undefined)
+            reflectionMergePartial<DiffProfile>(this, message, value);
+        return message;
+    }
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: DiffProfile): DiffProfile {
+        let message = target ?? this.create(), end = reader.pos + length;
+        while (reader.pos < end) {
+            let [fieldNo, wireType] = reader.tag();
+            switch (fieldNo) {
+                case /* parca.query.v1alpha1.ProfileDiffSelection a */ 1:
+                    message.a = ProfileDiffSelection.internalBinaryRead(reader, reader.uint32(), options, message.a);
+                    break;
+                case /* parca.query.v1alpha1.ProfileDiffSelection b */ 2:
+                    message.b = ProfileDiffSelection.internalBinaryRead(reader, reader.uint32(), options, message.b);
+                    break;
+                default:
+                    let u = options.readUnknownField;
+                    if (u === "throw")
+                        throw new globalThis.Error(`Unknown field ${fieldNo} (wire type ${wireType}) for ${this.typeName}`);
+                    let d = reader.skip(wireType);
+                    if (u !== false)
+                        (u === true ? UnknownFieldHandler.onRead : u)(this.typeName, message, fieldNo, wireType, d);
+            }
+        }
+        return message;
+    }
+    internalBinaryWrite(message: DiffProfile, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* parca.query.v1alpha1.ProfileDiffSelection a = 1; */
+        if (message.a)
+            ProfileDiffSelection.internalBinaryWrite(message.a, writer.tag(1, WireType.LengthDelimited).fork(), options).join();
+        /* parca.query.v1alpha1.ProfileDiffSelection b = 2; */
+        if (message.b)
+            ProfileDiffSelection.internalBinaryWrite(message.b, writer.tag(2, WireType.LengthDelimited).fork(), options).join();
+        let u = options.writeUnknownFields;
+        if (u !== false)
+            (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
+        return writer;
+    }
+}
+/**
+ * @generated MessageType for protobuf message parca.query.v1alpha1.DiffProfile
+ */
+export const DiffProfile = new DiffProfile$Type();
+// @generated message type with reflection information, may provide speed optimized methods
+class ProfileDiffSelection$Type extends MessageType<ProfileDiffSelection> {
+    constructor() {
+        super("parca.query.v1alpha1.ProfileDiffSelection", [
+            { no: 1, name: "mode", kind: "enum", T: () => ["parca.query.v1alpha1.ProfileDiffSelection.Mode", ProfileDiffSelection_Mode, "MODE_"] },
+            { no: 2, name: "merge", kind: "message", oneof: "options", T: () => MergeProfile },
+            { no: 3, name: "single", kind: "message", oneof: "options", T: () => SingleProfile }
+        ]);
+    }
+    create(value?: PartialMessage<ProfileDiffSelection>): ProfileDiffSelection {
+        const message = { mode: 0, options: { oneofKind: undefined } };
+        globalThis.Object.defineProperty(message, MESSAGE_TYPE, { enumerable: false, value: this });
+        if (value !== undefined)
+            reflectionMergePartial<ProfileDiffSelection>(this, message, value);
+        return message;
+    }
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: ProfileDiffSelection): ProfileDiffSelection {
+        let message = target ?? this.create(), end = reader.pos + length;
+        while (reader.pos < end) {
+            let [fieldNo, wireType] = reader.tag();
+            switch (fieldNo) {
+                case /* parca.query.v1alpha1.ProfileDiffSelection.Mode mode */ 1:
+                    message.mode = reader.int32();
+                    break;
+                case /* parca.query.v1alpha1.MergeProfile merge */ 2:
+                    message.options = {
+                        oneofKind: "merge",
+                        merge: MergeProfile.internalBinaryRead(reader, reader.uint32(), options, (message.options as any).merge)
+                    };
+                    break;
+                case /* parca.query.v1alpha1.SingleProfile single */ 3:
+                    message.options = {
+                        oneofKind: "single",
+                        single: SingleProfile.internalBinaryRead(reader, reader.uint32(), options, (message.options as any).single)
+                    };
+                    break;
+                default:
+                    let u = options.readUnknownField;
+                    if (u === "throw")
+                        throw new globalThis.Error(`Unknown field ${fieldNo} (wire type ${wireType}) for ${this.typeName}`);
+                    let d = reader.skip(wireType);
+                    if (u !== false)
+                        (u === true ? UnknownFieldHandler.onRead : u)(this.typeName, message, fieldNo, wireType, d);
+            }
+        }
+        return message;
+    }
+    internalBinaryWrite(message: ProfileDiffSelection, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* parca.query.v1alpha1.ProfileDiffSelection.Mode mode = 1; */
+        if (message.mode !== 0)
+            writer.tag(1, WireType.Varint).int32(message.mode);
+        /* parca.query.v1alpha1.MergeProfile merge = 2; */
+        if (message.options.oneofKind === "merge")
+            MergeProfile.internalBinaryWrite(message.options.merge, writer.tag(2, WireType.LengthDelimited).fork(), options).join();
+        /* parca.query.v1alpha1.SingleProfile single = 3; */
+        if (message.options.oneofKind === "single")
+            SingleProfile.internalBinaryWrite(message.options.single, writer.tag(3, WireType.LengthDelimited).fork(), options).join();
+        let u = options.writeUnknownFields;
+        if (u !== false)
+            (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
+        return writer;
+    }
+}
+/**
+ * @generated MessageType for protobuf message parca.query.v1alpha1.ProfileDiffSelection
+ */
+export const ProfileDiffSelection = new ProfileDiffSelection$Type();
+// @generated message type with reflection information, may provide speed optimized methods
+class QueryRequest$Type extends MessageType<QueryRequest> {
+    constructor() {
+        super("parca.query.v1alpha1.QueryRequest", [
+            { no: 1, name: "mode", kind: "enum", T: () => ["parca.query.v1alpha1.QueryRequest.Mode", QueryRequest_Mode, "MODE_"] },
+            { no: 2, name: "diff", kind: "message", oneof: "options", T: () => DiffProfile },
+            { no: 3, name: "merge", kind: "message", oneof: "options", T: () => MergeProfile },
+            { no: 4, name: "single", kind: "message", oneof: "options", T: () => SingleProfile },
+            { no: 5, name: "report_type", kind: "enum", T: () => ["parca.query.v1alpha1.QueryRequest.ReportType", QueryRequest_ReportType, "REPORT_TYPE_"] },
+            { no: 6, name: "filter", kind: "message", T: () => Filter },
+            { no: 7, name: "function", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 8, name: "graph_options", kind: "message", T: () => GraphOptions },
+            { no: 9, name: "source_options", kind: "message", T: () => SourceOptions }
+        ]);
+    }
+    create(value?: PartialMessage<QueryRequest>): QueryRequest {
+        const message = { mode: 0, options: { oneofKind: undefined }, reportType: 0, function: "" };
+        globalThis.Object.defineProperty(message, MESSAGE_TYPE, { enumerable: false, value: this });
+        if (value !== undefined)
+            reflectionMergePartial<QueryRequest>(this, message, value);
+        return message;
+    }
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: QueryRequest): QueryRequest {
+        let message = target ?? this.create(), end = reader.pos + length;
+        while (reader.pos < end) {
+            let [fieldNo, wireType] = reader.tag();
+            switch (fieldNo) {
+                case /* parca.query.v1alpha1.QueryRequest.Mode mode */ 1:
+                    message.mode = reader.int32();
+                    break;
+                case /* parca.query.v1alpha1.DiffProfile diff */ 2:
+                    message.options = {
+                        oneofKind: "diff",
+                        diff: DiffProfile.internalBinaryRead(reader, reader.uint32(), options, (message.options as any).diff)
+                    };
+                    break;
+                case /* parca.query.v1alpha1.MergeProfile merge */ 3:
+                    message.options = {
+                        oneofKind: "merge",
+                        merge: MergeProfile.internalBinaryRead(reader, reader.uint32(), options, (message.options as any).merge)
+                    };
+                    break;
+                case /* parca.query.v1alpha1.SingleProfile single */ 4:
+                    message.options = {
+                        oneofKind: "single",
+                        single: SingleProfile.internalBinaryRead(reader, reader.uint32(), options, (message.options as any).single)
+                    };
+                    break;
+                case /* parca.query.v1alpha1.QueryRequest.ReportType report_type */ 5:
+                    message.reportType = reader.int32();
+                    break;
+                case /* parca.query.v1alpha1.Filter filter */ 6:
+                    message.filter = Filter.internalBinaryRead(reader, reader.uint32(), options, message.filter);
+                    break;
+                case /* string function */ 7:
+                    message.function = reader.string();
+                    break;
+                case /* parca.query.v1alpha1.GraphOptions graph_options */ 8:
+                    message.graphOptions = GraphOptions.internalBinaryRead(reader, reader.uint32(), options, message.graphOptions);
+                    break;
+                case /* parca.query.v1alpha1.SourceOptions source_options */ 9:
+                    message.sourceOptions = SourceOptions.internalBinaryRead(reader, reader.uint32(), options, message.sourceOptions);
+                    break;
+                default:
+                    let u = options.readUnknownField;
+                    if (u === "throw")
+                        throw new globalThis.Error(`Unknown field ${fieldNo} (wire type ${wireType}) for ${this.typeName}`);
+                    let d = reader.skip(wireType);
+                    if (u !== false)
+                        (u === true ? UnknownFieldHandler.onRead : u)(this.typeName, message, fieldNo, wireType, d);
+            }
+        }
+        return message;
+    }
+    internalBinaryWrite(message: QueryRequest, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* parca.query.v1alpha1.QueryRequest.Mode mode = 1; */
+        if (message.mode !== 0)
+            writer.tag(1, WireType.Varint).int32(message.mode);
+        /* parca.query.v1alpha1.DiffProfile diff = 2; */
+        if (message.options.oneofKind === "diff")
+            DiffProfile.internalBinaryWrite(message.options.diff, writer.tag(2, WireType.LengthDelimited).fork(), options).join();
+        /* parca.query.v1alpha1.MergeProfile merge = 3; */
+        if (message.options.oneofKind === "merge")
+            MergeProfile.internalBinaryWrite(message.options.merge, writer.tag(3, WireType.LengthDelimited).fork(), options).join();
+        /* parca.query.v1alpha1.SingleProfile single = 4; */
+        if (message.options.oneofKind === "single")
+            SingleProfile.internalBinaryWrite(message.options.single, writer.tag(4, WireType.LengthDelimited).fork(), options).join();
+        /* parca.query.v1alpha1.QueryRequest.ReportType report_type = 5; */
+        if (message.reportType !== 0)
+            writer.tag(5, WireType.Varint).int32(message.reportType);
+        /* parca.query.v1alpha1.Filter filter = 6; */
+        if (message.filter)
+            Filter.internalBinaryWrite(message.filter, writer.tag(6, WireType.LengthDelimited).fork(), options).join();
+        /* string function = 7; */
+        if (message.function !== "")
+            writer.tag(7, WireType.LengthDelimited).string(message.function);
+        /* parca.query.v1alpha1.GraphOptions graph_options = 8; */
+        if (message.graphOptions)
+            GraphOptions.internalBinaryWrite(message.graphOptions, writer.tag(8, WireType.LengthDelimited).fork(), options).join();
+        /* parca.query.v1alpha1.SourceOptions source_options = 9; */
+        if (message.sourceOptions)
+            SourceOptions.internalBinaryWrite(message.sourceOptions, writer.tag(9, WireType.LengthDelimited).fork(), options).join();
+        let u = options.writeUnknownFields;
+        if (u !== false)
+            (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
+        return writer;
+    }
 }
 /**
- * ValuesResponse are the set of matching values
- *
- * @generated from protobuf message parca.query.v1alpha1.ValuesResponse
+ * @generated MessageType for protobuf message parca.query.v1alpha1.QueryRequest
  */
-export interface ValuesResponse {
-    /**
-     * label_values are the set of matching label values
-     *
-     * @generated from protobuf field: repeated string label_values = 1;
-     */
-    labelValues: string[];
-    /**
-     * warnings is unimplemented
-     *
-     * @generated from protobuf field: repeated string warnings = 2;
-     */
-    warnings: string[];
+export const QueryRequest = new QueryRequest$Type();
+// @generated message type with reflection information, may provide speed optimized methods
+class SourceOptions$Type extends MessageType<SourceOptions> {
+    constructor() {
+        super("parca.query.v1alpha1.SourceOptions", [
+            { no: 1, name: "filename", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 2, name: "function", kind: "scalar", T: 9 /*ScalarType.STRING*/ }
+        ]);
+    }
+    create(value?: PartialMessage<SourceOptions>): SourceOptions {
+        const message = { filename: "", function: "" };
+        globalThis.Object.defineProperty(message, MESSAGE_TYPE, { enumerable: false, value: this });
+        if (value !== undefined)
+            reflectionMergePartial<SourceOptions>(this, message, value);
+        return message;
+    }
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: SourceOptions): SourceOptions {
+        let message = target ?? this.create(), end = reader.pos + length;
+        while (reader.pos < end) {
+            let [fieldNo, wireType] = reader.tag();
+            switch (fieldNo) {
+                case /* string filename */ 1:
+                    message.filename = reader.string();
+                    break;
+                case /* string function */ 2:
+                    message.function = reader.string();
+                    break;
+                default:
+                    let u = options.readUnknownField;
+                    if (u === "throw")
+                        throw new globalThis.Error(`Unknown field ${fieldNo} (wire type ${wireType}) for ${this.typeName}`);
+                    let d = reader.skip(wireType);
+                    if (u !== false)
+                        (u === true ? UnknownFieldHandler.onRead : u)(this.typeName, message, fieldNo, wireType, d);
+            }
+        }
+        return message;
+    }
+    internalBinaryWrite(message: SourceOptions, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* string filename = 1; */
+        if (message.filename !== "")
+            writer.tag(1, WireType.LengthDelimited).string(message.filename);
+        /* string function = 2; */
+        if (message.function !== "")
+            writer.tag(2, WireType.LengthDelimited).string(message.function);
+        let u = options.writeUnknownFields;
+        if (u !== false)
+            (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
+        return writer;
+    }
 }
 /**
- * ValueType represents a value, including its type and unit
- *
- * @generated from protobuf message parca.query.v1alpha1.ValueType
+ * @generated MessageType for protobuf message parca.query.v1alpha1.SourceOptions
  */
-export interface ValueType {
-    /**
-     * type is the type of the value
-     *
-     * @generated from protobuf field: string type = 1;
-     */
-    type: string;
-    /**
-     * unit is the unit of the value
-     *
-     * @generated from protobuf field: string unit = 2;
-     */
-    unit: string;
-}
+export const SourceOptions = new SourceOptions$Type();
 // @generated message type with reflection information, may provide speed optimized methods
-class ProfileTypesRequest$Type extends MessageType<ProfileTypesRequest> {
+class GraphOptions$Type extends MessageType<GraphOptions> {
     constructor() {
-        super("parca.query.v1alpha1.ProfileTypesRequest", []);
+        super("parca.query.v1alpha1.GraphOptions", [
+            { no: 1, name: "node_fraction", kind: "scalar", T: 2 /*ScalarType.FLOAT*/ },
+            { no: 2, name: "edge_fraction", kind: "scalar", T: 2 /*ScalarType.FLOAT*/ },
+            { no: 3, name: "dot", kind: "scalar", T: 8 /*ScalarType.BOOL*/ }
+        ]);
     }
-    create(value?: PartialMessage<ProfileTypesRequest>): ProfileTypesRequest {
-        const message = {};
+    create(value?: PartialMessage<GraphOptions>): GraphOptions {
+        const message = { nodeFraction: 0, edgeFraction: 0, dot: false };
         globalThis.Object.defineProperty(message, MESSAGE_TYPE, { enumerable: false, value: this });
         if (value !== undefined)
-            reflectionMergePartial<ProfileTypesRequest>(this, message, value);
+            reflectionMergePartial<GraphOptions>(this, message, value);
         return message;
     }
-    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: ProfileTypesRequest): ProfileTypesRequest {
-        return target ?? this.create();
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: GraphOptions): GraphOptions {
+        let message = target ?? this.create(), end = reader.pos + length;
+        while (reader.pos < end) {
+            let [fieldNo, wireType] = reader.tag();
+            switch (fieldNo) {
+                case /* float node_fraction */ 1:
+                    message.nodeFraction = reader.float();
+                    break;
+                case /* float edge_fraction */ 2:
+                    message.edgeFraction = reader.float();
+                    break;
+                case /* bool dot */ 3:
+                    message.dot = reader.bool();
+                    break;
+                default:
+                    let u = options.readUnknownField;
+                    if (u === "throw")
+                        throw new globalThis.Error(`Unknown field ${fieldNo} (wire type ${wireType}) for ${this.typeName}`);
+                    let d = reader.skip(wireType);
+                    if (u !== false)
+                        (u === true ? UnknownFieldHandler.onRead : u)(this.typeName, message, fieldNo, wireType, d);
+            }
+        }
+        return message;
     }
-    internalBinaryWrite(message: ProfileTypesRequest, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+    internalBinaryWrite(message: GraphOptions, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* float node_fraction = 1; */
+        if (message.nodeFraction !== 0)
+            writer.tag(1, WireType.Bit32).float(message.nodeFraction);
+        /* float edge_fraction = 2; */
+        if (message.edgeFraction !== 0)
+            writer.tag(2, WireType.Bit32).float(message.edgeFraction);
+        /* bool dot = 3; */
+        if (message.dot !== false)
+            writer.tag(3, WireType.Varint).bool(message.dot);
         let u = options.writeUnknownFields;
         if (u !== false)
             (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
@@ -807,30 +2509,46 @@ class ProfileTypesRequest$Type extends MessageType<ProfileTypesRequest> {
     }
 }
 /**
- * @generated MessageType for protobuf message parca.query.v1alpha1.ProfileTypesRequest
+ * @generated MessageType for protobuf message parca.query.v1alpha1.GraphOptions
  */
-export const ProfileTypesRequest = new ProfileTypesRequest$Type();
+export const GraphOptions = new GraphOptions$Type();
 // @generated message type with reflection information, may provide speed optimized methods
-class ProfileTypesResponse$Type extends MessageType<ProfileTypesResponse> {
+class Filter$Type extends MessageType<Filter> {
     constructor() {
-        super("parca.query.v1alpha1.ProfileTypesResponse", [
-            { no: 1, name: "types", kind: "message", repeat: 1 /*RepeatType.PACKED*/, T: () => ProfileType }
+        super("parca.query.v1alpha1.Filter", [
+            { no: 1, name: "focus", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 2, name: "ignore", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 3, name: "hide", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 4, name: "show_from", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 5, name: "prune_from", kind: "scalar", T: 9 /*ScalarType.STRING*/ }
         ]);
     }
-    create(value?: PartialMessage<ProfileTypesResponse>): ProfileTypesResponse {
-        const message = { types: [] };
+    create(value?: PartialMessage<Filter>): Filter {
+        const message = { focus: "", ignore: "", hide: "", showFrom: "", pruneFrom: "" };
         globalThis.Object.defineProperty(message, MESSAGE_TYPE, { enumerable: false, value: this });
         if (value !== undefined)
-            reflectionMergePartial<ProfileTypesResponse>(this, message, value);
+            reflectionMergePartial<Filter>(this, message, value);
         return message;
     }
-    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: ProfileTypesResponse): ProfileTypesResponse {
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: Filter): Filter {
         let message = target ?? this.create(), end = reader.pos + length;
         while (reader.pos < end) {
             let [fieldNo, wireType] = reader.tag();
             switch (fieldNo) {
-                case /* repeated parca.query.v1alpha1.ProfileType types */ 1:
-                    message.types.push(ProfileType.internalBinaryRead(reader, reader.uint32(), options));
+                case /* string focus */ 1:
+                    message.focus = reader.string();
+                    break;
+                case /* string ignore */ 2:
+                    message.ignore = reader.string();
+                    break;
+                case /* string hide */ 3:
+                    message.hide = reader.string();
+                    break;
+                case /* string show_from */ 4:
+                    message.showFrom = reader.string();
+                    break;
+                case /* string prune_from */ 5:
+                    message.pruneFrom = reader.string();
                     break;
                 default:
                     let u = options.readUnknownField;
@@ -843,10 +2561,22 @@ class ProfileTypesResponse$Type extends MessageType<ProfileTypesResponse> {
         }
         return message;
     }
-    internalBinaryWrite(message: ProfileTypesResponse, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
-        /* repeated parca.query.v1alpha1.ProfileType types = 1; */
-        for (let i = 0; i < message.types.length; i++)
-            ProfileType.internalBinaryWrite(message.types[i], writer.tag(1, WireType.LengthDelimited).fork(), options).join();
+    internalBinaryWrite(message: Filter, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* string focus = 1; */
+        if (message.focus !== "")
+            writer.tag(1, WireType.LengthDelimited).string(message.focus);
+        /* string ignore = 2; */
+        if (message.ignore !== "")
+            writer.tag(2, WireType.LengthDelimited).string(message.ignore);
+        /* string hide = 3; */
+        if (message.hide !== "")
+            writer.tag(3, WireType.LengthDelimited).string(message.hide);
+        /* string show_from = 4; */
+        if (message.showFrom !== "")
+            writer.tag(4, WireType.LengthDelimited).string(message.showFrom);
+        /* string prune_from = 5; */
+        if (message.pruneFrom !== "")
+            writer.tag(5, WireType.LengthDelimited).string(message.pruneFrom);
         let u = options.writeUnknownFields;
         if (u !== false)
             (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
@@ -854,50 +2584,110 @@ class ProfileTypesResponse$Type extends MessageType<ProfileTypesResponse> {
     }
 }
 /**
- * @generated MessageType for protobuf message parca.query.v1alpha1.ProfileTypesResponse
+ * @generated MessageType for protobuf message parca.query.v1alpha1.Filter
  */
-export const ProfileTypesResponse = new ProfileTypesResponse$Type();
+export const Filter = new Filter$Type();
 // @generated message type with reflection information, may provide speed optimized methods
-class ProfileType$Type extends MessageType<ProfileType> {
+class Top$Type extends MessageType<Top> {
     constructor() {
-        super("parca.query.v1alpha1.ProfileType", [
-            { no: 1, name: "name", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
-            { no: 2, name: "sample_type", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
-            { no: 3, name: "sample_unit", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
-            { no: 4, name: "period_type", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
-            { no: 5, name: "period_unit", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
-            { no: 6, name: "delta", kind: "scalar", T: 8 /*ScalarType.BOOL*/ }
+        super("parca.query.v1alpha1.Top", [
+            { no: 1, name: "list", kind: "message", repeat: 1 /*RepeatType.PACKED*/, T: () => TopNode },
+            { no: 2, name: "reported", kind: "scalar", T: 5 /*ScalarType.INT32*/ },
+            { no: 3, name: "total", kind: "scalar", T: 5 /*ScalarType.INT32*/ },
+            { no: 4, name: "unit", kind: "scalar", T: 9 /*ScalarType.STRING*/ }
         ]);
     }
-    create(value?: PartialMessage<ProfileType>): ProfileType {
-        const message = { name: "", sampleType: "", sampleUnit: "", periodType: "", periodUnit: "", delta: false };
+    create(value?: PartialMessage<Top>): Top {
+        const message = { list: [], reported: 0, total: 0, unit: "" };
         globalThis.Object.defineProperty(message, MESSAGE_TYPE, { enumerable: false, value: this });
         if (value !== undefined)
-            reflectionMergePartial<ProfileType>(this, message, value);
+            reflectionMergePartial<Top>(this, message, value);
         return message;
     }
-    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: ProfileType): ProfileType {
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: Top): Top {
         let message = target ?? this.create(), end = reader.pos + length;
         while (reader.pos < end) {
             let [fieldNo, wireType] = reader.tag();
             switch (fieldNo) {
-                case /* string name */ 1:
-                    message.name = reader.string();
+                case /* repeated parca.query.v1alpha1.TopNode list */ 1:
+                    message.list.push(TopNode.internalBinaryRead(reader, reader.uint32(), options));
                     break;
-                case /* string sample_type */ 2:
-                    message.sampleType = reader.string();
+                case /* int32 reported */ 2:
+                    message.reported = reader.int32();
                     break;
-                case /* string sample_unit */ 3:
-                    message.sampleUnit = reader.string();
+                case /* int32 total */ 3:
+                    message.total = reader.int32();
                     break;
-                case /* string period_type */ 4:
-                    message.periodType = reader.string();
+                case /* string unit */ 4:
+                    message.unit = reader.string();
                     break;
-                case /* string period_unit */ 5:
-                    message.periodUnit = reader.string();
+                default:
+                    let u = options.readUnknownField;
+                    if (u === "throw")
+                        throw new globalThis.Error(`Unknown field ${fieldNo} (wire type ${wireType}) for ${this.typeName}`);
+                    let d = reader.skip(wireType);
+                    if (u !== false)
+                        (u === true ? UnknownFieldHandler.onRead : u)(this.typeName, message, fieldNo, wireType, d);
+            }
+        }
+        return message;
+    }
+    internalBinaryWrite(message: Top, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* repeated parca.query.v1alpha1.TopNode list = 1; */
+        for (let i = 0; i < message.list.length; i++)
+            TopNode.internalBinaryWrite(message.list[i], writer.tag(1, WireType.LengthDelimited).fork(), options).join();
+        /* int32 reported = 2; */
+        if (message.reported !== 0)
+            writer.tag(2, WireType.Varint).int32(message.reported);
+        /* int32 total = 3; */
+        if (message.total !== 0)
+            writer.tag(3, WireType.Varint).int32(message.total);
+        /* string unit = 4; */
+        if (message.unit !== "")
+            writer.tag(4, WireType.LengthDelimited).string(message.unit);
+        let u = options.writeUnknownFields;
+        if (u !== false)
+            (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
+        return writer;
+    }
+}
+/**
+ * @generated MessageType for protobuf message parca.query.v1alpha1.Top
+ */
+export const Top = new Top$Type();
+// @generated message type with reflection information, may provide speed optimized methods
+class TopNode$Type extends MessageType<TopNode> {
+    constructor() {
+        super("parca.query.v1alpha1.TopNode", [
+            { no: 1, name: "meta", kind: "message", T: () => TopNodeMeta },
+            { no: 2, name: "cumulative", kind: "scalar", T: 3 /*ScalarType.INT64*/ },
+            { no: 3, name: "flat", kind: "scalar", T: 3 /*ScalarType.INT64*/ },
+            { no: 4, name: "diff", kind: "scalar", T: 3 /*ScalarType.INT64*/ }
+        ]);
+    }
+    create(value?: PartialMessage<TopNode>): TopNode {
+        const message = { cumulative: "0", flat: "0", diff: "0" };
+        globalThis.Object.defineProperty(message, MESSAGE_TYPE, { enumerable: false, value: this });
+        if (value !== undefined)
+            reflectionMergePartial<TopNode>(this, message, value);
+        return message;
+    }
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: TopNode): TopNode {
+        let message = target ?? this.create(), end = reader.pos + length;
+        while (reader.pos < end) {
+            let [fieldNo, wireType] = reader.tag();
+            switch (fieldNo) {
+                case /* parca.query.v1alpha1.TopNodeMeta meta */ 1:
+                    message.meta = TopNodeMeta.internalBinaryRead(reader, reader.uint32(), options, message.meta);
                     break;
-                case /* bool delta */ 6:
-                    message.delta = reader.bool();
+                case /* int64 cumulative */ 2:
+                    message.cumulative = reader.int64().toString();
+                    break;
+                case /* int64 flat */ 3:
+                    message.flat = reader.int64().toString();
+                    break;
+                case /* int64 diff */ 4:
+                    message.diff = reader.int64().toString();
                     break;
                 default:
                     let u = options.readUnknownField;
@@ -910,25 +2700,19 @@ class ProfileType$Type extends MessageType<ProfileType> {
         }
         return message;
     }
-    internalBinaryWrite(message: ProfileType, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
-        /* string name = 1; */
-        if (message.name !== "")
-            writer.tag(1, WireType.LengthDelimited).string(message.name);
-        /* string sample_type = 2; */
-        if (message.sampleType !== "")
-            writer.tag(2, WireType.LengthDelimited).string(message.sampleType);
-        /* string sample_unit = 3; */
-        if (message.sampleUnit !== "")
-            writer.tag(3, WireType.LengthDelimited).string(message.sampleUnit);
-        /* string period_type = 4; */
-        if (message.periodType !== "")
-            writer.tag(4, WireType.LengthDelimited).string(message.periodType);
-        /* string period_unit = 5; */
-        if (message.periodUnit !== "")
-            writer.tag(5, WireType.LengthDelimited).string(message.periodUnit);
-        /* bool delta = 6; */
-        if (message.delta !== false)
-            writer.tag(6, WireType.Varint).bool(message.delta);
+    internalBinaryWrite(message: TopNode, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* parca.query.v1alpha1.TopNodeMeta meta = 1; */
+        if (message.meta)
+            TopNodeMeta.internalBinaryWrite(message.meta, writer.tag(1, WireType.LengthDelimited).fork(), options).join();
+        /* int64 cumulative = 2; */
+        if (message.cumulative !== "0")
+            writer.tag(2, WireType.Varint).int64(message.cumulative);
+        /* int64 flat = 3; */
+        if (message.flat !== "0")
+            writer.tag(3, WireType.Varint).int64(message.flat);
+        /* int64 diff = 4; */
+        if (message.diff !== "0")
+            writer.tag(4, WireType.Varint).int64(message.diff);
         let u = options.writeUnknownFields;
         if (u !== false)
             (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
@@ -936,42 +2720,42 @@ class ProfileType$Type extends MessageType<ProfileType> {
     }
 }
 /**
- * @generated MessageType for protobuf message parca.query.v1alpha1.ProfileType
+ * @generated MessageType for protobuf message parca.query.v1alpha1.TopNode
  */
-export const ProfileType = new ProfileType$Type();
+export const TopNode = new TopNode$Type();
 // @generated message type with reflection information, may provide speed optimized methods
-class QueryRangeRequest$Type extends MessageType<QueryRangeRequest> {
+class TopNodeMeta$Type extends MessageType<TopNodeMeta> {
     constructor() {
-        super("parca.query.v1alpha1.QueryRangeRequest", [
-            { no: 1, name: "query", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
-            { no: 2, name: "start", kind: "message", T: () => Timestamp },
-            { no: 3, name: "end", kind: "message", T: () => Timestamp },
-            { no: 4, name: "limit", kind: "scalar", T: 13 /*ScalarType.UINT32*/ }
+        super("parca.query.v1alpha1.TopNodeMeta", [
+            { no: 1, name: "location", kind: "message", T: () => Location },
+            { no: 2, name: "mapping", kind: "message", T: () => Mapping },
+            { no: 3, name: "function", kind: "message", T: () => Function },
+            { no: 4, name: "line", kind: "message", T: () => Line }
         ]);
     }
-    create(value?: PartialMessage<QueryRangeRequest>): QueryRangeRequest {
-        const message = { query: "", limit: 0 };
+    create(value?: PartialMessage<TopNodeMeta>): TopNodeMeta {
+        const message = {};
         globalThis.Object.defineProperty(message, MESSAGE_TYPE, { enumerable: false, value: this });
         if (value !== undefined)
-            reflectionMergePartial<QueryRangeRequest>(this, message, value);
+            reflectionMergePartial<TopNodeMeta>(this, message, value);
         return message;
     }
-    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: QueryRangeRequest): QueryRangeRequest {
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: TopNodeMeta): TopNodeMeta {
         let message = target ?? this.create(), end = reader.pos + length;
         while (reader.pos < end) {
             let [fieldNo, wireType] = reader.tag();
             switch (fieldNo) {
-                case /* string query */ 1:
-                    message.query = reader.string();
+                case /* parca.metastore.v1alpha1.Location location */ 1:
+                    message.location = Location.internalBinaryRead(reader, reader.uint32(), options, message.location);
                     break;
-                case /* google.protobuf.Timestamp start */ 2:
-                    message.start = Timestamp.internalBinaryRead(reader, reader.uint32(), options, message.start);
+                case /* parca.metastore.v1alpha1.Mapping mapping */ 2:
+                    message.mapping = Mapping.internalBinaryRead(reader, reader.uint32(), options, message.mapping);
                     break;
-                case /* google.protobuf.Timestamp end */ 3:
-                    message.end = Timestamp.internalBinaryRead(reader, reader.uint32(), options, message.end);
+                case /* parca.metastore.v1alpha1.Function function */ 3:
+                    message.function = Function.internalBinaryRead(reader, reader.uint32(), options, message.function);
                     break;
-                case /* uint32 limit */ 4:
-                    message.limit = reader.uint32();
+                case /* parca.metastore.v1alpha1.Line line */ 4:
+                    message.line = Line.internalBinaryRead(reader, reader.uint32(), options, message.line);
                     break;
                 default:
                     let u = options.readUnknownField;
@@ -984,19 +2768,19 @@ class QueryRangeRequest$Type extends MessageType<QueryRangeRequest> {
         }
         return message;
     }
-    internalBinaryWrite(message: QueryRangeRequest, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
-        /* string query = 1; */
-        if (message.query !== "")
-            writer.tag(1, WireType.LengthDelimited).string(message.query);
-        /* google.protobuf.Timestamp start = 2; */
-        if (message.start)
-            Timestamp.internalBinaryWrite(message.start, writer.tag(2, WireType.LengthDelimited).fork(), options).join();
-        /* google.protobuf.Timestamp end = 3; */
-        if (message.end)
-            Timestamp.internalBinaryWrite(message.end, writer.tag(3, WireType.LengthDelimited).fork(), options).join();
-        /* uint32 limit = 4; */
-        if (message.limit !== 0)
-            writer.tag(4, WireType.Varint).uint32(message.limit);
+    internalBinaryWrite(message: TopNodeMeta, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* parca.metastore.v1alpha1.Location location = 1; */
+        if (message.location)
+            Location.internalBinaryWrite(message.location, writer.tag(1, WireType.LengthDelimited).fork(), options).join();
+        /* parca.metastore.v1alpha1.Mapping mapping = 2; */
+        if (message.mapping)
+            Mapping.internalBinaryWrite(message.mapping, writer.tag(2, WireType.LengthDelimited).fork(), options).join();
+        /* parca.metastore.v1alpha1.Function function = 3; */
+        if (message.function)
+            Function.internalBinaryWrite(message.function, writer.tag(3, WireType.LengthDelimited).fork(), options).join();
+        /* parca.metastore.v1alpha1.Line line = 4; */
+        if (message.line)
+            Line.internalBinaryWrite(message.line, writer.tag(4, WireType.LengthDelimited).fork(), options).join();
         let u = options.writeUnknownFields;
         if (u !== false)
             (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
@@ -1004,30 +2788,54 @@ class QueryRangeRequest$Type extends MessageType<QueryRangeRequest> {
     }
 }
 /**
- * @generated MessageType for protobuf message parca.query.v1alpha1.QueryRangeRequest
+ * @generated MessageType for protobuf message parca.query.v1alpha1.TopNodeMeta
  */
-export const QueryRangeRequest = new QueryRangeRequest$Type();
+export const TopNodeMeta = new TopNodeMeta$Type();
 // @generated message type with reflection information, may provide speed optimized methods
-class QueryRangeResponse$Type extends MessageType<QueryRangeResponse> {
+class CallgraphNeighborhood$Type extends MessageType<CallgraphNeighborhood> {
     constructor() {
-        super("parca.query.v1alpha1.QueryRangeResponse", [
-            { no: 1, name: "series", kind: "message", repeat: 1 /*RepeatType.PACKED*/, T: () => MetricsSeries }
+        super("parca.query.v1alpha1.CallgraphNeighborhood", [
+            { no: 1, name: "function", kind: "message", T: () => Function },
+            { no: 2, name: "cumulative", kind: "scalar", T: 3 /*ScalarType.INT64*/ },
+            { no: 3, name: "flat", kind: "scalar", T: 3 /*ScalarType.INT64*/ },
+            { no: 4, name: "diff", kind: "scalar", T: 3 /*ScalarType.INT64*/ },
+            { no: 5, name: "callers", kind: "message", repeat: 1 /*RepeatType.PACKED*/, T: () => CallgraphNeighborhoodNode },
+            { no: 6, name: "callees", kind: "message", repeat: 1 /*RepeatType.PACKED*/, T: () => CallgraphNeighborhoodNode },
+            { no: 7, name: "unit", kind: "scalar", T: 9 /*ScalarType.STRING*/ }
         ]);
     }
-    create(value?: PartialMessage<QueryRangeResponse>): QueryRangeResponse {
-        const message = { series: [] };
+    create(value?: PartialMessage<CallgraphNeighborhood>): CallgraphNeighborhood {
+        const message = { cumulative: "0", flat: "0", diff: "0", callers: [], callees: [], unit: "" };
         globalThis.Object.defineProperty(message, MESSAGE_TYPE, { enumerable: false, value: this });
         if (value !== undefined)
-            reflectionMergePartial<QueryRangeResponse>(this, message, value);
+            reflectionMergePartial<CallgraphNeighborhood>(this, message, value);
         return message;
     }
-    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: QueryRangeResponse): QueryRangeResponse {
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: CallgraphNeighborhood): CallgraphNeighborhood {
         let message = target ?? this.create(), end = reader.pos + length;
         while (reader.pos < end) {
             let [fieldNo, wireType] = reader.tag();
             switch (fieldNo) {
-                case /* repeated parca.query.v1alpha1.MetricsSeries series */ 1:
-                    message.series.push(MetricsSeries.internalBinaryRead(reader, reader.uint32(), options));
+                case /* parca.metastore.v1alpha1.Function function */ 1:
+                    message.function = Function.internalBinaryRead(reader, reader.uint32(), options, message.function);
+                    break;
+                case /* int64 cumulative */ 2:
+                    message.cumulative = reader.int64().toString();
+                    break;
+                case /* int64 flat */ 3:
+                    message.flat = reader.int64().toString();
+                    break;
+                case /* int64 diff */ 4:
+                    message.diff = reader.int64().toString();
+                    break;
+                case /* repeated parca.query.v1alpha1.CallgraphNeighborhoodNode callers */ 5:
+                    message.callers.push(CallgraphNeighborhoodNode.internalBinaryRead(reader, reader.uint32(), options));
+                    break;
+                case /* repeated parca.query.v1alpha1.CallgraphNeighborhoodNode callees */ 6:
+                    message.callees.push(CallgraphNeighborhoodNode.internalBinaryRead(reader, reader.uint32(), options));
+                    break;
+                case /* string unit */ 7:
+                    message.unit = reader.string();
                     break;
                 default:
                     let u = options.readUnknownField;
@@ -1040,10 +2848,28 @@ class QueryRangeResponse$Type extends MessageType<QueryRangeResponse> {
         }
         return message;
     }
-    internalBinaryWrite(message: QueryRangeResponse, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
-        /* repeated parca.query.v1alpha1.MetricsSeries series = 1; */
-        for (let i = 0; i < message.series.length; i++)
-            MetricsSeries.internalBinaryWrite(message.series[i], writer.tag(1, WireType.LengthDelimited).fork(), options).join();
+    internalBinaryWrite(message: CallgraphNeighborhood, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* parca.metastore.v1alpha1.Function function = 1; */
+        if (message.function)
+            Function.internalBinaryWrite(message.function, writer.tag(1, WireType.LengthDelimited).fork(), options).join();
+        /* int64 cumulative = 2; */
+        if (message.cumulative !== "0")
+            writer.tag(2, WireType.Varint).int64(message.cumulative);
+        /* int64 flat = 3; */
+        if (message.flat !== "0")
+            writer.tag(3, WireType.Varint).int64(message.flat);
+        /* int64 diff = 4; */
+        if (message.diff !== "0")
+            writer.tag(4, WireType.Varint).int64(message.diff);
+        /* repeated parca.query.v1alpha1.CallgraphNeighborhoodNode callers = 5; */
+        for (let i = 0; i < message.callers.length; i++)
+            CallgraphNeighborhoodNode.internalBinaryWrite(message.callers[i], writer.tag(5, WireType.LengthDelimited).fork(), options).join();
+        /* repeated parca.query.v1alpha1.CallgraphNeighborhoodNode callees = 6; */
+        for (let i = 0; i < message.callees.length; i++)
+            CallgraphNeighborhoodNode.internalBinaryWrite(message.callees[i], writer.tag(6, WireType.LengthDelimited).fork(), options).join();
+        /* string unit = 7; */
+        if (message.unit !== "")
+            writer.tag(7, WireType.LengthDelimited).string(message.unit);
         let u = options.writeUnknownFields;
         if (u !== false)
             (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
@@ -1051,42 +2877,54 @@ class QueryRangeResponse$Type extends MessageType<QueryRangeResponse> {
     }
 }
 /**
- * @generated MessageType for protobuf message parca.query.v1alpha1.QueryRangeResponse
+ * @generated MessageType for protobuf message parca.query.v1alpha1.CallgraphNeighborhood
  */
-export const QueryRangeResponse = new QueryRangeResponse$Type();
+export const CallgraphNeighborhood = new CallgraphNeighborhood$Type();
 // @generated message type with reflection information, may provide speed optimized methods
-class MetricsSeries$Type extends MessageType<MetricsSeries> {
+class CallgraphNeighborhoodNode$Type extends MessageType<CallgraphNeighborhoodNode> {
     constructor() {
-        super("parca.query.v1alpha1.MetricsSeries", [
-            { no: 1, name: "labelset", kind: "message", T: () => LabelSet },
-            { no: 2, name: "samples", kind: "message", repeat: 1 /*RepeatType.PACKED*/, T: () => MetricsSample },
-            { no: 3, name: "period_type", kind: "message", T: () => ValueType },
-            { no: 4, name: "sample_type", kind: "message", T: () => ValueType }
+        super("parca.query.v1alpha1.CallgraphNeighborhoodNode", [
+            { no: 1, name: "function", kind: "message", T: () => Function },
+            { no: 2, name: "mapping", kind: "message", T: () => Mapping },
+            { no: 3, name: "address", kind: "scalar", T: 4 /*ScalarType.UINT64*/ },
+            { no: 4, name: "cumulative", kind: "scalar", T: 3 /*ScalarType.INT64*/ },
+            { no: 5, name: "flat", kind: "scalar", T: 3 /*ScalarType.INT64*/ },
+            { no: 6, name: "diff", kind: "scalar", T: 3 /*ScalarType.INT64*/ },
+            { no: 7, name: "children", kind: "message", repeat: 1 /*RepeatType.PACKED*/, T: () => CallgraphNeighborhoodNode }
         ]);
     }
-    create(value?: PartialMessage<MetricsSeries>): MetricsSeries {
-        const message = { samples: [] };
+    create(value?: PartialMessage<CallgraphNeighborhoodNode>): CallgraphNeighborhoodNode {
+        const message = { address: "0", cumulative: "0", flat: "0", diff: "0", children: [] };
         globalThis.Object.defineProperty(message, MESSAGE_TYPE, { enumerable: false, value: this });
         if (value !== undefined)
-            reflectionMergePartial<MetricsSeries>(this, message, value);
+            reflectionMergePartial<CallgraphNeighborhoodNode>(this, message, value);
         return message;
     }
-    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: MetricsSeries): MetricsSeries {
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: CallgraphNeighborhoodNode): CallgraphNeighborhoodNode {
         let message = target ?? this.create(), end = reader.pos + length;
         while (reader.pos < end) {
             let [fieldNo, wireType] = reader.tag();
             switch (fieldNo) {
-                case /* parca.profilestore.v1alpha1.LabelSet labelset */ 1:
-                    message.labelset = LabelSet.internalBinaryRead(reader, reader.uint32(), options, message.labelset);
+                case /* parca.metastore.v1alpha1.Function function */ 1:
+                    message.function = Function.internalBinaryRead(reader, reader.uint32(), options, message.function);
                     break;
-                case /* repeated parca.query.v1alpha1.MetricsSample samples */ 2:
-                    message.samples.push(MetricsSample.internalBinaryRead(reader, reader.uint32(), options));
+                case /* parca.metastore.v1alpha1.Mapping mapping */ 2:
+                    message.mapping = Mapping.internalBinaryRead(reader, reader.uint32(), options, message.mapping);
                     break;
-                case /* parca.query.v1alpha1.ValueType period_type */ 3:
-                    message.periodType = ValueType.internalBinaryRead(reader, reader.uint32(), options, message.periodType);
+                case /* uint64 address */ 3:
+                    message.address = reader.uint64().toString();
                     break;
-                case /* parca.query.v1alpha1.ValueType sample_type */ 4:
-                    message.sampleType = ValueType.internalBinaryRead(reader, reader.uint32(), options, message.sampleType);
+                case /* int64 cumulative */ 4:
+                    message.cumulative = reader.int64().toString();
+                    break;
+                case /* int64 flat */ 5:
+                    message.flat = reader.int64().toString();
+                    break;
+                case /* int64 diff */ 6:
+                    message.diff = reader.int64().toString();
+                    break;
+                case /* repeated parca.query.v1alpha1.CallgraphNeighborhoodNode children */ 7:
+                    message.children.push(CallgraphNeighborhoodNode.internalBinaryRead(reader, reader.uint32(), options));
                     break;
                 default:
                     let u = options.readUnknownField;
@@ -1099,19 +2937,28 @@ class MetricsSeries$Type extends MessageType<MetricsSeries> {
         }
         return message;
     }
-    internalBinaryWrite(message: MetricsSeries, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
-        /* parca.profilestore.v1alpha1.LabelSet labelset = 1; */
-        if (message.labelset)
-            LabelSet.internalBinaryWrite(message.labelset, writer.tag(1, WireType.LengthDelimited).fork(), options).join();
-        /* repeated parca.query.v1alpha1.MetricsSample samples = 2; */
-        for (let i = 0; i < message.samples.length; i++)
-            MetricsSample.internalBinaryWrite(message.samples[i], writer.tag(2, WireType.LengthDelimited).fork(), options).join();
-        /* parca.query.v1alpha1.ValueType period_type = 3; */
-        if (message.periodType)
-            ValueType.internalBinaryWrite(message.periodType, writer.tag(3, WireType.LengthDelimited).fork(), options).join();
-        /* parca.query.v1alpha1.ValueType sample_type = 4; */
-        if (message.sampleType)
-            ValueType.internalBinaryWrite(message.sampleType, writer.tag(4, WireType.LengthDelimited).fork(), options).join();
+    internalBinaryWrite(message: CallgraphNeighborhoodNode, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* parca.metastore.v1alpha1.Function function = 1; */
+        if (message.function)
+            Function.internalBinaryWrite(message.function, writer.tag(1, WireType.LengthDelimited).fork(), options).join();
+        /* parca.metastore.v1alpha1.Mapping mapping = 2; */
+        if (message.mapping)
+            Mapping.internalBinaryWrite(message.mapping, writer.tag(2, WireType.LengthDelimited).fork(), options).join();
+        /* uint64 address = 3; */
+        if (message.address !== "0")
+            writer.tag(3, WireType.Varint).uint64(message.address);
+        /* int64 cumulative = 4; */
+        if (message.cumulative !== "0")
+            writer.tag(4, WireType.Varint).int64(message.cumulative);
+        /* int64 flat = 5; */
+        if (message.flat !== "0")
+            writer.tag(5, WireType.Varint).int64(message.flat);
+        /* int64 diff = 6; */
+        if (message.diff !== "0")
+            writer.tag(6, WireType.Varint).int64(message.diff);
+        /* repeated parca.query.v1alpha1.CallgraphNeighborhoodNode children = 7; */
+        for (let i = 0; i < message.children.length; i++)
+            CallgraphNeighborhoodNode.internalBinaryWrite(message.children[i], writer.tag(7, WireType.LengthDelimited).fork(), options).join();
         let u = options.writeUnknownFields;
         if (u !== false)
             (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
@@ -1119,34 +2966,54 @@ class MetricsSeries$Type extends MessageType<MetricsSeries> {
     }
 }
 /**
- * @generated MessageType for protobuf message parca.query.v1alpha1.MetricsSeries
+ * @generated MessageType for protobuf message parca.query.v1alpha1.CallgraphNeighborhoodNode
  */
-export const MetricsSeries = new MetricsSeries$Type();
+export const CallgraphNeighborhoodNode = new CallgraphNeighborhoodNode$Type();
 // @generated message type with reflection information, may provide speed optimized methods
-class MetricsSample$Type extends MessageType<MetricsSample> {
+class Graph$Type extends MessageType<Graph> {
     constructor() {
-        super("parca.query.v1alpha1.MetricsSample", [
-            { no: 1, name: "timestamp", kind: "message", T: () => Timestamp },
-            { no: 2, name: "value", kind: "scalar", T: 3 /*ScalarType.INT64*/ }
+        super("parca.query.v1alpha1.Graph", [
+            { no: 1, name: "nodes", kind: "message", repeat: 1 /*RepeatType.PACKED*/, T: () => GraphNode },
+            { no: 2, name: "edges", kind: "message", repeat: 1 /*RepeatType.PACKED*/, T: () => GraphEdge },
+            { no: 3, name: "total", kind: "scalar", T: 3 /*ScalarType.INT64*/ },
+            { no: 4, name: "unit", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 5, name: "dropped_nodes", kind: "scalar", T: 5 /*ScalarType.INT32*/ },
+            { no: 6, name: "dropped_edges", kind: "scalar", T: 5 /*ScalarType.INT32*/ },
+            { no: 7, name: "dot", kind: "scalar", T: 9 /*ScalarType.STRING*/ }
         ]);
     }
-    create(value?: PartialMessage<MetricsSample>): MetricsSample {
-        const message = { value: "0" };
+    create(value?: PartialMessage<Graph>): Graph {
+        const message = { nodes: [], edges: [], total: "0", unit: "", droppedNodes: 0, droppedEdges: 0, dot: "" };
         globalThis.Object.defineProperty(message, MESSAGE_TYPE, { enumerable: false, value: this });
         if (value !== undefined)
-            reflectionMergePartial<MetricsSample>(this, message, value);
+            reflectionMergePartial<Graph>(this, message, value);
         return message;
     }
-    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: MetricsSample): MetricsSample {
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: Graph): Graph {
         let message = target ?? this.create(), end = reader.pos + length;
         while (reader.pos < end) {
             let [fieldNo, wireType] = reader.tag();
             switch (fieldNo) {
-                case /* google.protobuf.Timestamp timestamp */ 1:
-                    message.timestamp = Timestamp.internalBinaryRead(reader, reader.uint32(), options, message.timestamp);
+                case /* repeated parca.query.v1alpha1.GraphNode nodes */ 1:
+                    message.nodes.push(GraphNode.internalBinaryRead(reader, reader.uint32(), options));
                     break;
-                case /* int64 value */ 2:
-                    message.value = reader.int64().toString();
+                case /* repeated parca.query.v1alpha1.GraphEdge edges */ 2:
+                    message.edges.push(GraphEdge.internalBinaryRead(reader, reader.uint32(), options));
+                    break;
+                case /* int64 total */ 3:
+                    message.total = reader.int64().toString();
+                    break;
+                case /* string unit */ 4:
+                    message.unit = reader.string();
+                    break;
+                case /* int32 dropped_nodes */ 5:
+                    message.droppedNodes = reader.int32();
+                    break;
+                case /* int32 dropped_edges */ 6:
+                    message.droppedEdges = reader.int32();
+                    break;
+                case /* string dot */ 7:
+                    message.dot = reader.string();
                     break;
                 default:
                     let u = options.readUnknownField;
@@ -1159,13 +3026,28 @@ class MetricsSample$Type extends MessageType<MetricsSample> {
         }
         return message;
     }
-    internalBinaryWrite(message: MetricsSample, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
-        /* google.protobuf.Timestamp timestamp = 1; */
-        if (message.timestamp)
-            Timestamp.internalBinaryWrite(message.timestamp, writer.tag(1, WireType.LengthDelimited).fork(), options).join();
-        /* int64 value = 2; */
-        if (message.value !== "0")
-            writer.tag(2, WireType.Varint).int64(message.value);
+    internalBinaryWrite(message: Graph, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* repeated parca.query.v1alpha1.GraphNode nodes = 1; */
+        for (let i = 0; i < message.nodes.length; i++)
+            GraphNode.internalBinaryWrite(message.nodes[i], writer.tag(1, WireType.LengthDelimited).fork(), options).join();
+        /* repeated parca.query.v1alpha1.GraphEdge edges = 2; */
+        for (let i = 0; i < message.edges.length; i++)
+            GraphEdge.internalBinaryWrite(message.edges[i], writer.tag(2, WireType.LengthDelimited).fork(), options).join();
+        /* int64 total = 3; */
+        if (message.total !== "0")
+            writer.tag(3, WireType.Varint).int64(message.total);
+        /* string unit = 4; */
+        if (message.unit !== "")
+            writer.tag(4, WireType.LengthDelimited).string(message.unit);
+        /* int32 dropped_nodes = 5; */
+        if (message.droppedNodes !== 0)
+            writer.tag(5, WireType.Varint).int32(message.droppedNodes);
+        /* int32 dropped_edges = 6; */
+        if (message.droppedEdges !== 0)
+            writer.tag(6, WireType.Varint).int32(message.droppedEdges);
+        /* string dot = 7; */
+        if (message.dot !== "")
+            writer.tag(7, WireType.LengthDelimited).string(message.dot);
         let u = options.writeUnknownFields;
         if (u !== false)
             (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
@@ -1173,38 +3055,50 @@ class MetricsSample$Type extends MessageType<MetricsSample> {
     }
 }
 /**
- * @generated MessageType for protobuf message parca.query.v1alpha1.MetricsSample
+ * @generated MessageType for protobuf message parca.query.v1alpha1.Graph
  */
-export const MetricsSample = new MetricsSample$Type();
+export const Graph = new Graph$Type();
 // @generated message type with reflection information, may provide speed optimized methods
-class MergeProfile$Type extends MessageType<MergeProfile> {
+class GraphNode$Type extends MessageType<GraphNode> {
     constructor() {
-        super("parca.query.v1alpha1.MergeProfile", [
-            { no: 1, name: "query", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
-            { no: 2, name: "start", kind: "message", T: () => Timestamp },
-            { no: 3, name: "end", kind: "message", T: () => Timestamp }
+        super("parca.query.v1alpha1.GraphNode", [
+            { no: 1, name: "function", kind: "message", T: () => Function },
+            { no: 2, name: "mapping", kind: "message", T: () => Mapping },
+            { no: 3, name: "address", kind: "scalar", T: 4 /*ScalarType.UINT64*/ },
+            { no: 4, name: "cumulative", kind: "scalar", T: 3 /*ScalarType.INT64*/ },
+            { no: 5, name: "flat", kind: "scalar", T: 3 /*ScalarType.INT64*/ },
+            { no: 6, name: "diff", kind: "scalar", T: 3 /*ScalarType.INT64*/ }
         ]);
     }
-    create(value?: PartialMessage<MergeProfile>): MergeProfile {
-        const message = { query: "" };
+    create(value?: PartialMessage<GraphNode>): GraphNode {
+        const message = { address: "0", cumulative: "0", flat: "0", diff: "0" };
         globalThis.Object.defineProperty(message, MESSAGE_TYPE, { enumerable: false, value: this });
         if (value !== undefined)
-            reflectionMergePartial<MergeProfile>(this, message, value);
+            reflectionMergePartial<GraphNode>(this, message, value);
         return message;
     }
-    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: MergeProfile): MergeProfile {
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: GraphNode): GraphNode {
         let message = target ?? this.create(), end = reader.pos + length;
         while (reader.pos < end) {
             let [fieldNo, wireType] = reader.tag();
             switch (fieldNo) {
-                case /* string query */ 1:
-                    message.query = reader.string();
+                case /* parca.metastore.v1alpha1.Function function */ 1:
+                    message.function = Function.internalBinaryRead(reader, reader.uint32(), options, message.function);
                     break;
-                case /* google.protobuf.Timestamp start */ 2:
-                    message.start = Timestamp.internalBinaryRead(reader, reader.uint32(), options, message.start);
+                case /* parca.metastore.v1alpha1.Mapping mapping */ 2:
+                    message.mapping = Mapping.internalBinaryRead(reader, reader.uint32(), options, message.mapping);
                     break;
-                case /* google.protobuf.Timestamp end */ 3:
-                    message.end = Timestamp.internalBinaryRead(reader, reader.uint32(), options, message.end);
+                case /* uint64 address */ 3:
+                    message.address = reader.uint64().toString();
+                    break;
+                case /* int64 cumulative */ 4:
+                    message.cumulative = reader.int64().toString();
+                    break;
+                case /* int64 flat */ 5:
+                    message.flat = reader.int64().toString();
+                    break;
+                case /* int64 diff */ 6:
+                    message.diff = reader.int64().toString();
                     break;
                 default:
                     let u = options.readUnknownField;
@@ -1217,16 +3111,25 @@ class MergeProfile$Type extends MessageType<MergeProfile> {
         }
         return message;
     }
-    internalBinaryWrite(message: MergeProfile, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
-        /* string query = 1; */
-        if (message.query !== "")
-            writer.tag(1, WireType.LengthDelimited).string(message.query);
-        /* google.protobuf.Timestamp start = 2; */
-        if (message.start)
-            Timestamp.internalBinaryWrite(message.start, writer.tag(2, WireType.LengthDelimited).fork(), options).join();
-        /* google.protobuf.Timestamp end = 3; */
-        if (message.end)
-            Timestamp.internalBinaryWrite(message.end, writer.tag(3, WireType.LengthDelimited).fork(), options).join();
+    internalBinaryWrite(message: GraphNode, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* parca.metastore.v1alpha1.Function function = 1; */
+        if (message.function)
+            Function.internalBinaryWrite(message.function, writer.tag(1, WireType.LengthDelimited).fork(), options).join();
+        /* parca.metastore.v1alpha1.Mapping mapping = 2; */
+        if (message.mapping)
+            Mapping.internalBinaryWrite(message.mapping, writer.tag(2, WireType.LengthDelimited).fork(), options).join();
+        /* uint64 address = 3; */
+        if (message.address !== "0")
+            writer.tag(3, WireType.Varint).uint64(message.address);
+        /* int64 cumulative = 4; */
+        if (message.cumulative !== "0")
+            writer.tag(4, WireType.Varint).int64(message.cumulative);
+        /* int64 flat = 5; */
+        if (message.flat !== "0")
+            writer.tag(5, WireType.Varint).int64(message.flat);
+        /* int64 diff = 6; */
+        if (message.diff !== "0")
+            writer.tag(6, WireType.Varint).int64(message.diff);
         let u = options.writeUnknownFields;
         if (u !== false)
             (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
@@ -1234,34 +3137,46 @@ class MergeProfile$Type extends MessageType<MergeProfile> {
     }
 }
 /**
- * @generated MessageType for protobuf message parca.query.v1alpha1.MergeProfile
+ * @generated MessageType for protobuf message parca.query.v1alpha1.GraphNode
  */
-export const MergeProfile = new MergeProfile$Type();
+export const GraphNode = new GraphNode$Type();
 // @generated message type with reflection information, may provide speed optimized methods
-class SingleProfile$Type extends MessageType<SingleProfile> {
+class GraphEdge$Type extends MessageType<GraphEdge> {
     constructor() {
-        super("parca.query.v1alpha1.SingleProfile", [
-            { no: 1, name: "time", kind: "message", T: () => Timestamp },
-            { no: 2, name: "query", kind: "scalar", T: 9 /*ScalarType.STRING*/ }
+        super("parca.query.v1alpha1.GraphEdge", [
+            { no: 1, name: "source", kind: "scalar", T: 13 /*ScalarType.UINT32*/ },
+            { no: 2, name: "target", kind: "scalar", T: 13 /*ScalarType.UINT32*/ },
+            { no: 3, name: "weight", kind: "scalar", T: 3 /*ScalarType.INT64*/ },
+            { no: 4, name: "residual", kind: "scalar", T: 8 /*ScalarType.BOOL*/ },
+            { no: 5, name: "inline", kind: "scalar", T: 8 /*ScalarType.BOOL*/ }
         ]);
     }
-    create(value?: PartialMessage<SingleProfile>): SingleProfile {
-        const message = { query: "" };
+    create(value?: PartialMessage<GraphEdge>): GraphEdge {
+        const message = { source: 0, target: 0, weight: "0", residual: false, inline: false };
         globalThis.Object.defineProperty(message, MESSAGE_TYPE, { enumerable: false, value: this });
         if (value !== undefined)
-            reflectionMergePartial<SingleProfile>(this, message, value);
+            reflectionMergePartial<GraphEdge>(this, message, value);
         return message;
     }
-    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: SingleProfile): SingleProfile {
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: GraphEdge): GraphEdge {
         let message = target ?? this.create(), end = reader.pos + length;
         while (reader.pos < end) {
             let [fieldNo, wireType] = reader.tag();
             switch (fieldNo) {
-                case /* google.protobuf.Timestamp time */ 1:
-                    message.time = Timestamp.internalBinaryRead(reader, reader.uint32(), options, message.time);
+                case /* uint32 source */ 1:
+                    message.source = reader.uint32();
                     break;
-                case /* string query */ 2:
-                    message.query = reader.string();
+                case /* uint32 target */ 2:
+                    message.target = reader.uint32();
+                    break;
+                case /* int64 weight */ 3:
+                    message.weight = reader.int64().toString();
+                    break;
+                case /* bool residual */ 4:
+                    message.residual = reader.bool();
+                    break;
+                case /* bool inline */ 5:
+                    message.inline = reader.bool();
                     break;
                 default:
                     let u = options.readUnknownField;
@@ -1274,13 +3189,22 @@ class SingleProfile$Type extends MessageType<SingleProfile> {
         }
         return message;
     }
-    internalBinaryWrite(message: SingleProfile, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
-        /* google.protobuf.Timestamp time = 1; */
-        if (message.time)
-            Timestamp.internalBinaryWrite(message.time, writer.tag(1, WireType.LengthDelimited).fork(), options).join();
-        /* string query = 2; */
-        if (message.query !== "")
-            writer.tag(2, WireType.LengthDelimited).string(message.query);
+    internalBinaryWrite(message: GraphEdge, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* uint32 source = 1; */
+        if (message.source !== 0)
+            writer.tag(1, WireType.Varint).uint32(message.source);
+        /* uint32 target = 2; */
+        if (message.target !== 0)
+            writer.tag(2, WireType.Varint).uint32(message.target);
+        /* int64 weight = 3; */
+        if (message.weight !== "0")
+            writer.tag(3, WireType.Varint).int64(message.weight);
+        /* bool residual = 4; */
+        if (message.residual !== false)
+            writer.tag(4, WireType.Varint).bool(message.residual);
+        /* bool inline = 5; */
+        if (message.inline !== false)
+            writer.tag(5, WireType.Varint).bool(message.inline);
         let u = options.writeUnknownFields;
         if (u !== false)
             (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
@@ -1288,34 +3212,38 @@ class SingleProfile$Type extends MessageType<SingleProfile> {
     }
 }
 /**
- * @generated MessageType for protobuf message parca.query.v1alpha1.SingleProfile
+ * @generated MessageType for protobuf message parca.query.v1alpha1.GraphEdge
  */
-export const SingleProfile = new SingleProfile$Type();
+export const GraphEdge = new GraphEdge$Type();
 // @generated message type with reflection information, may provide speed optimized methods
-class DiffProfile$Type extends MessageType<DiffProfile> {
+class Disassembly$Type extends MessageType<Disassembly> {
     constructor() {
-        super("parca.query.v1alpha1.DiffProfile", [
-            { no: 1, name: "a", kind: "message", T: () => ProfileDiffSelection },
-            { no: 2, name: "b", kind: "message", T: () => ProfileDiffSelection }
+        super("parca.query.v1alpha1.Disassembly", [
+            { no: 1, name: "functions", kind: "message", repeat: 1 /*RepeatType.PACKED*/, T: () => DisassemblyFunction },
+            { no: 2, name: "total", kind: "scalar", T: 3 /*ScalarType.INT64*/ },
+            { no: 3, name: "unit", kind: "scalar", T: 9 /*ScalarType.STRING*/ }
         ]);
     }
-    create(value?: PartialMessage<DiffProfile>): DiffProfile {
-        const message = {};
+    create(value?: PartialMessage<Disassembly>): Disassembly {
+        const message = { functions: [], total: "0", unit: "" };
         globalThis.Object.defineProperty(message, MESSAGE_TYPE, { enumerable: false, value: this });
         if (value !== undefined)
-            reflectionMergePartial<DiffProfile>(this, message, value);
+            reflectionMergePartial<Disassembly>(this, message, value);
         return message;
     }
-    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: DiffProfile): DiffProfile {
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: Disassembly): Disassembly {
         let message = target ?? this.create(), end = reader.pos + length;
         while (reader.pos < end) {
             let [fieldNo, wireType] = reader.tag();
             switch (fieldNo) {
-                case /* parca.query.v1alpha1.ProfileDiffSelection a */ 1:
-                    message.a = ProfileDiffSelection.internalBinaryRead(reader, reader.uint32(), options, message.a);
+                case /* repeated parca.query.v1alpha1.DisassemblyFunction functions */ 1:
+                    message.functions.push(DisassemblyFunction.internalBinaryRead(reader, reader.uint32(), options));
                     break;
-                case /* parca.query.v1alpha1.ProfileDiffSelection b */ 2:
-                    message.b = ProfileDiffSelection.internalBinaryRead(reader, reader.uint32(), options, message.b);
+                case /* int64 total */ 2:
+                    message.total = reader.int64().toString();
+                    break;
+                case /* string unit */ 3:
+                    message.unit = reader.string();
                     break;
                 default:
                     let u = options.readUnknownField;
@@ -1328,13 +3256,16 @@ class DiffProfile$Type extends MessageType<DiffProfile> {
         }
         return message;
     }
-    internalBinaryWrite(message: DiffProfile, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
-        /* parca.query.v1alpha1.ProfileDiffSelection a = 1; */
-        if (message.a)
-            ProfileDiffSelection.internalBinaryWrite(message.a, writer.tag(1, WireType.LengthDelimited).fork(), options).join();
-        /* parca.query.v1alpha1.ProfileDiffSelection b = 2; */
-        if (message.b)
-            ProfileDiffSelection.internalBinaryWrite(message.b, writer.tag(2, WireType.LengthDelimited).fork(), options).join();
+    internalBinaryWrite(message: Disassembly, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* repeated parca.query.v1alpha1.DisassemblyFunction functions = 1; */
+        for (let i = 0; i < message.functions.length; i++)
+            DisassemblyFunction.internalBinaryWrite(message.functions[i], writer.tag(1, WireType.LengthDelimited).fork(), options).join();
+        /* int64 total = 2; */
+        if (message.total !== "0")
+            writer.tag(2, WireType.Varint).int64(message.total);
+        /* string unit = 3; */
+        if (message.unit !== "")
+            writer.tag(3, WireType.LengthDelimited).string(message.unit);
         let u = options.writeUnknownFields;
         if (u !== false)
             (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
@@ -1342,44 +3273,38 @@ class DiffProfile$Type extends MessageType<DiffProfile> {
     }
 }
 /**
- * @generated MessageType for protobuf message parca.query.v1alpha1.DiffProfile
+ * @generated MessageType for protobuf message parca.query.v1alpha1.Disassembly
  */
-export const DiffProfile = new DiffProfile$Type();
+export const Disassembly = new Disassembly$Type();
 // @generated message type with reflection information, may provide speed optimized methods
-class ProfileDiffSelection$Type extends MessageType<ProfileDiffSelection> {
+class DisassemblyFunction$Type extends MessageType<DisassemblyFunction> {
     constructor() {
-        super("parca.query.v1alpha1.ProfileDiffSelection", [
-            { no: 1, name: "mode", kind: "enum", T: () => ["parca.query.v1alpha1.ProfileDiffSelection.Mode", ProfileDiffSelection_Mode, "MODE_"] },
-            { no: 2, name: "merge", kind: "message", oneof: "options", T: () => MergeProfile },
-            { no: 3, name: "single", kind: "message", oneof: "options", T: () => SingleProfile }
+        super("parca.query.v1alpha1.DisassemblyFunction", [
+            { no: 1, name: "function", kind: "message", T: () => Function },
+            { no: 2, name: "mapping", kind: "message", T: () => Mapping },
+            { no: 3, name: "instructions", kind: "message", repeat: 1 /*RepeatType.PACKED*/, T: () => DisassemblyInstruction }
         ]);
     }
-    create(value?: PartialMessage<ProfileDiffSelection>): ProfileDiffSelection {
-        const message = { mode: 0, options: { oneofKind: undefined } };
+    create(value?: PartialMessage<DisassemblyFunction>): DisassemblyFunction {
+        const message = { instructions: [] };
         globalThis.Object.defineProperty(message, MESSAGE_TYPE, { enumerable: false, value: this });
         if (value !== undefined)
-            reflectionMergePartial<ProfileDiffSelection>(this, message, value);
+            reflectionMergePartial<DisassemblyFunction>(this, message, value);
         return message;
     }
-    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: ProfileDiffSelection): ProfileDiffSelection {
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: DisassemblyFunction): DisassemblyFunction {
         let message = target ?? this.create(), end = reader.pos + length;
         while (reader.pos < end) {
             let [fieldNo, wireType] = reader.tag();
             switch (fieldNo) {
-                case /* parca.query.v1alpha1.ProfileDiffSelection.Mode mode */ 1:
-                    message.mode = reader.int32();
+                case /* parca.metastore.v1alpha1.Function function */ 1:
+                    message.function = Function.internalBinaryRead(reader, reader.uint32(), options, message.function);
                     break;
-                case /* parca.query.v1alpha1.MergeProfile merge */ 2:
-                    message.options = {
-                        oneofKind: "merge",
-                        merge: MergeProfile.internalBinaryRead(reader, reader.uint32(), options, (message.options as any).merge)
-                    };
+                case /* parca.metastore.v1alpha1.Mapping mapping */ 2:
+                    message.mapping = Mapping.internalBinaryRead(reader, reader.uint32(), options, message.mapping);
                     break;
-                case /* parca.query.v1alpha1.SingleProfile single */ 3:
-                    message.options = {
-                        oneofKind: "single",
-                        single: SingleProfile.internalBinaryRead(reader, reader.uint32(), options, (message.options as any).single)
-                    };
+                case /* repeated parca.query.v1alpha1.DisassemblyInstruction instructions */ 3:
+                    message.instructions.push(DisassemblyInstruction.internalBinaryRead(reader, reader.uint32(), options));
                     break;
                 default:
                     let u = options.readUnknownField;
@@ -1392,16 +3317,16 @@ class ProfileDiffSelection$Type extends MessageType<ProfileDiffSelection> {
         }
         return message;
     }
-    internalBinaryWrite(message: ProfileDiffSelection, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
-        /* parca.query.v1alpha1.ProfileDiffSelection.Mode mode = 1; */
-        if (message.mode !== 0)
-            writer.tag(1, WireType.Varint).int32(message.mode);
-        /* parca.query.v1alpha1.MergeProfile merge = 2; */
-        if (message.options.oneofKind === "merge")
-            MergeProfile.internalBinaryWrite(message.options.merge, writer.tag(2, WireType.LengthDelimited).fork(), options).join();
-        /* parca.query.v1alpha1.SingleProfile single = 3; */
-        if (message.options.oneofKind === "single")
-            SingleProfile.internalBinaryWrite(message.options.single, writer.tag(3, WireType.LengthDelimited).fork(), options).join();
+    internalBinaryWrite(message: DisassemblyFunction, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* parca.metastore.v1alpha1.Function function = 1; */
+        if (message.function)
+            Function.internalBinaryWrite(message.function, writer.tag(1, WireType.LengthDelimited).fork(), options).join();
+        /* parca.metastore.v1alpha1.Mapping mapping = 2; */
+        if (message.mapping)
+            Mapping.internalBinaryWrite(message.mapping, writer.tag(2, WireType.LengthDelimited).fork(), options).join();
+        /* repeated parca.query.v1alpha1.DisassemblyInstruction instructions = 3; */
+        for (let i = 0; i < message.instructions.length; i++)
+            DisassemblyInstruction.internalBinaryWrite(message.instructions[i], writer.tag(3, WireType.LengthDelimited).fork(), options).join();
         let u = options.writeUnknownFields;
         if (u !== false)
             (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
@@ -1409,55 +3334,46 @@ class ProfileDiffSelection$Type extends MessageType<ProfileDiffSelection> {
     }
 }
 /**
- * @generated MessageType for protobuf message parca.query.v1alpha1.ProfileDiffSelection
+ * @generated MessageType for protobuf message parca.query.v1alpha1.DisassemblyFunction
  */
-export const ProfileDiffSelection = new ProfileDiffSelection$Type();
+export const DisassemblyFunction = new DisassemblyFunction$Type();
 // @generated message type with reflection information, may provide speed optimized methods
-class QueryRequest$Type extends MessageType<QueryRequest> {
+class DisassemblyInstruction$Type extends MessageType<DisassemblyInstruction> {
     constructor() {
-        super("parca.query.v1alpha1.QueryRequest", [
-            { no: 1, name: "mode", kind: "enum", T: () => ["parca.query.v1alpha1.QueryRequest.Mode", QueryRequest_Mode, "MODE_"] },
-            { no: 2, name: "diff", kind: "message", oneof: "options", T: () => DiffProfile },
-            { no: 3, name: "merge", kind: "message", oneof: "options", T: () => MergeProfile },
-            { no: 4, name: "single", kind: "message", oneof: "options", T: () => SingleProfile },
-            { no: 5, name: "report_type", kind: "enum", T: () => ["parca.query.v1alpha1.QueryRequest.ReportType", QueryRequest_ReportType, "REPORT_TYPE_"] }
+        super("parca.query.v1alpha1.DisassemblyInstruction", [
+            { no: 1, name: "address", kind: "scalar", T: 4 /*ScalarType.UINT64*/ },
+            { no: 2, name: "text", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 3, name: "flat", kind: "scalar", T: 3 /*ScalarType.INT64*/ },
+            { no: 4, name: "cumulative", kind: "scalar", T: 3 /*ScalarType.INT64*/ },
+            { no: 5, name: "diff", kind: "scalar", T: 3 /*ScalarType.INT64*/ }
         ]);
     }
-    create(value?: PartialMessage<QueryRequest>): QueryRequest {
-        const message = { mode: 0, options: { oneofKind: undefined }, reportType: 0 };
+    create(value?: PartialMessage<DisassemblyInstruction>): DisassemblyInstruction {
+        const message = { address: "0", text: "", flat: "0", cumulative: "0", diff: "0" };
         globalThis.Object.defineProperty(message, MESSAGE_TYPE, { enumerable: false, value: this });
         if (value !== undefined)
-            reflectionMergePartial<QueryRequest>(this, message, value);
+            reflectionMergePartial<DisassemblyInstruction>(this, message, value);
         return message;
     }
-    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: QueryRequest): QueryRequest {
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: DisassemblyInstruction): DisassemblyInstruction {
         let message = target ?? this.create(), end = reader.pos + length;
         while (reader.pos < end) {
             let [fieldNo, wireType] = reader.tag();
             switch (fieldNo) {
-                case /* parca.query.v1alpha1.QueryRequest.Mode mode */ 1:
-                    message.mode = reader.int32();
+                case /* uint64 address */ 1:
+                    message.address = reader.uint64().toString();
                     break;
-                case /* parca.query.v1alpha1.DiffProfile diff */ 2:
-                    message.options = {
-                        oneofKind: "diff",
-                        diff: DiffProfile.internalBinaryRead(reader, reader.uint32(), options, (message.options as any).diff)
-                    };
+                case /* string text */ 2:
+                    message.text = reader.string();
                     break;
-                case /* parca.query.v1alpha1.MergeProfile merge */ 3:
-                    message.options = {
-                        oneofKind: "merge",
-                        merge: MergeProfile.internalBinaryRead(reader, reader.uint32(), options, (message.options as any).merge)
-                    };
+                case /* int64 flat */ 3:
+                    message.flat = reader.int64().toString();
                     break;
-                case /* parca.query.v1alpha1.SingleProfile single */ 4:
-                    message.options = {
-                        oneofKind: "single",
-                        single: SingleProfile.internalBinaryRead(reader, reader.uint32(), options, (message.options as any).single)
-                    };
+                case /* int64 cumulative */ 4:
+                    message.cumulative = reader.int64().toString();
                     break;
-                case /* parca.query.v1alpha1.QueryRequest.ReportType report_type */ 5:
-                    message.reportType = reader.int32();
+                case /* int64 diff */ 5:
+                    message.diff = reader.int64().toString();
                     break;
                 default:
                     let u = options.readUnknownField;
@@ -1470,22 +3386,22 @@ class QueryRequest$Type extends MessageType<QueryRequest> {
         }
         return message;
     }
-    internalBinaryWrite(message: QueryRequest, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
-        /* parca.query.v1alpha1.QueryRequest.Mode mode = 1; */
-        if (message.mode !== 0)
-            writer.tag(1, WireType.Varint).int32(message.mode);
-        /* parca.query.v1alpha1.DiffProfile diff = 2; */
-        if (message.options.oneofKind === "diff")
-            DiffProfile.internalBinaryWrite(message.options.diff, writer.tag(2, WireType.LengthDelimited).fork(), options).join();
-        /* parca.query.v1alpha1.MergeProfile merge = 3; */
-        if (message.options.oneofKind === "merge")
-            MergeProfile.internalBinaryWrite(message.options.merge, writer.tag(3, WireType.LengthDelimited).fork(), options).join();
-        /* parca.query.v1alpha1.SingleProfile single = 4; */
-        if (message.options.oneofKind === "single")
-            SingleProfile.internalBinaryWrite(message.options.single, writer.tag(4, WireType.LengthDelimited).fork(), options).join();
-        /* parca.query.v1alpha1.QueryRequest.ReportType report_type = 5; */
-        if (message.reportType !== 0)
-            writer.tag(5, WireType.Varint).int32(message.reportType);
+    internalBinaryWrite(message: DisassemblyInstruction, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* uint64 address = 1; */
+        if (message.address !== "0")
+            writer.tag(1, WireType.Varint).uint64(message.address);
+        /* string text = 2; */
+        if (message.text !== "")
+            writer.tag(2, WireType.LengthDelimited).string(message.text);
+        /* int64 flat = 3; */
+        if (message.flat !== "0")
+            writer.tag(3, WireType.Varint).int64(message.flat);
+        /* int64 cumulative = 4; */
+        if (message.cumulative !== "0")
+            writer.tag(4, WireType.Varint).int64(message.cumulative);
+        /* int64 diff = 5; */
+        if (message.diff !== "0")
+            writer.tag(5, WireType.Varint).int64(message.diff);
         let u = options.writeUnknownFields;
         if (u !== false)
             (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
@@ -1493,41 +3409,37 @@ class QueryRequest$Type extends MessageType<QueryRequest> {
     }
 }
 /**
- * @generated MessageType for protobuf message parca.query.v1alpha1.QueryRequest
+ * @generated MessageType for protobuf message parca.query.v1alpha1.DisassemblyInstruction
  */
-export const QueryRequest = new QueryRequest$Type();
+export const DisassemblyInstruction = new DisassemblyInstruction$Type();
 // @generated message type with reflection information, may provide speed optimized methods
-class Top$Type extends MessageType<Top> {
+class Source$Type extends MessageType<Source> {
     constructor() {
-        super("parca.query.v1alpha1.Top", [
-            { no: 1, name: "list", kind: "message", repeat: 1 /*RepeatType.PACKED*/, T: () => TopNode },
-            { no: 2, name: "reported", kind: "scalar", T: 5 /*ScalarType.INT32*/ },
-            { no: 3, name: "total", kind: "scalar", T: 5 /*ScalarType.INT32*/ },
-            { no: 4, name: "unit", kind: "scalar", T: 9 /*ScalarType.STRING*/ }
+        super("parca.query.v1alpha1.Source", [
+            { no: 1, name: "files", kind: "message", repeat: 1 /*RepeatType.PACKED*/, T: () => SourceFile },
+            { no: 2, name: "total", kind: "scalar", T: 3 /*ScalarType.INT64*/ },
+            { no: 3, name: "unit", kind: "scalar", T: 9 /*ScalarType.STRING*/ }
         ]);
     }
-    create(value?: PartialMessage<Top>): Top {
-        const message = { list: [], reported: 0, total: 0, unit: "" };
+    create(value?: PartialMessage<Source>): Source {
+        const message = { files: [], total: "0", unit: "" };
         globalThis.Object.defineProperty(message, MESSAGE_TYPE, { enumerable: false, value: this });
         if (value !== undefined)
-            reflectionMergePartial<Top>(this, message, value);
+            reflectionMergePartial<Source>(this, message, value);
         return message;
     }
-    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: Top): Top {
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: Source): Source {
         let message = target ?? this.create(), end = reader.pos + length;
         while (reader.pos < end) {
             let [fieldNo, wireType] = reader.tag();
             switch (fieldNo) {
-                case /* repeated parca.query.v1alpha1.TopNode list */ 1:
-                    message.list.push(TopNode.internalBinaryRead(reader, reader.uint32(), options));
+                case /* repeated parca.query.v1alpha1.SourceFile files */ 1:
+                    message.files.push(SourceFile.internalBinaryRead(reader, reader.uint32(), options));
                     break;
-                case /* int32 reported */ 2:
-                    message.reported = reader.int32();
-                    break;
-                case /* int32 total */ 3:
-                    message.total = reader.int32();
+                case /* int64 total */ 2:
+                    message.total = reader.int64().toString();
                     break;
-                case /* string unit */ 4:
+                case /* string unit */ 3:
                     message.unit = reader.string();
                     break;
                 default:
@@ -1541,19 +3453,16 @@ class Top$Type extends MessageType<Top> {
         }
         return message;
     }
-    internalBinaryWrite(message: Top, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
-        /* repeated parca.query.v1alpha1.TopNode list = 1; */
-        for (let i = 0; i < message.list.length; i++)
-            TopNode.internalBinaryWrite(message.list[i], writer.tag(1, WireType.LengthDelimited).fork(), options).join();
-        /* int32 reported = 2; */
-        if (message.reported !== 0)
-            writer.tag(2, WireType.Varint).int32(message.reported);
-        /* int32 total = 3; */
-        if (message.total !== 0)
-            writer.tag(3, WireType.Varint).int32(message.total);
-        /* string unit = 4; */
+    internalBinaryWrite(message: Source, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* repeated parca.query.v1alpha1.SourceFile files = 1; */
+        for (let i = 0; i < message.files.length; i++)
+            SourceFile.internalBinaryWrite(message.files[i], writer.tag(1, WireType.LengthDelimited).fork(), options).join();
+        /* int64 total = 2; */
+        if (message.total !== "0")
+            writer.tag(2, WireType.Varint).int64(message.total);
+        /* string unit = 3; */
         if (message.unit !== "")
-            writer.tag(4, WireType.LengthDelimited).string(message.unit);
+            writer.tag(3, WireType.LengthDelimited).string(message.unit);
         let u = options.writeUnknownFields;
         if (u !== false)
             (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
@@ -1561,42 +3470,42 @@ class Top$Type extends MessageType<Top> {
     }
 }
 /**
- * @generated MessageType for protobuf message parca.query.v1alpha1.Top
+ * @generated MessageType for protobuf message parca.query.v1alpha1.Source
  */
-export const Top = new Top$Type();
+export const Source = new Source$Type();
 // @generated message type with reflection information, may provide speed optimized methods
-class TopNode$Type extends MessageType<TopNode> {
+class SourceFile$Type extends MessageType<SourceFile> {
     constructor() {
-        super("parca.query.v1alpha1.TopNode", [
-            { no: 1, name: "meta", kind: "message", T: () => TopNodeMeta },
-            { no: 2, name: "cumulative", kind: "scalar", T: 3 /*ScalarType.INT64*/ },
-            { no: 3, name: "flat", kind: "scalar", T: 3 /*ScalarType.INT64*/ },
-            { no: 4, name: "diff", kind: "scalar", T: 3 /*ScalarType.INT64*/ }
+        super("parca.query.v1alpha1.SourceFile", [
+            { no: 1, name: "filename", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 2, name: "build_id", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 3, name: "lines", kind: "message", repeat: 1 /*RepeatType.PACKED*/, T: () => SourceLine },
+            { no: 4, name: "source", kind: "scalar", T: 9 /*ScalarType.STRING*/ }
         ]);
     }
-    create(value?: PartialMessage<TopNode>): TopNode {
-        const message = { cumulative: "0", flat: "0", diff: "0" };
+    create(value?: PartialMessage<SourceFile>): SourceFile {
+        const message = { filename: "", buildId: "", lines: [], source: "" };
         globalThis.Object.defineProperty(message, MESSAGE_TYPE, { enumerable: false, value: this });
         if (value !== undefined)
-            reflectionMergePartial<TopNode>(this, message, value);
+            reflectionMergePartial<SourceFile>(this, message, value);
         return message;
     }
-    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: TopNode): TopNode {
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: SourceFile): SourceFile {
         let message = target ?? this.create(), end = reader.pos + length;
         while (reader.pos < end) {
             let [fieldNo, wireType] = reader.tag();
             switch (fieldNo) {
-                case /* parca.query.v1alpha1.TopNodeMeta meta */ 1:
-                    message.meta = TopNodeMeta.internalBinaryRead(reader, reader.uint32(), options, message.meta);
+                case /* string filename */ 1:
+                    message.filename = reader.string();
                     break;
-                case /* int64 cumulative */ 2:
-                    message.cumulative = reader.int64().toString();
+                case /* string build_id */ 2:
+                    message.buildId = reader.string();
                     break;
-                case /* int64 flat */ 3:
-                    message.flat = reader.int64().toString();
+                case /* repeated parca.query.v1alpha1.SourceLine lines */ 3:
+                    message.lines.push(SourceLine.internalBinaryRead(reader, reader.uint32(), options));
                     break;
-                case /* int64 diff */ 4:
-                    message.diff = reader.int64().toString();
+                case /* string source */ 4:
+                    message.source = reader.string();
                     break;
                 default:
                     let u = options.readUnknownField;
@@ -1609,19 +3518,19 @@ class TopNode$Type extends MessageType<TopNode> {
         }
         return message;
     }
-    internalBinaryWrite(message: TopNode, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
-        /* parca.query.v1alpha1.TopNodeMeta meta = 1; */
-        if (message.meta)
-            TopNodeMeta.internalBinaryWrite(message.meta, writer.tag(1, WireType.LengthDelimited).fork(), options).join();
-        /* int64 cumulative = 2; */
-        if (message.cumulative !== "0")
-            writer.tag(2, WireType.Varint).int64(message.cumulative);
-        /* int64 flat = 3; */
-        if (message.flat !== "0")
-            writer.tag(3, WireType.Varint).int64(message.flat);
-        /* int64 diff = 4; */
-        if (message.diff !== "0")
-            writer.tag(4, WireType.Varint).int64(message.diff);
+    internalBinaryWrite(message: SourceFile, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* string filename = 1; */
+        if (message.filename !== "")
+            writer.tag(1, WireType.LengthDelimited).string(message.filename);
+        /* string build_id = 2; */
+        if (message.buildId !== "")
+            writer.tag(2, WireType.LengthDelimited).string(message.buildId);
+        /* repeated parca.query.v1alpha1.SourceLine lines = 3; */
+        for (let i = 0; i < message.lines.length; i++)
+            SourceLine.internalBinaryWrite(message.lines[i], writer.tag(3, WireType.LengthDelimited).fork(), options).join();
+        /* string source = 4; */
+        if (message.source !== "")
+            writer.tag(4, WireType.LengthDelimited).string(message.source);
         let u = options.writeUnknownFields;
         if (u !== false)
             (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
@@ -1629,42 +3538,42 @@ class TopNode$Type extends MessageType<TopNode> {
     }
 }
 /**
- * @generated MessageType for protobuf message parca.query.v1alpha1.TopNode
+ * @generated MessageType for protobuf message parca.query.v1alpha1.SourceFile
  */
-export const TopNode = new TopNode$Type();
+export const SourceFile = new SourceFile$Type();
 // @generated message type with reflection information, may provide speed optimized methods
-class TopNodeMeta$Type extends MessageType<TopNodeMeta> {
+class SourceLine$Type extends MessageType<SourceLine> {
     constructor() {
-        super("parca.query.v1alpha1.TopNodeMeta", [
-            { no: 1, name: "location", kind: "message", T: () => Location },
-            { no: 2, name: "mapping", kind: "message", T: () => Mapping },
-            { no: 3, name: "function", kind: "message", T: () => Function },
-            { no: 4, name: "line", kind: "message", T: () => Line }
+        super("parca.query.v1alpha1.SourceLine", [
+            { no: 1, name: "line", kind: "scalar", T: 3 /*ScalarType.INT64*/ },
+            { no: 2, name: "flat", kind: "scalar", T: 3 /*ScalarType.INT64*/ },
+            { no: 3, name: "cumulative", kind: "scalar", T: 3 /*ScalarType.INT64*/ },
+            { no: 4, name: "diff", kind: "scalar", T: 3 /*ScalarType.INT64*/ }
         ]);
     }
-    create(value?: PartialMessage<TopNodeMeta>): TopNodeMeta {
-        const message = {};
+    create(value?: PartialMessage<SourceLine>): SourceLine {
+        const message = { line: "0", flat: "0", cumulative: "0", diff: "0" };
         globalThis.Object.defineProperty(message, MESSAGE_TYPE, { enumerable: false, value: this });
         if (value !== undefined)
-            reflectionMergePartial<TopNodeMeta>(this, message, value);
+            reflectionMergePartial<SourceLine>(this, message, value);
         return message;
     }
-    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: TopNodeMeta): TopNodeMeta {
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: SourceLine): SourceLine {
         let message = target ?? this.create(), end = reader.pos + length;
         while (reader.pos < end) {
             let [fieldNo, wireType] = reader.tag();
             switch (fieldNo) {
-                case /* parca.metastore.v1alpha1.Location location */ 1:
-                    message.location = Location.internalBinaryRead(reader, reader.uint32(), options, message.location);
+                case /* int64 line */ 1:
+                    message.line = reader.int64().toString();
                     break;
-                case /* parca.metastore.v1alpha1.Mapping mapping */ 2:
-                    message.mapping = Mapping.internalBinaryRead(reader, reader.uint32(), options, message.mapping);
+                case /* int64 flat */ 2:
+                    message.flat = reader.int64().toString();
                     break;
-                case /* parca.metastore.v1alpha1.Function function */ 3:
-                    message.function = Function.internalBinaryRead(reader, reader.uint32(), options, message.function);
+                case /* int64 cumulative */ 3:
+                    message.cumulative = reader.int64().toString();
                     break;
-                case /* parca.metastore.v1alpha1.Line line */ 4:
-                    message.line = Line.internalBinaryRead(reader, reader.uint32(), options, message.line);
+                case /* int64 diff */ 4:
+                    message.diff = reader.int64().toString();
                     break;
                 default:
                     let u = options.readUnknownField;
@@ -1677,19 +3586,19 @@ class TopNodeMeta$Type extends MessageType<TopNodeMeta> {
         }
         return message;
     }
-    internalBinaryWrite(message: TopNodeMeta, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
-        /* parca.metastore.v1alpha1.Location location = 1; */
-        if (message.location)
-            Location.internalBinaryWrite(message.location, writer.tag(1, WireType.LengthDelimited).fork(), options).join();
-        /* parca.metastore.v1alpha1.Mapping mapping = 2; */
-        if (message.mapping)
-            Mapping.internalBinaryWrite(message.mapping, writer.tag(2, WireType.LengthDelimited).fork(), options).join();
-        /* parca.metastore.v1alpha1.Function function = 3; */
-        if (message.function)
-            Function.internalBinaryWrite(message.function, writer.tag(3, WireType.LengthDelimited).fork(), options).join();
-        /* parca.metastore.v1alpha1.Line line = 4; */
-        if (message.line)
-            Line.internalBinaryWrite(message.line, writer.tag(4, WireType.LengthDelimited).fork(), options).join();
+    internalBinaryWrite(message: SourceLine, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* int64 line = 1; */
+        if (message.line !== "0")
+            writer.tag(1, WireType.Varint).int64(message.line);
+        /* int64 flat = 2; */
+        if (message.flat !== "0")
+            writer.tag(2, WireType.Varint).int64(message.flat);
+        /* int64 cumulative = 3; */
+        if (message.cumulative !== "0")
+            writer.tag(3, WireType.Varint).int64(message.cumulative);
+        /* int64 diff = 4; */
+        if (message.diff !== "0")
+            writer.tag(4, WireType.Varint).int64(message.diff);
         let u = options.writeUnknownFields;
         if (u !== false)
             (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
@@ -1697,9 +3606,9 @@ class TopNodeMeta$Type extends MessageType<TopNodeMeta> {
     }
 }
 /**
- * @generated MessageType for protobuf message parca.query.v1alpha1.TopNodeMeta
+ * @generated MessageType for protobuf message parca.query.v1alpha1.SourceLine
  */
-export const TopNodeMeta = new TopNodeMeta$Type();
+export const SourceLine = new SourceLine$Type();
 // @generated message type with reflection information, may provide speed optimized methods
 class Flamegraph$Type extends MessageType<Flamegraph> {
     constructor() {
@@ -1971,7 +3880,12 @@ class QueryResponse$Type extends MessageType<QueryResponse> {
         super("parca.query.v1alpha1.QueryResponse", [
             { no: 5, name: "flamegraph", kind: "message", oneof: "report", T: () => Flamegraph },
             { no: 6, name: "pprof", kind: "scalar", oneof: "report", T: 12 /*ScalarType.BYTES*/ },
-            { no: 7, name: "top", kind: "message", oneof: "report", T: () => Top }
+            { no: 7, name: "top", kind: "message", oneof: "report", T: () => Top },
+            { no: 8, name: "groups", kind: "message", oneof: "report", T: () => GroupedReports },
+            { no: 9, name: "callgraph_neighborhood", kind: "message", oneof: "report", T: () => CallgraphNeighborhood },
+            { no: 10, name: "graph", kind: "message", oneof: "report", T: () => Graph },
+            { no: 11, name: "source", kind: "message", oneof: "report", T: () => Source },
+            { no: 12, name: "disassembly", kind: "message", oneof: "report", T: () => Disassembly }
         ]);
     }
     create(value?: PartialMessage<QueryResponse>): QueryResponse {
@@ -2004,6 +3918,36 @@ class QueryResponse$Type extends MessageType<QueryResponse> {
                         top: Top.internalBinaryRead(reader, reader.uint32(), options, (message.report as any).top)
                     };
                     break;
+                case /* parca.query.v1alpha1.GroupedReports groups */ 8:
+                    message.report = {
+                        oneofKind: "groups",
+                        groups: GroupedReports.internalBinaryRead(reader, reader.uint32(), options, (message.report as any).groups)
+                    };
+                    break;
+                case /* parca.query.v1alpha1.CallgraphNeighborhood callgraph_neighborhood */ 9:
+                    message.report = {
+                        oneofKind: "callgraphNeighborhood",
+                        callgraphNeighborhood: CallgraphNeighborhood.internalBinaryRead(reader, reader.uint32(), options, (message.report as any).callgraphNeighborhood)
+                    };
+                    break;
+                case /* parca.query.v1alpha1.Graph graph */ 10:
+                    message.report = {
+                        oneofKind: "graph",
+                        graph: Graph.internalBinaryRead(reader, reader.uint32(), options, (message.report as any).graph)
+                    };
+                    break;
+                case /* parca.query.v1alpha1.Source source */ 11:
+                    message.report = {
+                        oneofKind: "source",
+                        source: Source.internalBinaryRead(reader, reader.uint32(), options, (message.report as any).source)
+                    };
+                    break;
+                case /* parca.query.v1alpha1.Disassembly disassembly */ 12:
+                    message.report = {
+                        oneofKind: "disassembly",
+                        disassembly: Disassembly.internalBinaryRead(reader, reader.uint32(), options, (message.report as any).disassembly)
+                    };
+                    break;
                 default:
                     let u = options.readUnknownField;
                     if (u === "throw")
@@ -2025,6 +3969,21 @@ class QueryResponse$Type extends MessageType<QueryResponse> {
         /* parca.query.v1alpha1.Top top = 7; */
         if (message.report.oneofKind === "top")
             Top.internalBinaryWrite(message.report.top, writer.tag(7, WireType.LengthDelimited).fork(), options).join();
+        /* parca.query.v1alpha1.GroupedReports groups = 8; */
+        if (message.report.oneofKind === "groups")
+            GroupedReports.internalBinaryWrite(message.report.groups, writer.tag(8, WireType.LengthDelimited).fork(), options).join();
+        /* parca.query.v1alpha1.CallgraphNeighborhood callgraph_neighborhood = 9; */
+        if (message.report.oneofKind === "callgraphNeighborhood")
+            CallgraphNeighborhood.internalBinaryWrite(message.report.callgraphNeighborhood, writer.tag(9, WireType.LengthDelimited).fork(), options).join();
+        /* parca.query.v1alpha1.Graph graph = 10; */
+        if (message.report.oneofKind === "graph")
+            Graph.internalBinaryWrite(message.report.graph, writer.tag(10, WireType.LengthDelimited).fork(), options).join();
+        /* parca.query.v1alpha1.Source source = 11; */
+        if (message.report.oneofKind === "source")
+            Source.internalBinaryWrite(message.report.source, writer.tag(11, WireType.LengthDelimited).fork(), options).join();
+        /* parca.query.v1alpha1.Disassembly disassembly = 12; */
+        if (message.report.oneofKind === "disassembly")
+            Disassembly.internalBinaryWrite(message.report.disassembly, writer.tag(12, WireType.LengthDelimited).fork(), options).join();
         let u = options.writeUnknownFields;
         if (u !== false)
             (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
@@ -2036,16 +3995,181 @@ class QueryResponse$Type extends MessageType<QueryResponse> {
  */
 export const QueryResponse = new QueryResponse$Type();
 // @generated message type with reflection information, may provide speed optimized methods
+class GroupedReports$Type extends MessageType<GroupedReports> {
+    constructor() {
+        super("parca.query.v1alpha1.GroupedReports", [
+            { no: 1, name: "reports", kind: "message", repeat: 1 /*RepeatType.PACKED*/, T: () => GroupedReport }
+        ]);
+    }
+    create(value?: PartialMessage<GroupedReports>): GroupedReports {
+        const message = { reports: [] };
+        globalThis.Object.defineProperty(message, MESSAGE_TYPE, { enumerable: false, value: this });
+        if (value !== undefined)
+            reflectionMergePartial<GroupedReports>(this, message, value);
+        return message;
+    }
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: GroupedReports): GroupedReports {
+        let message = target ?? this.create(), end = reader.pos + length;
+        while (reader.pos < end) {
+            let [fieldNo, wireType] = reader.tag();
+            switch (fieldNo) {
+                case /* repeated parca.query.v1alpha1.GroupedReport reports */ 1:
+                    message.reports.push(GroupedReport.internalBinaryRead(reader, reader.uint32(), options));
+                    break;
+                default:
+                    let u = options.readUnknownField;
+                    if (u === "throw")
+                        throw new globalThis.Error(`Unknown field ${fieldNo} (wire type ${wireType}) for ${this.typeName}`);
+                    let d = reader.skip(wireType);
+                    if (u !== false)
+                        (u === true ? UnknownFieldHandler.onRead : u)(this.typeName, message, fieldNo, wireType, d);
+            }
+        }
+        return message;
+    }
+    internalBinaryWrite(message: GroupedReports, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* repeated parca.query.v1alpha1.GroupedReport reports = 1; */
+        for (let i = 0; i < message.reports.length; i++)
+            GroupedReport.internalBinaryWrite(message.reports[i], writer.tag(1, WireType.LengthDelimited).fork(), options).join();
+        let u = options.writeUnknownFields;
+        if (u !== false)
+            (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
+        return writer;
+    }
+}
+/**
+ * @generated MessageType for protobuf message parca.query.v1alpha1.GroupedReports
+ */
+export const GroupedReports = new GroupedReports$Type();
+// @generated message type with reflection information, may provide speed optimized methods
+class GroupedReport$Type extends MessageType<GroupedReport> {
+    constructor() {
+        super("parca.query.v1alpha1.GroupedReport", [
+            { no: 1, name: "labels", kind: "message", repeat: 1 /*RepeatType.PACKED*/, T: () => Label },
+            { no: 2, name: "flamegraph", kind: "message", oneof: "report", T: () => Flamegraph },
+            { no: 3, name: "pprof", kind: "scalar", oneof: "report", T: 12 /*ScalarType.BYTES*/ },
+            { no: 4, name: "top", kind: "message", oneof: "report", T: () => Top },
+            { no: 5, name: "callgraph_neighborhood", kind: "message", oneof: "report", T: () => CallgraphNeighborhood },
+            { no: 6, name: "graph", kind: "message", oneof: "report", T: () => Graph },
+            { no: 7, name: "source", kind: "message", oneof: "report", T: () => Source },
+            { no: 8, name: "disassembly", kind: "message", oneof: "report", T: () => Disassembly }
+        ]);
+    }
+    create(value?: PartialMessage<GroupedReport>): GroupedReport {
+        const message = { labels: [], report: { oneofKind: undefined } };
+        globalThis.Object.defineProperty(message, MESSAGE_TYPE, { enumerable: false, value: this });
+        if (value !== undefined)
+            reflectionMergePartial<GroupedReport>(this, message, value);
+        return message;
+    }
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: GroupedReport): GroupedReport {
+        let message = target ?? this.create(), end = reader.pos + length;
+        while (reader.pos < end) {
+            let [fieldNo, wireType] = reader.tag();
+            switch (fieldNo) {
+                case /* repeated parca.profilestore.v1alpha1.Label labels */ 1:
+                    message.labels.push(Label.internalBinaryRead(reader, reader.uint32(), options));
+                    break;
+                case /* parca.query.v1alpha1.Flamegraph flamegraph */ 2:
+                    message.report = {
+                        oneofKind: "flamegraph",
+                        flamegraph: Flamegraph.internalBinaryRead(reader, reader.uint32(), options, (message.report as any).flamegraph)
+                    };
+                    break;
+                case /* bytes pprof */ 3:
+                    message.report = {
+                        oneofKind: "pprof",
+                        pprof: reader.bytes()
+                    };
+                    break;
+                case /* parca.query.v1alpha1.Top top */ 4:
+                    message.report = {
+                        oneofKind: "top",
+                        top: Top.internalBinaryRead(reader, reader.uint32(), options, (message.report as any).top)
+                    };
+                    break;
+                case /* parca.query.v1alpha1.CallgraphNeighborhood callgraph_neighborhood */ 5:
+                    message.report = {
+                        oneofKind: "callgraphNeighborhood",
+                        callgraphNeighborhood: CallgraphNeighborhood.internalBinaryRead(reader, reader.uint32(), options, (message.report as any).callgraphNeighborhood)
+                    };
+                    break;
+                case /* parca.query.v1alpha1.Graph graph */ 6:
+                    message.report = {
+                        oneofKind: "graph",
+                        graph: Graph.internalBinaryRead(reader, reader.uint32(), options, (message.report as any).graph)
+                    };
+                    break;
+                case /* parca.query.v1alpha1.Source source */ 7:
+                    message.report = {
+                        oneofKind: "source",
+                        source: Source.internalBinaryRead(reader, reader.uint32(), options, (message.report as any).source)
+                    };
+                    break;
+                case /* parca.query.v1alpha1.Disassembly disassembly */ 8:
+                    message.report = {
+                        oneofKind: "disassembly",
+                        disassembly: Disassembly.internalBinaryRead(reader, reader.uint32(), options, (message.report as any).disassembly)
+                    };
+                    break;
+                default:
+                    let u = options.readUnknownField;
+                    if (u === "throw")
+                        throw new globalThis.Error(`Unknown field ${fieldNo} (wire type ${wireType}) for ${this.typeName}`);
+                    let d = reader.skip(wireType);
+                    if (u !== false)
+                        (u === true ? UnknownFieldHandler.onRead : u)(this.typeName, message, fieldNo, wireType, d);
+            }
+        }
+        return message;
+    }
+    internalBinaryWrite(message: GroupedReport, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* repeated parca.profilestore.v1alpha1.Label labels = 1; */
+        for (let i = 0; i < message.labels.length; i++)
+            Label.internalBinaryWrite(message.labels[i], writer.tag(1, WireType.LengthDelimited).fork(), options).join();
+        /* parca.query.v1alpha1.Flamegraph flamegraph = 2; */
+        if (message.report.oneofKind === "flamegraph")
+            Flamegraph.internalBinaryWrite(message.report.flamegraph, writer.tag(2, WireType.LengthDelimited).fork(), options).join();
+        /* bytes pprof = 3; */
+        if (message.report.oneofKind === "pprof")
+            writer.tag(3, WireType.LengthDelimited).bytes(message.report.pprof);
+        /* parca.query.v1alpha1.Top top = 4; */
+        if (message.report.oneofKind === "top")
+            Top.internalBinaryWrite(message.report.top, writer.tag(4, WireType.LengthDelimited).fork(), options).join();
+        /* parca.query.v1alpha1.CallgraphNeighborhood callgraph_neighborhood = 5; */
+        if (message.report.oneofKind === "callgraphNeighborhood")
+            CallgraphNeighborhood.internalBinaryWrite(message.report.callgraphNeighborhood, writer.tag(5, WireType.LengthDelimited).fork(), options).join();
+        /* parca.query.v1alpha1.Graph graph = 6; */
+        if (message.report.oneofKind === "graph")
+            Graph.internalBinaryWrite(message.report.graph, writer.tag(6, WireType.LengthDelimited).fork(), options).join();
+        /* parca.query.v1alpha1.Source source = 7; */
+        if (message.report.oneofKind === "source")
+            Source.internalBinaryWrite(message.report.source, writer.tag(7, WireType.LengthDelimited).fork(), options).join();
+        /* parca.query.v1alpha1.Disassembly disassembly = 8; */
+        if (message.report.oneofKind === "disassembly")
+            Disassembly.internalBinaryWrite(message.report.disassembly, writer.tag(8, WireType.LengthDelimited).fork(), options).join();
+        let u = options.writeUnknownFields;
+        if (u !== false)
+            (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
+        return writer;
+    }
+}
+/**
+ * @generated MessageType for protobuf message parca.query.v1alpha1.GroupedReport
+ */
+export const GroupedReport = new GroupedReport$Type();
+// @generated message type with reflection information, may provide speed optimized methods
 class SeriesRequest$Type extends MessageType<SeriesRequest> {
     constructor() {
         super("parca.query.v1alpha1.SeriesRequest", [
             { no: 1, name: "match", kind: "scalar", repeat: 2 /*RepeatType.UNPACKED*/, T: 9 /*ScalarType.STRING*/ },
             { no: 2, name: "start", kind: "message", T: () => Timestamp },
-            { no: 3, name: "end", kind: "message", T: () => Timestamp }
+            { no: 3, name: "end", kind: "message", T: () => Timestamp },
+            { no: 4, name: "limit", kind: "scalar", T: 13 /*ScalarType.UINT32*/ }
         ]);
     }
     create(value?: PartialMessage<SeriesRequest>): SeriesRequest {
-        const message = { match: [] };
+        const message = { match: [], limit: 0 };
         globalThis.Object.defineProperty(message, MESSAGE_TYPE, { enumerable: false, value: this });
         if (value !== undefined)
             reflectionMergePartial<SeriesRequest>(this, message, value);
@@ -2065,6 +4189,9 @@ class SeriesRequest$Type extends MessageType<SeriesRequest> {
                 case /* google.protobuf.Timestamp end */ 3:
                     message.end = Timestamp.internalBinaryRead(reader, reader.uint32(), options, message.end);
                     break;
+                case /* uint32 limit */ 4:
+                    message.limit = reader.uint32();
+                    break;
                 default:
                     let u = options.readUnknownField;
                     if (u === "throw")
@@ -2086,6 +4213,9 @@ class SeriesRequest$Type extends MessageType<SeriesRequest> {
         /* google.protobuf.Timestamp end = 3; */
         if (message.end)
             Timestamp.internalBinaryWrite(message.end, writer.tag(3, WireType.LengthDelimited).fork(), options).join();
+        /* uint32 limit = 4; */
+        if (message.limit !== 0)
+            writer.tag(4, WireType.Varint).uint32(message.limit);
         let u = options.writeUnknownFields;
         if (u !== false)
             (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
@@ -2099,19 +4229,47 @@ export const SeriesRequest = new SeriesRequest$Type();
 // @generated message type with reflection information, may provide speed optimized methods
 class SeriesResponse$Type extends MessageType<SeriesResponse> {
     constructor() {
-        super("parca.query.v1alpha1.SeriesResponse", []);
+        super("parca.query.v1alpha1.SeriesResponse", [
+            { no: 1, name: "series", kind: "message", repeat: 1 /*RepeatType.PACKED*/, T: () => Series },
+            { no: 2, name: "truncated", kind: "scalar", T: 8 /*ScalarType.BOOL*/ }
+        ]);
     }
     create(value?: PartialMessage<SeriesResponse>): SeriesResponse {
-        const message = {};
+        const message = { series: [], truncated: false };
         globalThis.Object.defineProperty(message, MESSAGE_TYPE, { enumerable: false, value: this });
         if (value !== undefined)
             reflectionMergePartial<SeriesResponse>(this, message, value);
         return message;
     }
     internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: SeriesResponse): SeriesResponse {
-        return target ?? this.create();
+        let message = target ?? this.create(), end = reader.pos + length;
+        while (reader.pos < end) {
+            let [fieldNo, wireType] = reader.tag();
+            switch (fieldNo) {
+                case /* repeated parca.query.v1alpha1.Series series */ 1:
+                    message.series.push(Series.internalBinaryRead(reader, reader.uint32(), options));
+                    break;
+                case /* bool truncated */ 2:
+                    message.truncated = reader.bool();
+                    break;
+                default:
+                    let u = options.readUnknownField;
+                    if (u === "throw")
+                        throw new globalThis.Error(`Unknown field ${fieldNo} (wire type ${wireType}) for ${this.typeName}`);
+                    let d = reader.skip(wireType);
+                    if (u !== false)
+                        (u === true ? UnknownFieldHandler.onRead : u)(this.typeName, message, fieldNo, wireType, d);
+            }
+        }
+        return message;
     }
     internalBinaryWrite(message: SeriesResponse, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* repeated parca.query.v1alpha1.Series series = 1; */
+        for (let i = 0; i < message.series.length; i++)
+            Series.internalBinaryWrite(message.series[i], writer.tag(1, WireType.LengthDelimited).fork(), options).join();
+        /* bool truncated = 2; */
+        if (message.truncated !== false)
+            writer.tag(2, WireType.Varint).bool(message.truncated);
         let u = options.writeUnknownFields;
         if (u !== false)
             (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
@@ -2123,16 +4281,72 @@ class SeriesResponse$Type extends MessageType<SeriesResponse> {
  */
 export const SeriesResponse = new SeriesResponse$Type();
 // @generated message type with reflection information, may provide speed optimized methods
+class Series$Type extends MessageType<Series> {
+    constructor() {
+        super("parca.query.v1alpha1.Series", [
+            { no: 1, name: "labelset", kind: "message", T: () => LabelSet },
+            { no: 2, name: "profile_type", kind: "message", T: () => ProfileType }
+        ]);
+    }
+    create(value?: PartialMessage<Series>): Series {
+        const message = {};
+        globalThis.Object.defineProperty(message, MESSAGE_TYPE, { enumerable: false, value: this });
+        if (value !== undefined)
+            reflectionMergePartial<Series>(this, message, value);
+        return message;
+    }
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: Series): Series {
+        let message = target ?? this.create(), end = reader.pos + length;
+        while (reader.pos < end) {
+            let [fieldNo, wireType] = reader.tag();
+            switch (fieldNo) {
+                case /* parca.profilestore.v1alpha1.LabelSet labelset */ 1:
+                    message.labelset = LabelSet.internalBinaryRead(reader, reader.uint32(), options, message.labelset);
+                    break;
+                case /* parca.query.v1alpha1.ProfileType profile_type */ 2:
+                    message.profileType = ProfileType.internalBinaryRead(reader, reader.uint32(), options, message.profileType);
+                    break;
+                default:
+                    let u = options.readUnknownField;
+                    if (u === "throw")
+                        throw new globalThis.Error(`Unknown field ${fieldNo} (wire type ${wireType}) for ${this.typeName}`);
+                    let d = reader.skip(wireType);
+                    if (u !== false)
+                        (u === true ? UnknownFieldHandler.onRead : u)(this.typeName, message, fieldNo, wireType, d);
+            }
+        }
+        return message;
+    }
+    internalBinaryWrite(message: Series, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* parca.profilestore.v1alpha1.LabelSet labelset = 1; */
+        if (message.labelset)
+            LabelSet.internalBinaryWrite(message.labelset, writer.tag(1, WireType.LengthDelimited).fork(), options).join();
+        /* parca.query.v1alpha1.ProfileType profile_type = 2; */
+        if (message.profileType)
+            ProfileType.internalBinaryWrite(message.profileType, writer.tag(2, WireType.LengthDelimited).fork(), options).join();
+        let u = options.writeUnknownFields;
+        if (u !== false)
+            (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
+        return writer;
+    }
+}
+/**
+ * @generated MessageType for protobuf message parca.query.v1alpha1.Series
+ */
+export const Series = new Series$Type();
+// @generated message type with reflection information, may provide speed optimized methods
 class LabelsRequest$Type extends MessageType<LabelsRequest> {
     constructor() {
         super("parca.query.v1alpha1.LabelsRequest", [
             { no: 1, name: "match", kind: "scalar", repeat: 2 /*RepeatType.UNPACKED*/, T: 9 /*ScalarType.STRING*/ },
             { no: 2, name: "start", kind: "message", T: () => Timestamp },
-            { no: 3, name: "end", kind: "message", T: () => Timestamp }
+            { no: 3, name: "end", kind: "message", T: () => Timestamp },
+            { no: 4, name: "limit", kind: "scalar", T: 13 /*ScalarType.UINT32*/ },
+            { no: 5, name: "prefix", kind: "scalar", T: 9 /*ScalarType.STRING*/ }
         ]);
     }
     create(value?: PartialMessage<LabelsRequest>): LabelsRequest {
-        const message = { match: [] };
+        const message = { match: [], limit: 0, prefix: "" };
         globalThis.Object.defineProperty(message, MESSAGE_TYPE, { enumerable: false, value: this });
         if (value !== undefined)
             reflectionMergePartial<LabelsRequest>(this, message, value);
@@ -2152,6 +4366,12 @@ class LabelsRequest$Type extends MessageType<LabelsRequest> {
                 case /* google.protobuf.Timestamp end */ 3:
                     message.end = Timestamp.internalBinaryRead(reader, reader.uint32(), options, message.end);
                     break;
+                case /* uint32 limit */ 4:
+                    message.limit = reader.uint32();
+                    break;
+                case /* string prefix */ 5:
+                    message.prefix = reader.string();
+                    break;
                 default:
                     let u = options.readUnknownField;
                     if (u === "throw")
@@ -2173,6 +4393,12 @@ class LabelsRequest$Type extends MessageType<LabelsRequest> {
         /* google.protobuf.Timestamp end = 3; */
         if (message.end)
             Timestamp.internalBinaryWrite(message.end, writer.tag(3, WireType.LengthDelimited).fork(), options).join();
+        /* uint32 limit = 4; */
+        if (message.limit !== 0)
+            writer.tag(4, WireType.Varint).uint32(message.limit);
+        /* string prefix = 5; */
+        if (message.prefix !== "")
+            writer.tag(5, WireType.LengthDelimited).string(message.prefix);
         let u = options.writeUnknownFields;
         if (u !== false)
             (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
@@ -2244,11 +4470,13 @@ class ValuesRequest$Type extends MessageType<ValuesRequest> {
             { no: 1, name: "label_name", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
             { no: 2, name: "match", kind: "scalar", repeat: 2 /*RepeatType.UNPACKED*/, T: 9 /*ScalarType.STRING*/ },
             { no: 3, name: "start", kind: "message", T: () => Timestamp },
-            { no: 4, name: "end", kind: "message", T: () => Timestamp }
+            { no: 4, name: "end", kind: "message", T: () => Timestamp },
+            { no: 5, name: "limit", kind: "scalar", T: 13 /*ScalarType.UINT32*/ },
+            { no: 6, name: "prefix", kind: "scalar", T: 9 /*ScalarType.STRING*/ }
         ]);
     }
     create(value?: PartialMessage<ValuesRequest>): ValuesRequest {
-        const message = { labelName: "", match: [] };
+        const message = { labelName: "", match: [], limit: 0, prefix: "" };
         globalThis.Object.defineProperty(message, MESSAGE_TYPE, { enumerable: false, value: this });
         if (value !== undefined)
             reflectionMergePartial<ValuesRequest>(this, message, value);
@@ -2271,6 +4499,12 @@ class ValuesRequest$Type extends MessageType<ValuesRequest> {
                 case /* google.protobuf.Timestamp end */ 4:
                     message.end = Timestamp.internalBinaryRead(reader, reader.uint32(), options, message.end);
                     break;
+                case /* uint32 limit */ 5:
+                    message.limit = reader.uint32();
+                    break;
+                case /* string prefix */ 6:
+                    message.prefix = reader.string();
+                    break;
                 default:
                     let u = options.readUnknownField;
                     if (u === "throw")
@@ -2295,6 +4529,12 @@ class ValuesRequest$Type extends MessageType<ValuesRequest> {
         /* google.protobuf.Timestamp end = 4; */
         if (message.end)
             Timestamp.internalBinaryWrite(message.end, writer.tag(4, WireType.LengthDelimited).fork(), options).join();
+        /* uint32 limit = 5; */
+        if (message.limit !== 0)
+            writer.tag(5, WireType.Varint).uint32(message.limit);
+        /* string prefix = 6; */
+        if (message.prefix !== "")
+            writer.tag(6, WireType.LengthDelimited).string(message.prefix);
         let u = options.writeUnknownFields;
         if (u !== false)
             (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
@@ -2413,6 +4653,107 @@ class ValueType$Type extends MessageType<ValueType> {
  * @generated MessageType for protobuf message parca.query.v1alpha1.ValueType
  */
 export const ValueType = new ValueType$Type();
+// @generated message type with reflection information, may provide speed optimized methods
+class GetRawProfileRequest$Type extends MessageType<GetRawProfileRequest> {
+    constructor() {
+        super("parca.query.v1alpha1.GetRawProfileRequest", [
+            { no: 1, name: "series", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 2, name: "time", kind: "message", T: () => Timestamp }
+        ]);
+    }
+    create(value?: PartialMessage<GetRawProfileRequest>): GetRawProfileRequest {
+        const message = { series: "" };
+        globalThis.Object.defineProperty(message, MESSAGE_TYPE, { enumerable: false, value: this });
+        if (value !== undefined)
+            reflectionMergePartial<GetRawProfileRequest>(this, message, value);
+        return message;
+    }
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: GetRawProfileRequest): GetRawProfileRequest {
+        let message = target ?? this.create(), end = reader.pos + length;
+        while (reader.pos < end) {
+            let [fieldNo, wireType] = reader.tag();
+            switch (fieldNo) {
+                case /* string series */ 1:
+                    message.series = reader.string();
+                    break;
+                case /* google.protobuf.Timestamp time */ 2:
+                    message.time = Timestamp.internalBinaryRead(reader, reader.uint32(), options, message.time);
+                    break;
+                default:
+                    let u = options.readUnknownField;
+                    if (u === "throw")
+                        throw new globalThis.Error(`Unknown field ${fieldNo} (wire type ${wireType}) for ${this.typeName}`);
+                    let d = reader.skip(wireType);
+                    if (u !== false)
+                        (u === true ? UnknownFieldHandler.onRead : u)(this.typeName, message, fieldNo, wireType, d);
+            }
+        }
+        return message;
+    }
+    internalBinaryWrite(message: GetRawProfileRequest, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* string series = 1; */
+        if (message.series !== "")
+            writer.tag(1, WireType.LengthDelimited).string(message.series);
+        /* google.protobuf.Timestamp time = 2; */
+        if (message.time)
+            Timestamp.internalBinaryWrite(message.time, writer.tag(2, WireType.LengthDelimited).fork(), options).join();
+        let u = options.writeUnknownFields;
+        if (u !== false)
+            (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
+        return writer;
+    }
+}
+/**
+ * @generated MessageType for protobuf message parca.query.v1alpha1.GetRawProfileRequest
+ */
+export const GetRawProfileRequest = new GetRawProfileRequest$Type();
+// @generated message type with reflection information, may provide speed optimized methods
+class GetRawProfileResponse$Type extends MessageType<GetRawProfileResponse> {
+    constructor() {
+        super("parca.query.v1alpha1.GetRawProfileResponse", [
+            { no: 1, name: "raw_profile", kind: "scalar", T: 12 /*ScalarType.BYTES*/ }
+        ]);
+    }
+    create(value?: PartialMessage<GetRawProfileResponse>): GetRawProfileResponse {
+        const message = { rawProfile: new Uint8Array(0) };
+        globalThis.Object.defineProperty(message, MESSAGE_TYPE, { enumerable: false, value: this });
+        if (value !== undefined)
+            reflectionMergePartial<GetRawProfileResponse>(this, message, value);
+        return message;
+    }
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: GetRawProfileResponse): GetRawProfileResponse {
+        let message = target ?? this.create(), end = reader.pos + length;
+        while (reader.pos < end) {
+            let [fieldNo, wireType] = reader.tag();
+            switch (fieldNo) {
+                case /* bytes raw_profile */ 1:
+                    message.rawProfile = reader.bytes();
+                    break;
+                default:
+                    let u = options.readUnknownField;
+                    if (u === "throw")
+                        throw new globalThis.Error(`Unknown field ${fieldNo} (wire type ${wireType}) for ${this.typeName}`);
+                    let d = reader.skip(wireType);
+                    if (u !== false)
+                        (u === true ? UnknownFieldHandler.onRead : u)(this.typeName, message, fieldNo, wireType, d);
+            }
+        }
+        return message;
+    }
+    internalBinaryWrite(message: GetRawProfileResponse, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* bytes raw_profile = 1; */
+        if (message.rawProfile.length)
+            writer.tag(1, WireType.LengthDelimited).bytes(message.rawProfile);
+        let u = options.writeUnknownFields;
+        if (u !== false)
+            (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
+        return writer;
+    }
+}
+/**
+ * @generated MessageType for protobuf message parca.query.v1alpha1.GetRawProfileResponse
+ */
+export const GetRawProfileResponse = new GetRawProfileResponse$Type();
 /**
  * @generated ServiceType for protobuf service parca.query.v1alpha1.QueryService
  */
@@ -2422,5 +4763,6 @@ export const QueryService = new ServiceType("parca.query.v1alpha1.QueryService",
     { name: "Series", options: { "google.api.http": { get: "/profiles/series" } }, I: SeriesRequest, O: SeriesResponse },
     { name: "ProfileTypes", options: { "google.api.http": { get: "/profiles/types" } }, I: ProfileTypesRequest, O: ProfileTypesResponse },
     { name: "Labels", options: { "google.api.http": { get: "/profiles/labels" } }, I: LabelsRequest, O: LabelsResponse },
-    { name: "Values", options: { "google.api.http": { get: "/profiles/labels/{label_name}/values" } }, I: ValuesRequest, O: ValuesResponse }
+    { name: "Values", options: { "google.api.http": { get: "/profiles/labels/{label_name}/values" } }, I: ValuesRequest, O: ValuesResponse },
+    { name: "GetRawProfile", options: { "google.api.http": { get: "/profiles/raw" } }, I: GetRawProfileRequest, O: GetRawProfileResponse }
 ]);
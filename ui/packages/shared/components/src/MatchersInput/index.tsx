@@ -47,7 +47,7 @@ export const useLabelNames = (client: QueryServiceClient): ILabelNamesResult =>
   const metadata = useGrpcMetadata();
 
   useEffect(() => {
-    const call = client.labels({match: []}, {meta: metadata});
+    const call = client.labels({match: [], limit: 0, prefix: ''}, {meta: metadata});
 
     call.response
       .then(response => setResult({response: response}))
@@ -105,7 +105,10 @@ const MatchersInput = ({
   const {response: labelNamesResponse, error: labelNamesError} = useLabelNames(queryClient);
 
   const getLabelNameValues = (labelName: string) => {
-    const call = queryClient.values({labelName: labelName, match: []}, {meta: metadata});
+    const call = queryClient.values(
+      {labelName: labelName, match: [], limit: 0, prefix: ''},
+      {meta: metadata}
+    );
 
     call.response
       .then(response => setLabelValuesResponse(response.labelValues))
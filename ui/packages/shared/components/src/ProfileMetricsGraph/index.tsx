@@ -1,7 +1,13 @@
 import React, {useState, useEffect} from 'react';
 import MetricsGraph from '../MetricsGraph';
 import {ProfileSelection, SingleProfileSelection} from '@parca/profile';
-import {QueryServiceClient, QueryRangeResponse, Label, Timestamp} from '@parca/client';
+import {
+  QueryServiceClient,
+  QueryRangeRequest_StepFunction,
+  QueryRangeResponse,
+  Label,
+  Timestamp,
+} from '@parca/client';
 import {RpcError} from '@protobuf-ts/runtime-rpc';
 import {DateTimeRange, Spinner, useGrpcMetadata} from '../';
 import {Query} from '@parca/parser';
@@ -49,6 +55,7 @@ export const useQueryRange = (
         start: Timestamp.fromDate(new Date(start)),
         end: Timestamp.fromDate(new Date(end)),
         limit: 0,
+        stepFunction: QueryRangeRequest_StepFunction.SUM_UNSPECIFIED,
       },
       {meta: metadata}
     );
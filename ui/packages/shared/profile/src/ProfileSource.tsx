@@ -166,6 +166,7 @@ export class SingleProfileSource implements ProfileSource {
         single: {
           time: Timestamp.fromDate(new Date(this.time)),
           query: this.query(),
+          sampleSelector: '',
         },
       },
       mode: ProfileDiffSelection_Mode.SINGLE_UNSPECIFIED,
@@ -179,10 +180,12 @@ export class SingleProfileSource implements ProfileSource {
         single: {
           time: Timestamp.fromDate(new Date(this.time)),
           query: this.query(),
+          sampleSelector: '',
         },
       },
       reportType: QueryRequest_ReportType.FLAMEGRAPH_UNSPECIFIED,
       mode: QueryRequest_Mode.SINGLE_UNSPECIFIED,
+      function: '',
     };
   }
 
@@ -255,6 +258,7 @@ export class ProfileDiffSource implements ProfileSource {
       },
       reportType: QueryRequest_ReportType.FLAMEGRAPH_UNSPECIFIED,
       mode: QueryRequest_Mode.DIFF,
+      function: '',
     };
   }
 
@@ -294,6 +298,8 @@ export class MergedProfileSource implements ProfileSource {
           start: Timestamp.fromDate(new Date(this.from)),
           end: Timestamp.fromDate(new Date(this.to)),
           query: this.query,
+          sampleSelector: '',
+          groupBy: [],
         },
       },
       mode: ProfileDiffSelection_Mode.MERGE,
@@ -308,10 +314,13 @@ export class MergedProfileSource implements ProfileSource {
           start: Timestamp.fromDate(new Date(this.from)),
           end: Timestamp.fromDate(new Date(this.to)),
           query: this.query,
+          sampleSelector: '',
+          groupBy: [],
         },
       },
       reportType: QueryRequest_ReportType.FLAMEGRAPH_UNSPECIFIED,
       mode: QueryRequest_Mode.MERGE,
+      function: '',
     };
   }
 